@@ -171,6 +171,7 @@ var requiredRoles = map[string]string{
 	atc.CheckResource:                 "pipeline-operator",
 	atc.CheckResourceWebHook:          "pipeline-operator",
 	atc.CheckResourceType:             "pipeline-operator",
+	atc.CheckResources:                "pipeline-operator",
 	atc.ListResourceVersions:          "viewer",
 	atc.GetResourceVersion:            "viewer",
 	atc.EnableResourceVersion:         "pipeline-operator",
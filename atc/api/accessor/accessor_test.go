@@ -607,6 +607,11 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.CheckResourceType, atc.CheckResourceType, "pipeline-operator", true),
 		Entry("viewer :: "+atc.CheckResourceType, atc.CheckResourceType, "viewer", false),
 
+		Entry("owner :: "+atc.CheckResources, atc.CheckResources, "owner", true),
+		Entry("member :: "+atc.CheckResources, atc.CheckResources, "member", true),
+		Entry("pipeline-operator :: "+atc.CheckResources, atc.CheckResources, "pipeline-operator", true),
+		Entry("viewer :: "+atc.CheckResources, atc.CheckResources, "viewer", false),
+
 		Entry("owner :: "+atc.ListResourceVersions, atc.ListResourceVersions, "owner", true),
 		Entry("member :: "+atc.ListResourceVersions, atc.ListResourceVersions, "member", true),
 		Entry("pipeline-operator :: "+atc.ListResourceVersions, atc.ListResourceVersions, "pipeline-operator", true),
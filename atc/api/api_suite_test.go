@@ -15,6 +15,7 @@ import (
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/concourse/atc/api/auth"
 	"github.com/concourse/concourse/atc/api/containerserver/containerserverfakes"
+	"github.com/concourse/concourse/atc/api/resourceserver"
 	"github.com/concourse/concourse/atc/api/resourceserver/resourceserverfakes"
 	"github.com/concourse/concourse/atc/auditor/auditorfakes"
 	"github.com/concourse/concourse/atc/creds"
@@ -52,6 +53,8 @@ var (
 	dbBuildFactory          *dbfakes.FakeBuildFactory
 	dbTeam                  *dbfakes.FakeTeam
 	fakeScannerFactory      *resourceserverfakes.FakeScannerFactory
+	webhookLimiter          resourceserver.WebhookLimiter
+	checkReuseWindow        time.Duration
 	fakeSecretManager       *credsfakes.FakeSecrets
 	credsManagers           creds.Managers
 	interceptTimeoutFactory *containerserverfakes.FakeInterceptTimeoutFactory
@@ -117,6 +120,8 @@ var _ = BeforeEach(func() {
 	fakeWorkerClient = new(workerfakes.FakeClient)
 
 	fakeScannerFactory = new(resourceserverfakes.FakeScannerFactory)
+	webhookLimiter = resourceserver.NewWebhookLimiter(1, 2)
+	checkReuseWindow = time.Minute
 
 	fakeVolumeRepository = new(dbfakes.FakeVolumeRepository)
 	fakeContainerRepository = new(dbfakes.FakeContainerRepository)
@@ -178,6 +183,8 @@ var _ = BeforeEach(func() {
 		fakeWorkerClient,
 
 		fakeScannerFactory,
+		webhookLimiter,
+		checkReuseWindow,
 
 		sink,
 
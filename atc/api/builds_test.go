@@ -1055,8 +1055,14 @@ var _ = Describe("Builds API", func() {
 						"foo": db.BuildPreparationStatusUnknown,
 						"bar": db.BuildPreparationStatusBlocking,
 					},
-					InputsSatisfied:     db.BuildPreparationStatusBlocking,
-					MissingInputReasons: db.MissingInputReasons{"some-input": "some-reason"},
+					InputsSatisfied:       db.BuildPreparationStatusBlocking,
+					MissingInputReasons:   db.MissingInputReasons{"some-input": "some-reason"},
+					MissingWorker:         db.BuildPreparationStatusBlocking,
+					MissingWorkerReasons:  db.MissingWorkerReasons{"tags": "some-worker-reason"},
+					Held:                  db.BuildPreparationStatusNotBlocking,
+					SerialGroups:          db.BuildPreparationStatusNotBlocking,
+					AwaitingManualTrigger: db.BuildPreparationStatusBlocking,
+					FilteredInputs:        map[string]int{"some-input": 3},
 				}
 				dbBuildFactory.BuildReturns(build, true, nil)
 				build.JobNameReturns("job1")
@@ -1192,6 +1198,16 @@ var _ = Describe("Builds API", func() {
 					"inputs_satisfied": "blocking",
 					"missing_input_reasons": {
 						"some-input": "some-reason"
+					},
+					"missing_worker": "blocking",
+					"missing_worker_reasons": {
+						"tags": "some-worker-reason"
+					},
+					"held": "not_blocking",
+					"serial_groups": "not_blocking",
+					"awaiting_manual_trigger": "blocking",
+					"filtered_inputs": {
+						"some-input": 3
 					}
 				}`))
 				})
@@ -10,6 +10,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
 	"github.com/vito/go-sse/sse"
 )
 
@@ -56,6 +57,18 @@ func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 		}
 
 		events, err := build.Events(eventID)
+		if err == db.ErrEventOffsetTooHigh {
+			// the client has already seen every event of the completed build
+			err := writer.WriteEnd(eventID)
+			if err != nil {
+				logger.Info("failed-to-write-end", lager.Data{"error": err.Error()})
+				return
+			}
+
+			<-clientNotifier.CloseNotify()
+			return
+		}
+
 		if err != nil {
 			logger.Error("failed-to-get-build-events", err, lager.Data{"build-id": build.ID(), "start": eventID})
 			w.WriteHeader(http.StatusInternalServerError)
@@ -85,6 +98,13 @@ func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 				return
 			}
 
+			// secret accesses are kept for auditing, not shown to whoever can
+			// see the build; the id still advances so that offsets line up
+			if ev.Event == event.EventTypeSecretAccessed {
+				eventID++
+				continue
+			}
+
 			err = writer.WriteEvent(eventID, ev)
 			if err != nil {
 				logger.Info("failed-to-write-event", lager.Data{"error": err.Error()})
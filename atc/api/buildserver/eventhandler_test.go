@@ -154,6 +154,44 @@ var _ = Describe("Handler", func() {
 				}))
 			})
 
+			Context("when the build has secret-accessed events", func() {
+				BeforeEach(func() {
+					msg := json.RawMessage(`{"path":"/concourse/main/some-secret"}`)
+					returnedEvents = []event.Envelope{
+						fakeEvent(`{"event":1}`),
+						{
+							Data:    &msg,
+							Event:   event.EventTypeSecretAccessed,
+							Version: "1.0",
+						},
+						fakeEvent(`{"event":2}`),
+					}
+				})
+
+				It("leaves them out of the stream without reusing their ids", func() {
+					defer db.Close(response.Body)
+					reader := sse.NewReadCloser(response.Body)
+
+					Expect(reader.Next()).To(Equal(sse.Event{
+						ID:   "0",
+						Name: "event",
+						Data: []byte(`{"data":{"event":1},"event":"fake","version":"42.0"}`),
+					}))
+
+					Expect(reader.Next()).To(Equal(sse.Event{
+						ID:   "2",
+						Name: "event",
+						Data: []byte(`{"data":{"event":2},"event":"fake","version":"42.0"}`),
+					}))
+
+					Expect(reader.Next()).To(Equal(sse.Event{
+						ID:   "3",
+						Name: "end",
+						Data: []byte{},
+					}))
+				})
+			})
+
 			Context("when the Last-Event-ID header is given", func() {
 				BeforeEach(func() {
 					request.Header.Set("Last-Event-ID", "1")
@@ -255,6 +293,36 @@ var _ = Describe("Handler", func() {
 			})
 		})
 
+		Context("when the Last-Event-ID is beyond the events of the completed build", func() {
+			BeforeEach(func() {
+				request.Header.Set("Last-Event-ID", "41")
+				build.EventsReturns(nil, db.ErrEventOffsetTooHigh)
+			})
+
+			JustBeforeEach(func() {
+				var err error
+
+				client := &http.Client{
+					Transport: &http.Transport{},
+				}
+				response, err = client.Do(request)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns 200 with an end event", func() {
+				defer db.Close(response.Body)
+
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				reader := sse.NewReadCloser(response.Body)
+				Expect(reader.Next()).To(Equal(sse.Event{
+					ID:   "42",
+					Name: "end",
+					Data: []byte{},
+				}))
+			})
+		})
+
 		Context("when subscribing to it fails", func() {
 			BeforeEach(func() {
 				build.EventsReturns(nil, errors.New("nope"))
@@ -13,12 +13,18 @@ func BuildPreparation(preparation db.BuildPreparation) atc.BuildPreparation {
 	}
 
 	return atc.BuildPreparation{
-		BuildID:             preparation.BuildID,
-		PausedPipeline:      atc.BuildPreparationStatus(preparation.PausedPipeline),
-		PausedJob:           atc.BuildPreparationStatus(preparation.PausedJob),
-		MaxRunningBuilds:    atc.BuildPreparationStatus(preparation.MaxRunningBuilds),
-		Inputs:              inputs,
-		InputsSatisfied:     atc.BuildPreparationStatus(preparation.InputsSatisfied),
-		MissingInputReasons: atc.MissingInputReasons(preparation.MissingInputReasons),
+		BuildID:               preparation.BuildID,
+		PausedPipeline:        atc.BuildPreparationStatus(preparation.PausedPipeline),
+		PausedJob:             atc.BuildPreparationStatus(preparation.PausedJob),
+		MaxRunningBuilds:      atc.BuildPreparationStatus(preparation.MaxRunningBuilds),
+		Inputs:                inputs,
+		InputsSatisfied:       atc.BuildPreparationStatus(preparation.InputsSatisfied),
+		MissingInputReasons:   atc.MissingInputReasons(preparation.MissingInputReasons),
+		MissingWorker:         atc.BuildPreparationStatus(preparation.MissingWorker),
+		MissingWorkerReasons:  atc.MissingWorkerReasons(preparation.MissingWorkerReasons),
+		Held:                  atc.BuildPreparationStatus(preparation.Held),
+		SerialGroups:          atc.BuildPreparationStatus(preparation.SerialGroups),
+		AwaitingManualTrigger: atc.BuildPreparationStatus(preparation.AwaitingManualTrigger),
+		FilteredInputs:        preparation.FilteredInputs,
 	}
 }
@@ -31,6 +31,7 @@ func Resource(resource db.Resource, showCheckError bool, teamName string) atc.Re
 		CheckSetupError: checkErrString,
 		CheckError:      rcCheckErrString,
 		PinComment:      resource.PinComment(),
+		LastCheckReason: resource.LastCheckReason(),
 	}
 
 	if !resource.LastCheckEndTime().IsZero() {
@@ -18,6 +18,7 @@ import (
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/radar"
 	"github.com/concourse/concourse/atc/radar/radarfakes"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/vars"
@@ -553,6 +554,8 @@ var _ = Describe("Resources API", func() {
 	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", func() {
 		var fakeScanner *radarfakes.FakeScanner
 		var checkRequestBody atc.CheckRequestBody
+		var checkQuery string
+		var ifModifiedSince string
 		var response *http.Response
 
 		BeforeEach(func() {
@@ -560,15 +563,20 @@ var _ = Describe("Resources API", func() {
 			fakeScannerFactory.NewResourceScannerReturns(fakeScanner)
 
 			checkRequestBody = atc.CheckRequestBody{}
+			checkQuery = ""
+			ifModifiedSince = ""
 		})
 
 		JustBeforeEach(func() {
 			reqPayload, err := json.Marshal(checkRequestBody)
 			Expect(err).NotTo(HaveOccurred())
 
-			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check", bytes.NewBuffer(reqPayload))
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check"+checkQuery, bytes.NewBuffer(reqPayload))
 			Expect(err).NotTo(HaveOccurred())
 			request.Header.Set("Content-Type", "application/json")
+			if ifModifiedSince != "" {
+				request.Header.Set("If-Modified-Since", ifModifiedSince)
+			}
 
 			response, err = client.Do(request)
 			Expect(err).NotTo(HaveOccurred())
@@ -599,18 +607,174 @@ var _ = Describe("Resources API", func() {
 			})
 
 			Context("when it finds the resource", func() {
+				var fakeResource *dbfakes.FakeResource
+
 				BeforeEach(func() {
-					fakeResource := new(dbfakes.FakeResource)
+					fakeResource = new(dbfakes.FakeResource)
 					fakeResource.IDReturns(1)
 					fakePipeline.ResourceReturns(fakeResource, true, nil)
 				})
 
+				Context("when only checking if not checked since a given time", func() {
+					BeforeEach(func() {
+						fakeResource.LastCheckEndTimeReturns(time.Now().Add(-10 * time.Minute))
+					})
+
+					Context("when the resource has been checked since then", func() {
+						BeforeEach(func() {
+							ifModifiedSince = time.Now().Add(-20 * time.Minute).UTC().Format(http.TimeFormat)
+						})
+
+						It("returns 304 without scanning", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNotModified))
+							Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the resource has not been checked since then", func() {
+						BeforeEach(func() {
+							ifModifiedSince = time.Now().Add(-5 * time.Minute).UTC().Format(http.TimeFormat)
+						})
+
+						It("scans", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+						})
+					})
+
+					Context("when the time is malformed", func() {
+						BeforeEach(func() {
+							ifModifiedSince = "yesterday"
+						})
+
+						It("returns 400 without scanning", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+						})
+					})
+				})
+
 				It("injects the proper pipelineDB", func() {
 					Expect(dbTeam.PipelineCallCount()).To(Equal(1))
 					pipelineName := dbTeam.PipelineArgsForCall(0)
 					Expect(pipelineName).To(Equal("a-pipeline"))
 				})
 
+				Context("when the resource was last checked within the reuse window", func() {
+					BeforeEach(func() {
+						fakeResource.LastCheckEndTimeReturns(time.Now().Add(-time.Second))
+					})
+
+					It("reuses the recent check instead of scanning", func() {
+						Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(0))
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					Context("when the last check failed", func() {
+						BeforeEach(func() {
+							fakeResource.CheckErrorReturns(errors.New("nope"))
+						})
+
+						It("scans again", func() {
+							Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+						})
+					})
+
+					Context("when the check is forced", func() {
+						BeforeEach(func() {
+							checkQuery = "?force=true"
+						})
+
+						It("scans anyway", func() {
+							Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+					})
+				})
+
+				Context("when checking recursively", func() {
+					var fakeTypeScanner *radarfakes.FakeScanner
+
+					BeforeEach(func() {
+						checkQuery = "?recursive=true"
+
+						fakeResource.NameReturns("resource-name")
+						fakeResource.TypeReturns("type-b")
+
+						typeA := new(dbfakes.FakeResourceType)
+						typeA.IDReturns(10)
+						typeA.NameReturns("type-a")
+						typeA.TypeReturns("registry-image")
+
+						typeB := new(dbfakes.FakeResourceType)
+						typeB.IDReturns(11)
+						typeB.NameReturns("type-b")
+						typeB.TypeReturns("type-a")
+
+						unrelatedType := new(dbfakes.FakeResourceType)
+						unrelatedType.IDReturns(12)
+						unrelatedType.NameReturns("unrelated")
+						unrelatedType.TypeReturns("registry-image")
+
+						fakePipeline.ResourceTypesReturns(db.ResourceTypes{typeB, unrelatedType, typeA}, nil)
+
+						fakeTypeScanner = new(radarfakes.FakeScanner)
+						fakeScannerFactory.NewResourceTypeScannerReturns(fakeTypeScanner)
+					})
+
+					It("checks the parent types first, then the resource", func() {
+						Expect(fakeTypeScanner.ScanFromVersionCallCount()).To(Equal(2))
+						_, firstTypeID, _ := fakeTypeScanner.ScanFromVersionArgsForCall(0)
+						Expect(firstTypeID).To(Equal(10))
+						_, secondTypeID, _ := fakeTypeScanner.ScanFromVersionArgsForCall(1)
+						Expect(secondTypeID).To(Equal(11))
+
+						Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+					})
+
+					It("returns the checks in the order they ran", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(body).To(MatchJSON(`[
+							{"name":"type-a","resource_type":true,"status":200},
+							{"name":"type-b","resource_type":true,"status":200},
+							{"name":"resource-name","status":200}
+						]`))
+					})
+
+					Context("when a parent type fails to check", func() {
+						BeforeEach(func() {
+							fakeTypeScanner.ScanFromVersionReturnsOnCall(0, errors.New("nope"))
+						})
+
+						It("stops checking and reports the failure", func() {
+							Expect(fakeTypeScanner.ScanFromVersionCallCount()).To(Equal(1))
+							Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+
+							Expect(response.StatusCode).To(Equal(http.StatusMultiStatus))
+
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(body).To(MatchJSON(`[
+								{"name":"type-a","resource_type":true,"status":500,"code":"internal_error","message":"nope"}
+							]`))
+						})
+					})
+				})
+
+				Context("when the resource was last checked before the reuse window", func() {
+					BeforeEach(func() {
+						fakeResource.LastCheckEndTimeReturns(time.Now().Add(-2 * time.Minute))
+					})
+
+					It("scans", func() {
+						Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+					})
+				})
+
 				It("tries to scan with no version specified", func() {
 					Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
 					_, actualResourceID, actualFromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
@@ -639,6 +803,96 @@ var _ = Describe("Resources API", func() {
 					})
 				})
 
+				It("does not override the source", func() {
+					Expect(fakeScanner.ScanWithSourceOverrideCallCount()).To(Equal(0))
+				})
+
+				Context("when checking with a source specified", func() {
+					BeforeEach(func() {
+						checkRequestBody = atc.CheckRequestBody{
+							From: atc.Version{
+								"some-version-key": "some-version-value",
+							},
+							Source: atc.Source{
+								"password": "rotated-password",
+							},
+						}
+					})
+
+					It("scans with the source override", func() {
+						Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(0))
+						Expect(fakeScanner.ScanWithSourceOverrideCallCount()).To(Equal(1))
+						_, actualResourceID, actualFromVersion, actualSource := fakeScanner.ScanWithSourceOverrideArgsForCall(0)
+						Expect(actualResourceID).To(Equal(1))
+						Expect(actualFromVersion).To(Equal(checkRequestBody.From))
+						Expect(actualSource).To(Equal(atc.Source{"password": "rotated-password"}))
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+
+				Context("when checking with a reason", func() {
+					BeforeEach(func() {
+						checkRequestBody = atc.CheckRequestBody{
+							Reason: "triggered by PR #123 webhook",
+						}
+					})
+
+					It("logs the reason for the check", func() {
+						Expect(logger.LogMessages()).To(ContainElement("api.check-resource.checking"))
+
+						for _, log := range logger.Logs() {
+							if log.Message == "api.check-resource.checking" {
+								Expect(log.Data).To(HaveKeyWithValue("resource", "resource-name"))
+								Expect(log.Data).To(HaveKeyWithValue("reason", "triggered by PR #123 webhook"))
+							}
+						}
+					})
+
+					It("records the reason on the resource", func() {
+						Expect(fakeResource.SetLastCheckReasonCallCount()).To(Equal(1))
+						Expect(fakeResource.SetLastCheckReasonArgsForCall(0)).To(Equal("triggered by PR #123 webhook"))
+					})
+
+					It("scans as usual", func() {
+						Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("returns the reason", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"resource": "resource-name",
+							"status": 200,
+							"reason": "triggered by PR #123 webhook"
+						}`))
+					})
+
+					Context("when recording the reason fails", func() {
+						BeforeEach(func() {
+							fakeResource.SetLastCheckReasonReturns(errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+
+						It("does not scan", func() {
+							Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(0))
+						})
+					})
+				})
+
+				Context("when checking without a reason", func() {
+					It("does not record a reason", func() {
+						Expect(fakeResource.SetLastCheckReasonCallCount()).To(Equal(0))
+					})
+				})
+
 				Context("when checking fails with ResourceNotFoundError", func() {
 					BeforeEach(func() {
 						fakeScanner.ScanFromVersionReturns(db.ResourceNotFoundError{})
@@ -667,11 +921,60 @@ var _ = Describe("Resources API", func() {
 
 					It("returns 500", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
-						buf := new(bytes.Buffer)
-						_, err := buf.ReadFrom(response.Body)
-						Expect(err).ToNot(HaveOccurred())
-						body := buf.String()
-						Expect(body).To(Equal("welp"))
+					})
+
+					It("returns an internal error code", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"code": "internal_error",
+							"message": "welp"
+						}`))
+					})
+
+					It("returns application/json", func() {
+						Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+					})
+				})
+
+				Context("when checking the resource fails because its resource type is missing", func() {
+					BeforeEach(func() {
+						fakeScanner.ScanFromVersionReturns(radar.ErrResourceTypeNotFound)
+					})
+
+					It("returns 422", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusUnprocessableEntity))
+					})
+
+					It("returns a resource type not found error code", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"code": "resource_type_not_found",
+							"message": "resource type not found"
+						}`))
+					})
+				})
+
+				Context("when checking the resource fails because its resource type failed to check", func() {
+					BeforeEach(func() {
+						fakeScanner.ScanFromVersionReturns(radar.ErrResourceTypeCheckError)
+					})
+
+					It("returns 502", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadGateway))
+					})
+
+					It("returns a resource type check failed error code", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"code": "resource_type_check_failed",
+							"message": "resource type failed to check"
+						}`))
 					})
 				})
 
@@ -717,6 +1020,150 @@ var _ = Describe("Resources API", func() {
 		})
 	})
 
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/check", func() {
+		var fakeScanner *radarfakes.FakeScanner
+		var checkRequestBody atc.CheckResourcesRequestBody
+		var response *http.Response
+
+		BeforeEach(func() {
+			fakeScanner = new(radarfakes.FakeScanner)
+			fakeScannerFactory.NewResourceScannerReturns(fakeScanner)
+
+			checkRequestBody = atc.CheckResourcesRequestBody{
+				Resources: []string{"some-resource", "missing-resource"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			reqPayload, err := json.Marshal(checkRequestBody)
+			Expect(err).NotTo(HaveOccurred())
+
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/check", bytes.NewBuffer(reqPayload))
+			Expect(err).NotTo(HaveOccurred())
+			request.Header.Set("Content-Type", "application/json")
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when one of the resources is missing", func() {
+				var fakeResource *dbfakes.FakeResource
+
+				BeforeEach(func() {
+					fakeResource = new(dbfakes.FakeResource)
+					fakeResource.IDReturns(1)
+
+					fakePipeline.ResourceStub = func(name string) (db.Resource, bool, error) {
+						if name == "some-resource" {
+							return fakeResource, true, nil
+						}
+
+						return nil, false, nil
+					}
+				})
+
+				It("checks the resource that was found", func() {
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+					_, actualResourceID, actualFromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+					Expect(actualResourceID).To(Equal(1))
+					Expect(actualFromVersion).To(BeNil())
+				})
+
+				It("returns 207", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusMultiStatus))
+				})
+
+				It("returns the status of each check", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"resource": "some-resource",
+							"status": 200
+						},
+						{
+							"resource": "missing-resource",
+							"status": 404,
+							"code": "resource_not_found"
+						}
+					]`))
+				})
+
+				It("returns application/json", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				Context("when checking with a reason", func() {
+					BeforeEach(func() {
+						checkRequestBody.Reason = "nightly rotation"
+					})
+
+					It("records the reason on the resource that was found", func() {
+						Expect(fakeResource.SetLastCheckReasonCallCount()).To(Equal(1))
+						Expect(fakeResource.SetLastCheckReasonArgsForCall(0)).To(Equal("nightly rotation"))
+					})
+
+					It("returns the reason with the status of the check", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`[
+							{
+								"resource": "some-resource",
+								"status": 200,
+								"reason": "nightly rotation"
+							},
+							{
+								"resource": "missing-resource",
+								"status": 404,
+								"code": "resource_not_found"
+							}
+						]`))
+					})
+				})
+			})
+
+			Context("when every resource checks successfully", func() {
+				BeforeEach(func() {
+					fakeResource := new(dbfakes.FakeResource)
+					fakeResource.IDReturns(1)
+					fakePipeline.ResourceReturns(fakeResource, true, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when no resources are given", func() {
+				BeforeEach(func() {
+					checkRequestBody = atc.CheckResourcesRequestBody{}
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resource-types", func() {
 		var response *http.Response
 
@@ -1306,6 +1753,7 @@ var _ = Describe("Resources API", func() {
 		var (
 			fakeScanner               *radarfakes.FakeScanner
 			checkRequestBody          atc.CheckRequestBody
+			webhookQuery              string
 			response                  *http.Response
 			fakeResource              *dbfakes.FakeResource
 			fakeResourceConfig        *dbfakes.FakeResourceConfig
@@ -1317,6 +1765,7 @@ var _ = Describe("Resources API", func() {
 			fakeScanner = new(radarfakes.FakeScanner)
 			fakeScannerFactory.NewResourceScannerReturns(fakeScanner)
 			checkRequestBody = atc.CheckRequestBody{}
+			webhookQuery = ""
 
 			fakeResource = new(dbfakes.FakeResource)
 			fakeResource.NameReturns("resource-name")
@@ -1330,7 +1779,7 @@ var _ = Describe("Resources API", func() {
 			reqPayload, err := json.Marshal(checkRequestBody)
 			Expect(err).NotTo(HaveOccurred())
 
-			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check/webhook?webhook_token=fake-token", bytes.NewBuffer(reqPayload))
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check/webhook?webhook_token=fake-token"+webhookQuery, bytes.NewBuffer(reqPayload))
 			Expect(err).NotTo(HaveOccurred())
 			request.Header.Set("Content-Type", "application/json")
 
@@ -1362,6 +1811,35 @@ var _ = Describe("Resources API", func() {
 				Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("resource-name"))
 			})
 
+			It("does not record a reason", func() {
+				Expect(fakeResource.SetLastCheckReasonCallCount()).To(Equal(0))
+			})
+
+			Context("when the webhook gives a reason", func() {
+				BeforeEach(func() {
+					webhookQuery = "&reason=push+to+master"
+				})
+
+				It("records the reason on the resource", func() {
+					Expect(fakeResource.SetLastCheckReasonCallCount()).To(Equal(1))
+					Expect(fakeResource.SetLastCheckReasonArgsForCall(0)).To(Equal("push to master"))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				Context("when recording the reason fails", func() {
+					BeforeEach(func() {
+						fakeResource.SetLastCheckReasonReturns(errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
 			Context("when finding the resource succeeds", func() {
 				BeforeEach(func() {
 					fakePipeline.ResourceReturns(fakeResource, true, nil)
@@ -1486,6 +1964,54 @@ var _ = Describe("Resources API", func() {
 				})
 			})
 
+			Context("when the webhook is called in rapid succession", func() {
+				var checkResource = func(path string) *http.Response {
+					request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/"+path, bytes.NewBufferString("{}"))
+					Expect(err).NotTo(HaveOccurred())
+					request.Header.Set("Content-Type", "application/json")
+
+					response, err := client.Do(request)
+					Expect(err).NotTo(HaveOccurred())
+
+					return response
+				}
+
+				It("returns 429 once the resource's burst is used up", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					response := checkResource("check/webhook?webhook_token=fake-token")
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					response = checkResource("check/webhook?webhook_token=fake-token")
+					Expect(response.StatusCode).To(Equal(http.StatusTooManyRequests))
+					Expect(response.Header.Get("Retry-After")).To(Equal("1"))
+
+					Expect(fakeScannerFactory.NewResourceScannerCallCount()).To(BeNumerically("<=", 2))
+				})
+
+				It("does not limit other resources", func() {
+					checkResource("check/webhook?webhook_token=fake-token")
+					checkResource("check/webhook?webhook_token=fake-token")
+
+					fakeResource.IDReturns(11)
+
+					response := checkResource("check/webhook?webhook_token=fake-token")
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("does not limit checks through the authenticated route", func() {
+					fakeaccess.IsAuthenticatedReturns(true)
+					fakeaccess.IsAuthorizedReturns(true)
+
+					checkResource("check/webhook?webhook_token=fake-token")
+					checkResource("check/webhook?webhook_token=fake-token")
+
+					for i := 0; i < 3; i++ {
+						response := checkResource("check")
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					}
+				})
+			})
 		})
 
 		Context("when unauthorized", func() {
@@ -3,10 +3,12 @@ package resourceserver
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/radar"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/google/jsonapi"
 	"github.com/tedsuo/rata"
@@ -39,9 +41,63 @@ func (s *Server) CheckResource(dbPipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		if reqBody.Reason != "" {
+			logger.Info("checking", lager.Data{
+				"resource": resourceName,
+				"reason":   reqBody.Reason,
+			})
+
+			err = dbResource.SetLastCheckReason(reqBody.Reason)
+			if err != nil {
+				logger.Error("failed-to-set-last-check-reason", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		if r.URL.Query().Get("recursive") == "true" {
+			s.checkRecursively(logger, w, dbPipeline, dbResource, reqBody)
+			return
+		}
+
+		// callers can ask to check only if the resource has not been checked
+		// since a given time
+		if header := r.Header.Get("If-Modified-Since"); header != "" {
+			since, err := http.ParseTime(header)
+			if err != nil {
+				logger.Info("malformed-if-modified-since", lager.Data{"error": err.Error()})
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			if dbResource.LastCheckEndTime().After(since) {
+				logger.Debug("checked-since", lager.Data{
+					"resource":       resourceName,
+					"since":          since,
+					"last-check-end": dbResource.LastCheckEndTime(),
+				})
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+
+		if s.recentlyChecked(dbResource) && reqBody.From == nil && reqBody.Source == nil && r.URL.Query().Get("force") != "true" {
+			logger.Debug("reusing-recent-check", lager.Data{
+				"resource":       resourceName,
+				"last-check-end": dbResource.LastCheckEndTime(),
+			})
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
 		scanner := s.scannerFactory.NewResourceScanner(dbPipeline)
 
-		err = scanner.ScanFromVersion(logger, dbResource.ID(), reqBody.From)
+		if reqBody.Source != nil {
+			err = scanner.ScanWithSourceOverride(logger, dbResource.ID(), reqBody.From, reqBody.Source)
+		} else {
+			err = scanner.ScanFromVersion(logger, dbResource.ID(), reqBody.From)
+		}
+
 		switch scanErr := err.(type) {
 		case resource.ErrResourceScriptFailed:
 			checkResponseBody := atc.CheckResponseBody{
@@ -68,10 +124,63 @@ func (s *Server) CheckResource(dbPipeline db.Pipeline) http.Handler {
 				Status: "400",
 			}})
 		case error:
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(err.Error()))
+			status, code := checkErrorStatus(err)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			err = json.NewEncoder(w).Encode(atc.CheckErrorResponseBody{
+				Code:    code,
+				Message: err.Error(),
+			})
+			if err != nil {
+				logger.Error("failed-to-encode-check-error-response-body", err)
+			}
 		default:
+			if reqBody.Reason == "" {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusOK)
+			err = json.NewEncoder(w).Encode(atc.CheckResourcesResult{
+				Resource: resourceName,
+				Status:   http.StatusOK,
+				Reason:   reqBody.Reason,
+			})
+			if err != nil {
+				logger.Error("failed-to-encode-check-result", err)
+			}
 		}
 	})
 }
+
+// recentlyChecked returns whether the resource's last check succeeded within
+// the server's check reuse window, in which case a requested check can be
+// skipped.
+func (s *Server) recentlyChecked(dbResource db.Resource) bool {
+	if s.checkReuseWindow == 0 || dbResource.CheckError() != nil {
+		return false
+	}
+
+	lastCheckEnd := dbResource.LastCheckEndTime()
+	if lastCheckEnd.IsZero() {
+		return false
+	}
+
+	return time.Since(lastCheckEnd) < s.checkReuseWindow
+}
+
+// checkErrorStatus distinguishes failures caused by the pipeline's
+// configuration (which the client can fix) from failures of a parent resource
+// type's check and from internal errors.
+func checkErrorStatus(err error) (int, atc.CheckErrorCode) {
+	switch err {
+	case radar.ErrResourceTypeNotFound:
+		return http.StatusUnprocessableEntity, atc.CheckErrorCodeResourceTypeNotFound
+	case radar.ErrResourceTypeCheckError:
+		return http.StatusBadGateway, atc.CheckErrorCodeResourceTypeCheckFailed
+	default:
+		return http.StatusInternalServerError, atc.CheckErrorCodeInternal
+	}
+}
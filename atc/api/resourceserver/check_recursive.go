@@ -0,0 +1,120 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/resource"
+)
+
+// checkRecursively checks each of the resource's custom resource types,
+// starting with the one furthest up the chain, and then the resource itself.
+// The checks stop at the first failure. Every check that ran gets its own
+// result; if any of them failed the response is 207 Multi-Status.
+func (s *Server) checkRecursively(logger lager.Logger, w http.ResponseWriter, dbPipeline db.Pipeline, dbResource db.Resource, reqBody atc.CheckRequestBody) {
+	resourceTypes, err := dbPipeline.ResourceTypes()
+	if err != nil {
+		logger.Error("failed-to-get-resource-types", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	typeScanner := s.scannerFactory.NewResourceTypeScanner(dbPipeline)
+
+	status := http.StatusOK
+	results := []atc.RecursiveCheckResult{}
+	for _, resourceType := range parentTypes(resourceTypes, dbResource.Type()) {
+		result := atc.RecursiveCheckResult{
+			Name:         resourceType.Name(),
+			ResourceType: true,
+		}
+
+		recursiveCheckResult(&result, typeScanner.ScanFromVersion(logger, resourceType.ID(), nil))
+
+		results = append(results, result)
+
+		if result.Status != http.StatusOK {
+			status = http.StatusMultiStatus
+			break
+		}
+	}
+
+	if status == http.StatusOK {
+		scanner := s.scannerFactory.NewResourceScanner(dbPipeline)
+
+		if reqBody.Source != nil {
+			err = scanner.ScanWithSourceOverride(logger, dbResource.ID(), reqBody.From, reqBody.Source)
+		} else {
+			err = scanner.ScanFromVersion(logger, dbResource.ID(), reqBody.From)
+		}
+
+		result := atc.RecursiveCheckResult{Name: dbResource.Name()}
+		recursiveCheckResult(&result, err)
+
+		if result.Status != http.StatusOK {
+			status = http.StatusMultiStatus
+		}
+
+		results = append(results, result)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	err = json.NewEncoder(w).Encode(results)
+	if err != nil {
+		logger.Error("failed-to-encode-check-results", err)
+	}
+}
+
+// parentTypes returns the chain of pipeline resource types that the given
+// type depends on, starting with the one furthest up the chain. A type whose
+// type is its own name refers to the base type of that name.
+func parentTypes(resourceTypes db.ResourceTypes, typeName string) []db.ResourceType {
+	byName := map[string]db.ResourceType{}
+	for _, resourceType := range resourceTypes {
+		byName[resourceType.Name()] = resourceType
+	}
+
+	chain := []db.ResourceType{}
+	seen := map[string]bool{}
+
+	for !seen[typeName] {
+		seen[typeName] = true
+
+		resourceType, found := byName[typeName]
+		if !found {
+			break
+		}
+
+		chain = append([]db.ResourceType{resourceType}, chain...)
+		typeName = resourceType.Type()
+	}
+
+	return chain
+}
+
+func recursiveCheckResult(result *atc.RecursiveCheckResult, err error) {
+	result.Status = http.StatusOK
+
+	switch scanErr := err.(type) {
+	case resource.ErrResourceScriptFailed:
+		result.Status = http.StatusBadRequest
+		result.Code = atc.CheckErrorCodeResourceCheckFailed
+		result.ExitStatus = scanErr.ExitStatus
+		result.Stderr = scanErr.Stderr
+	case db.ResourceNotFoundError:
+		result.Status = http.StatusNotFound
+		result.Code = atc.CheckErrorCodeResourceNotFound
+	case db.ResourceTypeNotFoundError:
+		result.Status = http.StatusNotFound
+		result.Code = atc.CheckErrorCodeResourceTypeNotFound
+		result.Message = err.Error()
+	case error:
+		result.Status, result.Code = checkErrorStatus(err)
+		result.Message = err.Error()
+	}
+}
@@ -0,0 +1,105 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/resource"
+)
+
+// CheckResources checks each of the named resources of the pipeline in turn.
+// Every resource gets its own result; if any of them did not check
+// successfully the response is 207 Multi-Status.
+func (s *Server) CheckResources(dbPipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("check-resources")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqBody atc.CheckResourcesRequestBody
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if len(reqBody.Resources) == 0 {
+			logger.Info("no-resources-given")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if reqBody.Reason != "" {
+			logger.Info("checking", lager.Data{
+				"resources": reqBody.Resources,
+				"reason":    reqBody.Reason,
+			})
+		}
+
+		scanner := s.scannerFactory.NewResourceScanner(dbPipeline)
+
+		status := http.StatusOK
+		results := []atc.CheckResourcesResult{}
+		for _, resourceName := range reqBody.Resources {
+			result := atc.CheckResourcesResult{
+				Resource: resourceName,
+				Status:   http.StatusOK,
+			}
+
+			dbResource, found, err := dbPipeline.Resource(resourceName)
+			if err != nil {
+				logger.Error("failed-to-get-resource", err, lager.Data{"resource": resourceName})
+				result.Status = http.StatusInternalServerError
+				result.Code = atc.CheckErrorCodeInternal
+				result.Message = err.Error()
+			} else if !found {
+				logger.Debug("resource-not-found", lager.Data{"resource": resourceName})
+				result.Status = http.StatusNotFound
+				result.Code = atc.CheckErrorCodeResourceNotFound
+			} else {
+				if reqBody.Reason != "" {
+					err = dbResource.SetLastCheckReason(reqBody.Reason)
+				}
+
+				if err == nil {
+					result.Reason = reqBody.Reason
+					err = scanner.ScanFromVersion(logger, dbResource.ID(), nil)
+				}
+
+				switch scanErr := err.(type) {
+				case resource.ErrResourceScriptFailed:
+					result.Status = http.StatusBadRequest
+					result.Code = atc.CheckErrorCodeResourceCheckFailed
+					result.ExitStatus = scanErr.ExitStatus
+					result.Stderr = scanErr.Stderr
+				case db.ResourceNotFoundError:
+					result.Status = http.StatusNotFound
+					result.Code = atc.CheckErrorCodeResourceNotFound
+				case db.ResourceTypeNotFoundError:
+					result.Status = http.StatusBadRequest
+					result.Code = atc.CheckErrorCodeResourceTypeNotFound
+					result.Message = err.Error()
+				case error:
+					result.Status, result.Code = checkErrorStatus(err)
+					result.Message = err.Error()
+				}
+			}
+
+			if result.Status != http.StatusOK {
+				status = http.StatusMultiStatus
+			}
+
+			results = append(results, result)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+
+		err = json.NewEncoder(w).Encode(results)
+		if err != nil {
+			logger.Error("failed-to-encode-check-results", err)
+		}
+	})
+}
@@ -2,7 +2,9 @@ package resourceserver
 
 import (
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
@@ -46,6 +48,25 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		allowed, retryAfter := s.webhookLimiter.Allow(pipelineResource.ID())
+		if !allowed {
+			logger.Info("rate-limited", lager.Data{"resource-name": resourceName, "retry-after": retryAfter.String()})
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		// webhooks can say why they asked for the check, e.g. which event of
+		// the service calling them it was for
+		if reason := r.URL.Query().Get("reason"); reason != "" {
+			err = pipelineResource.SetLastCheckReason(reason)
+			if err != nil {
+				logger.Error("failed-to-set-last-check-reason", err, lager.Data{"resource-name": resourceName})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
 		go func() {
 			var fromVersion atc.Version
 			resourceConfigID := pipelineResource.ResourceConfigID()
@@ -0,0 +1,116 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package resourceserverfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/api/resourceserver"
+)
+
+type FakeWebhookLimiter struct {
+	AllowStub        func(int) (bool, time.Duration)
+	allowMutex       sync.RWMutex
+	allowArgsForCall []struct {
+		arg1 int
+	}
+	allowReturns struct {
+		result1 bool
+		result2 time.Duration
+	}
+	allowReturnsOnCall map[int]struct {
+		result1 bool
+		result2 time.Duration
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeWebhookLimiter) Allow(arg1 int) (bool, time.Duration) {
+	fake.allowMutex.Lock()
+	ret, specificReturn := fake.allowReturnsOnCall[len(fake.allowArgsForCall)]
+	fake.allowArgsForCall = append(fake.allowArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("Allow", []interface{}{arg1})
+	fake.allowMutex.Unlock()
+	if fake.AllowStub != nil {
+		return fake.AllowStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.allowReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWebhookLimiter) AllowCallCount() int {
+	fake.allowMutex.RLock()
+	defer fake.allowMutex.RUnlock()
+	return len(fake.allowArgsForCall)
+}
+
+func (fake *FakeWebhookLimiter) AllowCalls(stub func(int) (bool, time.Duration)) {
+	fake.allowMutex.Lock()
+	defer fake.allowMutex.Unlock()
+	fake.AllowStub = stub
+}
+
+func (fake *FakeWebhookLimiter) AllowArgsForCall(i int) int {
+	fake.allowMutex.RLock()
+	defer fake.allowMutex.RUnlock()
+	argsForCall := fake.allowArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWebhookLimiter) AllowReturns(result1 bool, result2 time.Duration) {
+	fake.allowMutex.Lock()
+	defer fake.allowMutex.Unlock()
+	fake.AllowStub = nil
+	fake.allowReturns = struct {
+		result1 bool
+		result2 time.Duration
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookLimiter) AllowReturnsOnCall(i int, result1 bool, result2 time.Duration) {
+	fake.allowMutex.Lock()
+	defer fake.allowMutex.Unlock()
+	fake.AllowStub = nil
+	if fake.allowReturnsOnCall == nil {
+		fake.allowReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 time.Duration
+		})
+	}
+	fake.allowReturnsOnCall[i] = struct {
+		result1 bool
+		result2 time.Duration
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookLimiter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.allowMutex.RLock()
+	defer fake.allowMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeWebhookLimiter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ resourceserver.WebhookLimiter = new(FakeWebhookLimiter)
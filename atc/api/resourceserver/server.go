@@ -1,6 +1,8 @@
 package resourceserver
 
 import (
+	"time"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
@@ -20,6 +22,8 @@ type Server struct {
 	secretManager         creds.Secrets
 	resourceFactory       db.ResourceFactory
 	resourceConfigFactory db.ResourceConfigFactory
+	webhookLimiter        WebhookLimiter
+	checkReuseWindow      time.Duration
 }
 
 func NewServer(
@@ -28,6 +32,8 @@ func NewServer(
 	secretManager creds.Secrets,
 	resourceFactory db.ResourceFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
+	webhookLimiter WebhookLimiter,
+	checkReuseWindow time.Duration,
 ) *Server {
 	return &Server{
 		logger:                logger,
@@ -35,5 +41,7 @@ func NewServer(
 		secretManager:         secretManager,
 		resourceFactory:       resourceFactory,
 		resourceConfigFactory: resourceConfigFactory,
+		webhookLimiter:        webhookLimiter,
+		checkReuseWindow:      checkReuseWindow,
 	}
 }
@@ -0,0 +1,98 @@
+package resourceserver
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+//go:generate counterfeiter . WebhookLimiter
+
+// WebhookLimiter limits how frequently each resource may be checked via its
+// webhook.
+type WebhookLimiter interface {
+	// Allow reports whether a webhook check of the resource may proceed. If
+	// not, it also returns how long to wait before retrying.
+	Allow(resourceID int) (bool, time.Duration)
+}
+
+// NewWebhookLimiter returns a WebhookLimiter which allows each resource a
+// burst of checks, refilled at the given rate. A rate of 0 or less disables
+// limiting.
+func NewWebhookLimiter(checksPerSecond float64, burst int) WebhookLimiter {
+	if checksPerSecond <= 0 {
+		return unlimitedWebhookLimiter{}
+	}
+
+	limit := rate.Limit(checksPerSecond)
+
+	return &webhookLimiter{
+		limit:    limit,
+		burst:    burst,
+		idle:     time.Duration(float64(burst) / float64(limit) * float64(time.Second)),
+		limiters: map[int]*resourceLimiter{},
+	}
+}
+
+type unlimitedWebhookLimiter struct{}
+
+func (unlimitedWebhookLimiter) Allow(int) (bool, time.Duration) { return true, 0 }
+
+type webhookLimiter struct {
+	limit rate.Limit
+	burst int
+
+	// idle is how long it takes a resource's limiter to refill its burst,
+	// after which it is no different from a new one and can be dropped.
+	idle time.Duration
+
+	limitersL sync.Mutex
+	limiters  map[int]*resourceLimiter
+	lastSweep time.Time
+}
+
+type resourceLimiter struct {
+	*rate.Limiter
+	lastUsed time.Time
+}
+
+func (l *webhookLimiter) Allow(resourceID int) (bool, time.Duration) {
+	now := time.Now()
+
+	l.limitersL.Lock()
+	if now.Sub(l.lastSweep) > l.idle {
+		l.sweep(now)
+	}
+
+	limiter, found := l.limiters[resourceID]
+	if !found {
+		limiter = &resourceLimiter{Limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.limiters[resourceID] = limiter
+	}
+	limiter.lastUsed = now
+	l.limitersL.Unlock()
+
+	reservation := limiter.ReserveN(now, 1)
+
+	delay := reservation.DelayFrom(now)
+	if delay == 0 {
+		return true, 0
+	}
+
+	reservation.CancelAt(now)
+
+	return false, delay
+}
+
+// sweep drops the limiters of resources which have not been checked for long
+// enough that their burst has been refilled.
+func (l *webhookLimiter) sweep(now time.Time) {
+	for resourceID, limiter := range l.limiters {
+		if now.Sub(limiter.lastUsed) > l.idle {
+			delete(l.limiters, resourceID)
+		}
+	}
+
+	l.lastSweep = now
+}
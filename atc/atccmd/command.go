@@ -23,6 +23,7 @@ import (
 	"github.com/concourse/concourse/atc/api/auth"
 	"github.com/concourse/concourse/atc/api/buildserver"
 	"github.com/concourse/concourse/atc/api/containerserver"
+	"github.com/concourse/concourse/atc/api/resourceserver"
 	"github.com/concourse/concourse/atc/auditor"
 	"github.com/concourse/concourse/atc/builds"
 	"github.com/concourse/concourse/atc/creds"
@@ -114,6 +115,13 @@ type RunCommand struct {
 	GlobalResourceCheckTimeout   time.Duration `long:"global-resource-check-timeout" default:"1h" description:"Time limit on checking for new versions of resources."`
 	ResourceCheckingInterval     time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
 	ResourceTypeCheckingInterval time.Duration `long:"resource-type-checking-interval" default:"1m" description:"Interval on which to check for new versions of resource types."`
+	ResourceCheckBackoff         time.Duration `long:"resource-check-backoff" default:"10s" description:"Time to hold off checking a resource after its check fails. Doubles with each further failure."`
+	MaxResourceCheckBackoff      time.Duration `long:"max-resource-check-backoff" default:"1h" description:"Longest time to hold off checking a resource whose checks keep failing."`
+
+	ResourceWebhookCheckRateLimit float64 `long:"resource-webhook-check-rate-limit" default:"0" description:"Maximum rate, in checks per second, at which each resource may be checked via its webhook. 0 means no limit."`
+	ResourceWebhookCheckBurst     int     `long:"resource-webhook-check-burst" default:"10" description:"Number of webhook checks a resource may receive in quick succession before being rate limited."`
+
+	ResourceCheckReuseWindow time.Duration `long:"resource-check-reuse-window" default:"0" description:"Skip manually requested checks of a resource whose last check finished within this window, unless forced. 0 means every requested check runs."`
 
 	ContainerPlacementStrategy        string        `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" choice:"limit-active-tasks" description:"Method by which a worker is selected during container placement."`
 	MaxActiveTasksPerWorker           int           `long:"max-active-tasks-per-worker" default:"0" description:"Maximum allowed number of active build tasks per worker. Has effect only when used with limit-active-tasks placement strategy. 0 means no limit."`
@@ -149,6 +157,8 @@ type RunCommand struct {
 
 		OneOffBuildGracePeriod time.Duration `long:"one-off-grace-period" default:"5m" description:"Period after which one-off build containers will be garbage-collected."`
 		MissingGracePeriod     time.Duration `long:"missing-grace-period" default:"5m" description:"Period after which to reap containers and volumes that were created but went missing from the worker."`
+
+		ResourceCacheUseGracePeriod time.Duration `long:"resource-cache-use-grace-period" default:"5m" description:"Period after a build finishes for which the resource caches it used are kept."`
 	} `group:"Garbage Collection" namespace:"gc"`
 
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
@@ -161,6 +171,8 @@ type RunCommand struct {
 	DefaultDaysToRetainBuildLogs uint64 `long:"default-days-to-retain-build-logs" description:"Default days to retain build logs. 0 means unlimited"`
 	MaxDaysToRetainBuildLogs     uint64 `long:"max-days-to-retain-build-logs" description:"Maximum days to retain build logs, 0 means not specified. Will override values configured in jobs"`
 
+	DefaultBuildEventRetention time.Duration `long:"default-build-event-retention" description:"Default duration to keep build log events for pipelines that do not set their own retention. 0 means forever"`
+
 	DefaultCpuLimit    *int    `long:"default-task-cpu-limit" description:"Default max number of cpu shares per task, 0 means unlimited"`
 	DefaultMemoryLimit *string `long:"default-task-memory-limit" description:"Default maximum memory per task, 0 means unlimited"`
 
@@ -573,6 +585,7 @@ func (cmd *RunCommand) constructAPIMembers(
 		dbResourceConfigFactory,
 		cmd.ResourceTypeCheckingInterval,
 		cmd.ResourceCheckingInterval,
+		db.CheckBackoff{Min: cmd.ResourceCheckBackoff, Max: cmd.MaxResourceCheckBackoff},
 		cmd.ExternalURL.String(),
 		secretManager,
 		checkContainerStrategy,
@@ -773,11 +786,12 @@ func (cmd *RunCommand) constructBackendMembers(
 		dbResourceConfigFactory,
 		cmd.ResourceTypeCheckingInterval,
 		cmd.ResourceCheckingInterval,
+		db.CheckBackoff{Min: cmd.ResourceCheckBackoff, Max: cmd.MaxResourceCheckBackoff},
 		checkContainerStrategy,
 	)
 
 	dbWorkerLifecycle := db.NewWorkerLifecycle(dbConn)
-	dbResourceCacheLifecycle := db.NewResourceCacheLifecycle(dbConn)
+	dbResourceCacheLifecycle := db.NewResourceCacheLifecycle(dbConn, cmd.GC.ResourceCacheUseGracePeriod)
 	dbContainerRepository := db.NewContainerRepository(dbConn)
 	dbArtifactLifecycle := db.NewArtifactLifecycle(dbConn)
 	resourceConfigCheckSessionLifecycle := db.NewResourceConfigCheckSessionLifecycle(dbConn)
@@ -857,6 +871,17 @@ func (cmd *RunCommand) constructBackendMembers(
 			clock.NewClock(),
 			30*time.Second,
 		)},
+		{Name: "build-event-collector", Runner: lockrunner.NewRunner(
+			logger.Session("build-event-collector"),
+			gc.NewBuildEventCollector(
+				dbPipelineFactory,
+				cmd.DefaultBuildEventRetention,
+			),
+			"build-event-reaper",
+			lockFactory,
+			clock.NewClock(),
+			time.Minute,
+		)},
 	}
 
 	//Syslog Drainer Configuration
@@ -1123,6 +1148,13 @@ func (cmd *RunCommand) validate() error {
 		)
 	}
 
+	if cmd.ResourceWebhookCheckBurst < 1 {
+		errs = multierror.Append(
+			errs,
+			errors.New("--resource-webhook-check-burst must be at least 1"),
+		)
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -1372,6 +1404,8 @@ func (cmd *RunCommand) constructAPIHandler(
 
 		workerClient,
 		radarScannerFactory,
+		resourceserver.NewWebhookLimiter(cmd.ResourceWebhookCheckRateLimit, cmd.ResourceWebhookCheckBurst),
+		cmd.ResourceCheckReuseWindow,
 
 		reconfigurableSink,
 
@@ -118,6 +118,7 @@ var loggingLevels = map[string]string{
 	atc.CheckResource:                 "EnableResourceAuditLog",
 	atc.CheckResourceWebHook:          "EnableResourceAuditLog",
 	atc.CheckResourceType:             "EnableResourceAuditLog",
+	atc.CheckResources:                "EnableResourceAuditLog",
 	atc.ListResourceVersions:          "EnableResourceAuditLog",
 	atc.GetResourceVersion:            "EnableResourceAuditLog",
 	atc.EnableResourceVersion:         "EnableResourceAuditLog",
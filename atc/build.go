@@ -51,12 +51,20 @@ const (
 
 type MissingInputReasons map[string]string
 
+type MissingWorkerReasons map[string]string
+
 type BuildPreparation struct {
-	BuildID             int                               `json:"build_id"`
-	PausedPipeline      BuildPreparationStatus            `json:"paused_pipeline"`
-	PausedJob           BuildPreparationStatus            `json:"paused_job"`
-	MaxRunningBuilds    BuildPreparationStatus            `json:"max_running_builds"`
-	Inputs              map[string]BuildPreparationStatus `json:"inputs"`
-	InputsSatisfied     BuildPreparationStatus            `json:"inputs_satisfied"`
-	MissingInputReasons MissingInputReasons               `json:"missing_input_reasons"`
+	BuildID               int                               `json:"build_id"`
+	PausedPipeline        BuildPreparationStatus            `json:"paused_pipeline"`
+	PausedJob             BuildPreparationStatus            `json:"paused_job"`
+	MaxRunningBuilds      BuildPreparationStatus            `json:"max_running_builds"`
+	Inputs                map[string]BuildPreparationStatus `json:"inputs"`
+	InputsSatisfied       BuildPreparationStatus            `json:"inputs_satisfied"`
+	MissingInputReasons   MissingInputReasons               `json:"missing_input_reasons"`
+	MissingWorker         BuildPreparationStatus            `json:"missing_worker"`
+	MissingWorkerReasons  MissingWorkerReasons              `json:"missing_worker_reasons"`
+	Held                  BuildPreparationStatus            `json:"held"`
+	SerialGroups          BuildPreparationStatus            `json:"serial_groups"`
+	AwaitingManualTrigger BuildPreparationStatus            `json:"awaiting_manual_trigger"`
+	FilteredInputs        map[string]int                    `json:"filtered_inputs"`
 }
@@ -7,6 +7,9 @@ import (
 type VariableLookupFromSecrets struct {
 	Secrets     Secrets
 	LookupPaths []SecretLookupPath
+
+	// Accessed, if set, is called with the path of every secret found.
+	Accessed func(secretPath string)
 }
 
 func NewVariables(secrets Secrets, teamName string, pipelineName string) vars.Variables {
@@ -16,6 +19,16 @@ func NewVariables(secrets Secrets, teamName string, pipelineName string) vars.Va
 	}
 }
 
+// NewTrackedVariables is like NewVariables, but calls accessed with the path
+// of every secret that is found.
+func NewTrackedVariables(secrets Secrets, teamName string, pipelineName string, accessed func(secretPath string)) vars.Variables {
+	return VariableLookupFromSecrets{
+		Secrets:     secrets,
+		LookupPaths: secrets.NewSecretLookupPaths(teamName, pipelineName),
+		Accessed:    accessed,
+	}
+}
+
 func (sl VariableLookupFromSecrets) Get(varDef vars.VariableDefinition) (interface{}, bool, error) {
 	// try to find a secret according to our var->secret lookup paths
 	if len(sl.LookupPaths) > 0 {
@@ -31,16 +44,26 @@ func (sl VariableLookupFromSecrets) Get(varDef vars.VariableDefinition) (interfa
 			if !found {
 				continue
 			}
+			sl.accessed(secretId)
 			return result, true, nil
 		}
 		return nil, false, nil
 	} else {
 		// if no paths are specified (i.e. for fake & noop secret managers), then try 1-to-1 var->secret mapping
 		result, _, found, err := sl.Secrets.Get(varDef.Name)
+		if found {
+			sl.accessed(varDef.Name)
+		}
 		return result, found, err
 	}
 }
 
+func (sl VariableLookupFromSecrets) accessed(secretPath string) {
+	if sl.Accessed != nil {
+		sl.Accessed(secretPath)
+	}
+}
+
 func (sl VariableLookupFromSecrets) List() ([]vars.VariableDefinition, error) {
 	return nil, nil
 }
@@ -5,7 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -25,11 +29,47 @@ type BuildInput struct {
 	ResourceID int
 
 	FirstOccurrence bool
+
+	// Metadata is only populated by ResourcesWithMetadata.
+	Metadata ResourceConfigMetadataFields
 }
 
 type BuildOutput struct {
 	Name    string
 	Version atc.Version
+
+	// ID is only populated by OutputsSince, SuccessfulBuildOutputsSince,
+	// StreamSuccessfulBuildOutputs and Job.LatestSuccessfulBuildOutputs, to be
+	// used as the cursor for the next call.
+	ID int
+
+	// Metadata is only populated by Resources and ResourcesWithMetadata.
+	Metadata ResourceConfigMetadataFields
+
+	// ResourceID is only populated by Resources and
+	// Job.LatestSuccessfulBuildOutputs.
+	ResourceID int
+
+	// JobID is the job of the build which produced the output. It is only
+	// populated by SuccessfulBuildOutputsSince and StreamSuccessfulBuildOutputs.
+	JobID int
+}
+
+// BuildOrigin identifies what created a build.
+type BuildOrigin string
+
+const (
+	BuildOriginScheduler BuildOrigin = "scheduler"
+	BuildOriginAPI       BuildOrigin = "api"
+	BuildOriginWebhook   BuildOrigin = "webhook"
+	BuildOriginRerun     BuildOrigin = "rerun"
+)
+
+// FinishOptions configures how a build is finished.
+type FinishOptions struct {
+	// ClearPublicPlan replaces the public plan of builds which did not
+	// succeed with an empty plan.
+	ClearPublicPlan bool
 }
 
 type BuildStatus string
@@ -43,12 +83,22 @@ const (
 	BuildStatusErrored   BuildStatus = "errored"
 )
 
-var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.schema, b.private_plan, b.public_plan, b.create_time, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce, b.drained, b.aborted, b.completed").
+// Reasons recorded by MarkAsAbortedWithReason.
+const (
+	AbortReasonUser    = "user"
+	AbortReasonTimeout = "timeout"
+)
+
+var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.schema, b.private_plan, b.public_plan, b.create_time, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce, b.drained, b.aborted, b.completed, b.inputs_determined_at, b.retry_count, b.held, b.origin, b.abort_reason, b.tags, b.keep, b.interceptible, b.inputs_count, b.outputs_count").
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
 	JoinClause("LEFT OUTER JOIN pipelines p ON b.pipeline_id = p.id").
 	JoinClause("LEFT OUTER JOIN teams t ON b.team_id = t.id")
 
+// pendingBuildsOrder is the order in which pending builds are scheduled:
+// manually triggered builds jump ahead of the ones created by the scheduler.
+var pendingBuildsOrder = []string{"b.manually_triggered DESC", "b.id ASC"}
+
 var minMaxIdQuery = psql.Select("COALESCE(MAX(b.id), 0)", "COALESCE(MIN(b.id), 0)").
 	From("builds as b")
 
@@ -67,47 +117,104 @@ type Build interface {
 	PrivatePlan() atc.Plan
 	PublicPlan() *json.RawMessage
 	HasPlan() bool
+	HasPrivatePlan() bool
+	PrivatePlanForResume() (atc.Plan, bool, error)
 	Status() BuildStatus
 	StartTime() time.Time
 	CreateTime() time.Time
 	EndTime() time.Time
 	ReapTime() time.Time
+	InputsDeterminedAt() (time.Time, bool)
 	IsManuallyTriggered() bool
+	Origin() BuildOrigin
+	RetryCount() int
 	IsScheduled() bool
+	IsHeld() bool
+	IsKept() bool
 	IsRunning() bool
+
+	// InputCount and OutputCount are the number of inputs and outputs
+	// returned by Resources, as recorded when the build finished.
+	InputCount() int
+	OutputCount() int
 	IsCompleted() bool
+	Reapable(ReapPolicy) bool
 
 	Reload() (bool, error)
+	ReloadChanged() (bool, bool, error)
 
 	AcquireTrackingLock(logger lager.Logger, interval time.Duration) (lock.Lock, bool, error)
 
 	Interceptible() (bool, error)
 	Preparation() (BuildPreparation, bool, error)
+	PreparationCached() (BuildPreparation, bool, error)
 
+	SetPlan(atc.Plan) error
 	Start(atc.Plan) (bool, error)
 	Finish(BuildStatus) error
+	FinishWithEvents(BuildStatus, []atc.Event) error
+	FinishWithOptions(BuildStatus, FinishOptions) error
+	ErrorUnresolvedInputs(detail string) error
 
 	SetInterceptible(bool) error
+	SetKeep(bool) error
+	SetExpiry(time.Duration) error
+	SetName(string) error
+	MoveToTeam(Team) error
+	SetWaitingForWorker(tags []string) error
+
+	SaveWorkerName(string) error
+	WorkerNames() ([]string, error)
 
 	Events(uint) (EventSource, error)
+	EventsFromToken(token string) (EventSource, error)
+	EventsFromID(after int) (EventSource, error)
+	RedactedEvents(from uint, redactor SecretRedactor) (EventSource, error)
+	EventsBetween(start, end time.Time) (EventSource, error)
+	EventsCompressed(from uint) (io.ReadCloser, error)
 	SaveEvent(event atc.Event) error
+	SaveEvents(events []atc.Event) error
+	SaveEventWithSeq(clientSeq int64, event atc.Event) error
+	EventOffsetAtFraction(fraction float64) (uint, error)
+	EventCount() (int, error)
+	LatestStatusEvent() (event.Envelope, bool, error)
+	TrimEvents(before time.Time) (int, error)
+
+	ContainerHandles() ([]string, error)
 
 	Artifacts() ([]WorkerArtifact, error)
 	Artifact(artifactID int) (WorkerArtifact, error)
+	ArtifactByName(name string) (WorkerArtifact, bool, error)
 
 	SaveOutput(string, atc.Source, atc.VersionedResourceTypes, atc.Version, ResourceConfigMetadataFields, string, string) error
+	SaveOutputDeleted(resourceName string, version atc.Version) error
 	UseInputs(inputs []BuildInput) error
 
 	Resources() ([]BuildInput, []BuildOutput, error)
+	ResourcesWithMetadata() ([]BuildInput, []BuildOutput, error)
+	InputVersions() (map[string]atc.Version, error)
+	OutputsSince(outputID int) ([]BuildOutput, error)
+	UpdateOutputMetadata(outputName string, fields []ResourceConfigMetadataField) error
 	SaveImageResourceVersion(UsedResourceCache) error
+	RegisterResourceCacheUse(UsedResourceCache) error
+	ResourceCacheUses() ([]UsedResourceCache, error)
 
 	Pipeline() (Pipeline, bool, error)
+	Job() (Job, bool, error)
+	Reruns() ([]Build, error)
+	DownstreamJobs() ([]Job, error)
 
 	Delete() (bool, error)
 	MarkAsAborted() error
+	MarkAsAbortedWithReason(reason string) error
+	AbortReason() string
+	Tags() []string
 	IsAborted() bool
 	AbortNotifier() (Notifier, error)
+	NotifyOnCompletion() (Notifier, error)
 	Schedule() (bool, error)
+	Hold() error
+	Release() error
 
 	IsDrained() bool
 	SetDrained(bool) error
@@ -128,26 +235,50 @@ type build struct {
 	jobName      string
 
 	isManuallyTriggered bool
+	origin              BuildOrigin
+	retryCount          int
 
-	schema      string
-	privatePlan atc.Plan
-	publicPlan  *json.RawMessage
+	schema         string
+	privatePlan    atc.Plan
+	hasPrivatePlan bool
+	publicPlan     *json.RawMessage
 
 	createTime time.Time
 	startTime  time.Time
 	endTime    time.Time
 	reapTime   time.Time
 
+	inputsDeterminedAt time.Time
+
 	conn        Conn
 	lockFactory lock.LockFactory
 	drained     bool
 	aborted     bool
+	abortReason string
 	completed   bool
+	held        bool
+	tags        []string
+
+	keep          bool
+	interceptible bool
+
+	inputsCount  int
+	outputsCount int
 }
 
 var ErrBuildDisappeared = errors.New("build disappeared from db")
 var ErrBuildHasNoPipeline = errors.New("build has no pipeline")
 var ErrBuildArtifactNotFound = errors.New("build artifact not found")
+var ErrEventFractionOutOfRange = errors.New("event fraction must be between 0 and 1")
+var ErrBuildNotPending = errors.New("build is not pending")
+var ErrRetryOfOtherJobBuild = errors.New("cannot retry a build of another job")
+var ErrBuildOutputNotFound = errors.New("build output not found")
+var ErrBuildNotOneOff = errors.New("build is not a one-off build")
+var ErrBuildNameTaken = errors.New("build name is already taken by another build of the team")
+var ErrBuildNameNumeric = errors.New("build name must not be a number")
+var ErrBuildBelongsToPipeline = errors.New("build belongs to a pipeline")
+var ErrBuildHasNoPrivatePlan = errors.New("build has no private plan stored")
+var ErrEventOffsetTooHigh = errors.New("event offset is beyond the events of the completed build")
 
 type ResourceNotFoundInPipeline struct {
 	Resource string
@@ -167,6 +298,8 @@ func (b *build) PipelineName() string         { return b.pipelineName }
 func (b *build) TeamID() int                  { return b.teamID }
 func (b *build) TeamName() string             { return b.teamName }
 func (b *build) IsManuallyTriggered() bool    { return b.isManuallyTriggered }
+func (b *build) Origin() BuildOrigin          { return b.origin }
+func (b *build) RetryCount() int              { return b.retryCount }
 func (b *build) Schema() string               { return b.schema }
 func (b *build) PrivatePlan() atc.Plan        { return b.privatePlan }
 func (b *build) PublicPlan() *json.RawMessage { return b.publicPlan }
@@ -177,10 +310,75 @@ func (b *build) EndTime() time.Time           { return b.endTime }
 func (b *build) ReapTime() time.Time          { return b.reapTime }
 func (b *build) Status() BuildStatus          { return b.status }
 func (b *build) IsScheduled() bool            { return b.scheduled }
+func (b *build) IsHeld() bool                 { return b.held }
+func (b *build) IsKept() bool                 { return b.keep }
+func (b *build) InputCount() int              { return b.inputsCount }
+func (b *build) OutputCount() int             { return b.outputsCount }
 func (b *build) IsDrained() bool              { return b.drained }
 func (b *build) IsRunning() bool              { return !b.completed }
 func (b *build) IsAborted() bool              { return b.aborted }
+func (b *build) AbortReason() string          { return b.abortReason }
 func (b *build) IsCompleted() bool            { return b.completed }
+func (b *build) Tags() []string               { return b.tags }
+
+// HasPrivatePlan returns whether the build has a private plan stored, which
+// is the case from when it is started, or given a plan by SetPlan while still
+// pending, until it finishes. Unlike HasPlan, it does not consider the public
+// plan, which is kept after the build finishes.
+func (b *build) HasPrivatePlan() bool { return b.hasPrivatePlan }
+
+// PrivatePlanForResume reads the build's private plan from the database so
+// that it can be sent again, e.g. after a worker restarts mid-build. It returns
+// false if the build has already finished, and ErrBuildHasNoPrivatePlan if the
+// build is still running but no plan has been stored for it.
+func (b *build) PrivatePlanForResume() (atc.Plan, bool, error) {
+	var (
+		completed          bool
+		privatePlan, nonce sql.NullString
+	)
+	err := psql.Select("completed, private_plan, nonce").
+		From("builds").
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&completed, &privatePlan, &nonce)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.Plan{}, false, ErrBuildDisappeared
+		}
+		return atc.Plan{}, false, err
+	}
+
+	if completed {
+		return atc.Plan{}, false, nil
+	}
+
+	if !privatePlan.Valid {
+		return atc.Plan{}, false, ErrBuildHasNoPrivatePlan
+	}
+
+	decryptedPlan := []byte(privatePlan.String)
+	if nonce.Valid {
+		decryptedPlan, err = b.conn.EncryptionStrategy().Decrypt(privatePlan.String, &nonce.String)
+		if err != nil {
+			return atc.Plan{}, false, err
+		}
+	}
+
+	var plan atc.Plan
+	err = json.Unmarshal(decryptedPlan, &plan)
+	if err != nil {
+		return atc.Plan{}, false, err
+	}
+
+	return plan, true, nil
+}
+
+// InputsDeterminedAt returns the time at which the build's inputs were last
+// successfully saved, and false if they have not been saved yet.
+func (b *build) InputsDeterminedAt() (time.Time, bool) {
+	return b.inputsDeterminedAt, !b.inputsDeterminedAt.IsZero()
+}
 
 func (b *build) Reload() (bool, error) {
 	row := buildsQuery.Where(sq.Eq{"b.id": b.id}).
@@ -198,6 +396,29 @@ func (b *build) Reload() (bool, error) {
 	return true, nil
 }
 
+// ReloadChanged reloads the build, additionally reporting whether its status,
+// times or flags differ from before the reload.
+func (b *build) ReloadChanged() (bool, bool, error) {
+	prev := *b
+
+	found, err := b.Reload()
+	if err != nil || !found {
+		return false, found, err
+	}
+
+	changed := b.status != prev.status ||
+		b.scheduled != prev.scheduled ||
+		b.drained != prev.drained ||
+		b.aborted != prev.aborted ||
+		b.completed != prev.completed ||
+		b.held != prev.held ||
+		!b.startTime.Equal(prev.startTime) ||
+		!b.endTime.Equal(prev.endTime) ||
+		!b.reapTime.Equal(prev.reapTime)
+
+	return changed, true, nil
+}
+
 func (b *build) Interceptible() (bool, error) {
 	var interceptible bool
 
@@ -237,9 +458,116 @@ func (b *build) SetInterceptible(i bool) error {
 		return ErrBuildDisappeared
 	}
 
+	b.interceptible = i
+
+	return nil
+}
+
+// SetWaitingForWorker records that the build could not be placed on any
+// worker satisfying the given tags, which is reported by its preparation. A
+// nil slice clears it.
+func (b *build) SetWaitingForWorker(tags []string) error {
+	var value interface{}
+	if tags != nil {
+		value = pq.Array(tags)
+	}
+
+	result, err := psql.Update("builds").
+		Set("waiting_for_worker_tags", value).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	return nil
+}
+
+// SaveWorkerName records that the build ran on the named worker. Each worker
+// is only recorded once.
+func (b *build) SaveWorkerName(workerName string) error {
+	_, err := psql.Update("builds").
+		Set("worker_names", sq.Expr("array_append(worker_names, ?)", workerName)).
+		Where(sq.Eq{"id": b.id}).
+		Where(sq.Expr("NOT (? = ANY(worker_names))", workerName)).
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
+// WorkerNames returns the workers which the build ran on, in the order they
+// were first recorded.
+func (b *build) WorkerNames() ([]string, error) {
+	var workerNames pq.StringArray
+	err := psql.Select("worker_names").
+		From("builds").
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&workerNames)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrBuildDisappeared
+		}
+		return nil, err
+	}
+
+	return []string(workerNames), nil
+}
+
+// SetPlan stores the plan on a pending build, to be used by Start if it is
+// not given a plan of its own.
+func (b *build) SetPlan(plan atc.Plan) error {
+	metadata, err := json.Marshal(plan)
+	if err != nil {
+		return err
+	}
+
+	encryptedPlan, nonce, err := b.conn.EncryptionStrategy().Encrypt([]byte(metadata))
+	if err != nil {
+		return err
+	}
+
+	result, err := psql.Update("builds").
+		Set("private_plan", encryptedPlan).
+		Set("nonce", nonce).
+		Where(sq.Eq{
+			"id":      b.id,
+			"status":  "pending",
+			"aborted": false,
+		}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrBuildNotPending
+	}
+
+	b.privatePlan = plan
+
 	return nil
 }
 
+// Start transitions the build to started with the given plan. If the plan is
+// empty, the plan stored by SetPlan is used instead.
 func (b *build) Start(plan atc.Plan) (bool, error) {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -248,6 +576,37 @@ func (b *build) Start(plan atc.Plan) (bool, error) {
 
 	defer Rollback(tx)
 
+	if plan.ID == "" {
+		var privatePlan, nonce sql.NullString
+		err = psql.Select("private_plan, nonce").
+			From("builds").
+			Where(sq.Eq{"id": b.id}).
+			RunWith(tx).
+			QueryRow().
+			Scan(&privatePlan, &nonce)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return false, nil
+			}
+			return false, err
+		}
+
+		decryptedPlan := []byte(privatePlan.String)
+		if nonce.Valid {
+			decryptedPlan, err = b.conn.EncryptionStrategy().Decrypt(privatePlan.String, &nonce.String)
+			if err != nil {
+				return false, err
+			}
+		}
+
+		if len(decryptedPlan) > 0 {
+			err = json.Unmarshal(decryptedPlan, &plan)
+			if err != nil {
+				return false, err
+			}
+		}
+	}
+
 	metadata, err := json.Marshal(plan)
 	if err != nil {
 		return false, err
@@ -312,6 +671,105 @@ func (b *build) Start(plan atc.Plan) (bool, error) {
 }
 
 func (b *build) Finish(status BuildStatus) error {
+	return b.finish(status, nil, FinishOptions{})
+}
+
+// FinishWithEvents saves the given events and finishes the build in a single
+// transaction, so that subscribers never see the final status event before
+// the events that preceded it.
+func (b *build) FinishWithEvents(status BuildStatus, finalEvents []atc.Event) error {
+	return b.finish(status, finalEvents, FinishOptions{})
+}
+
+func (b *build) FinishWithOptions(status BuildStatus, opts FinishOptions) error {
+	return b.finish(status, nil, opts)
+}
+
+// ErrorUnresolvedInputs errors the pending build because its inputs cannot be
+// resolved, saving an error event which names the inputs that are missing
+// according to the build's preparation, followed by the given detail.
+func (b *build) ErrorUnresolvedInputs(detail string) error {
+	prep, found, err := b.Preparation()
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return ErrBuildDisappeared
+	}
+
+	unresolved := []string{}
+	for name, reason := range prep.MissingInputReasons {
+		unresolved = append(unresolved, fmt.Sprintf("%s (%s)", name, reason))
+	}
+
+	sort.Strings(unresolved)
+
+	message := "unable to resolve inputs"
+	if len(unresolved) > 0 {
+		message += ": " + strings.Join(unresolved, ", ")
+	}
+
+	if detail != "" {
+		message += "\n" + detail
+	}
+
+	err = b.finishWhere(BuildStatusErrored, []atc.Event{
+		event.Error{
+			Message: message,
+			Time:    time.Now().Unix(),
+		},
+	}, FinishOptions{}, sq.Eq{
+		"id":     b.id,
+		"status": BuildStatusPending,
+	})
+	if err == sql.ErrNoRows {
+		return ErrBuildNotPending
+	}
+
+	return err
+}
+
+// buildInputsCount and buildOutputsCount count the inputs and outputs which
+// Resources would return for the build being updated.
+const (
+	buildInputsCount = `(
+		SELECT COUNT(*)
+		FROM build_resource_config_version_inputs inputs
+		JOIN resources ON resources.id = inputs.resource_id
+		JOIN resource_config_versions versions
+			ON versions.version_md5 = inputs.version_md5
+			AND versions.resource_config_scope_id = resources.resource_config_scope_id
+		WHERE inputs.build_id = builds.id
+		AND versions.check_order != 0
+		AND NOT EXISTS (
+			SELECT 1
+			FROM build_resource_config_version_outputs outputs
+			WHERE outputs.version_md5 = versions.version_md5
+			AND outputs.resource_id = resources.id
+			AND outputs.build_id = inputs.build_id
+		)
+	)`
+
+	buildOutputsCount = `(
+		SELECT COUNT(*)
+		FROM build_resource_config_version_outputs outputs
+		JOIN resources ON resources.id = outputs.resource_id
+		JOIN resource_config_versions versions
+			ON versions.version_md5 = outputs.version_md5
+			AND versions.resource_config_scope_id = resources.resource_config_scope_id
+		WHERE outputs.build_id = builds.id
+		AND versions.check_order != 0
+	)`
+)
+
+func (b *build) finish(status BuildStatus, finalEvents []atc.Event, opts FinishOptions) error {
+	return b.finishWhere(status, finalEvents, opts, sq.Eq{"id": b.id})
+}
+
+// finishWhere finishes the build only if it matches the given condition,
+// returning sql.ErrNoRows if it does not.
+func (b *build) finishWhere(status BuildStatus, finalEvents []atc.Event, opts FinishOptions, cond sq.Sqlizer) error {
 	tx, err := b.conn.Begin()
 	if err != nil {
 		return err
@@ -319,26 +777,58 @@ func (b *build) Finish(status BuildStatus) error {
 
 	defer Rollback(tx)
 
-	var endTime time.Time
+	for _, ev := range finalEvents {
+		err = b.saveEvent(tx, ev)
+		if err != nil {
+			return err
+		}
+	}
 
-	err = psql.Update("builds").
+	var (
+		startTime pq.NullTime
+		endTime   time.Time
+	)
+
+	update := psql.Update("builds").
 		Set("status", status).
 		Set("end_time", sq.Expr("now()")).
 		Set("completed", true).
 		Set("private_plan", nil).
 		Set("nonce", nil).
-		Where(sq.Eq{"id": b.id}).
-		Suffix("RETURNING end_time").
+		Set("inputs_count", sq.Expr(buildInputsCount)).
+		Set("outputs_count", sq.Expr(buildOutputsCount))
+
+	if opts.ClearPublicPlan && status != BuildStatusSucceeded {
+		update = update.Set("public_plan", "{}")
+	}
+
+	err = update.
+		Where(cond).
+		Suffix("RETURNING start_time, end_time, inputs_count, outputs_count").
 		RunWith(tx).
 		QueryRow().
-		Scan(&endTime)
+		Scan(&startTime, &endTime, &b.inputsCount, &b.outputsCount)
 	if err != nil {
 		return err
 	}
 
-	err = b.saveEvent(tx, event.Status{
-		Status: atc.BuildStatus(status),
-		Time:   endTime.Unix(),
+	var duration time.Duration
+	if startTime.Valid {
+		duration = endTime.Sub(startTime.Time)
+	}
+
+	err = b.saveEvents(tx, []atc.Event{
+		event.Status{
+			Status: atc.BuildStatus(status),
+			Time:   endTime.Unix(),
+		},
+		event.BuildSummary{
+			Status:   atc.BuildStatus(status),
+			Time:     endTime.Unix(),
+			Duration: int64(duration / time.Second),
+			Inputs:   b.inputsCount,
+			Outputs:  b.outputsCount,
+		},
 	})
 	if err != nil {
 		return err
@@ -395,6 +885,11 @@ func (b *build) Finish(status BuildStatus) error {
 		return err
 	}
 
+	err = b.conn.Bus().Notify(buildCompletionChannel(b.id))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -441,8 +936,15 @@ func (b *build) Delete() (bool, error) {
 // Setting status as aborted will also make Start() return false in case where
 // build was aborted before it was started.
 func (b *build) MarkAsAborted() error {
+	return b.MarkAsAbortedWithReason(AbortReasonUser)
+}
+
+// MarkAsAbortedWithReason is MarkAsAborted, recording why the build was
+// aborted.
+func (b *build) MarkAsAbortedWithReason(reason string) error {
 	_, err := psql.Update("builds").
 		Set("aborted", true).
+		Set("abort_reason", reason).
 		Where(sq.Eq{"id": b.id}).
 		RunWith(b.conn).
 		Exec()
@@ -470,10 +972,29 @@ func (b *build) AbortNotifier() (Notifier, error) {
 	})
 }
 
+// NotifyOnCompletion returns a notifier which fires once the build has
+// completed, including when it had already completed before subscribing.
+func (b *build) NotifyOnCompletion() (Notifier, error) {
+	return newConditionNotifier(b.conn.Bus(), buildCompletionChannel(b.id), func() (bool, error) {
+		var completed bool
+		err := psql.Select("completed").
+			From("builds").
+			Where(sq.Eq{"id": b.id}).
+			RunWith(b.conn).
+			QueryRow().
+			Scan(&completed)
+
+		return completed, err
+	})
+}
+
 func (b *build) Schedule() (bool, error) {
 	result, err := psql.Update("builds").
 		Set("scheduled", true).
-		Where(sq.Eq{"id": b.id}).
+		Where(sq.Eq{
+			"id":   b.id,
+			"held": false,
+		}).
 		RunWith(b.conn).
 		Exec()
 	if err != nil {
@@ -488,46 +1009,494 @@ func (b *build) Schedule() (bool, error) {
 	return rows == 1, nil
 }
 
-func (b *build) Pipeline() (Pipeline, bool, error) {
-	if b.pipelineID == 0 {
-		return nil, false, nil
-	}
-
-	row := pipelinesQuery.
-		Where(sq.Eq{"p.id": b.pipelineID}).
+// Hold keeps the pending build from being scheduled until it is released,
+// without pausing its job.
+func (b *build) Hold() error {
+	result, err := psql.Update("builds").
+		Set("held", true).
+		Where(sq.Eq{
+			"id":     b.id,
+			"status": BuildStatusPending,
+		}).
 		RunWith(b.conn).
-		QueryRow()
+		Exec()
+	if err != nil {
+		return err
+	}
 
-	pipeline := newPipeline(b.conn, b.lockFactory)
-	err := scanPipeline(pipeline, row)
+	rows, err := result.RowsAffected()
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, false, nil
-		}
-		return nil, false, err
+		return err
 	}
 
-	return pipeline, true, nil
+	if rows == 0 {
+		return ErrBuildNotPending
+	}
+
+	b.held = true
+
+	return nil
 }
 
-func (b *build) SaveImageResourceVersion(rc UsedResourceCache) error {
-	_, err := psql.Insert("build_image_resource_caches").
-		Columns("resource_cache_id", "build_id").
-		Values(rc.ID(), b.id).
+func (b *build) Release() error {
+	_, err := psql.Update("builds").
+		Set("held", false).
+		Where(sq.Eq{"id": b.id}).
 		RunWith(b.conn).
 		Exec()
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
-			return nil
-		}
-
 		return err
 	}
 
+	b.held = false
+
 	return nil
 }
 
-func (b *build) AcquireTrackingLock(logger lager.Logger, interval time.Duration) (lock.Lock, bool, error) {
+// SetKeep marks the build as kept, which stops its events from being reaped
+// regardless of the retention policy.
+func (b *build) SetKeep(keep bool) error {
+	result, err := psql.Update("builds").
+		Set("keep", keep).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrBuildDisappeared
+	}
+
+	b.keep = keep
+
+	return nil
+}
+
+// SetName renames the one-off build. Names are unique among the one-off
+// builds of a team; numeric names are reserved for the names given to new
+// builds.
+func (b *build) SetName(name string) error {
+	if b.jobID != 0 {
+		return ErrBuildNotOneOff
+	}
+
+	if _, err := strconv.Atoi(name); err == nil {
+		return ErrBuildNameNumeric
+	}
+
+	result, err := psql.Update("builds").
+		Set("name", name).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			return ErrBuildNameTaken
+		}
+
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrBuildDisappeared
+	}
+
+	b.name = name
+
+	return nil
+}
+
+// MoveToTeam hands the one-off build, along with its events, over to the
+// given team. Builds which belong to a pipeline stay with the pipeline's team.
+func (b *build) MoveToTeam(team Team) error {
+	if b.jobID != 0 {
+		return ErrBuildNotOneOff
+	}
+
+	if b.pipelineID != 0 {
+		return ErrBuildBelongsToPipeline
+	}
+
+	if team.ID() == b.teamID {
+		return nil
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	result, err := psql.Update("builds").
+		Set("team_id", team.ID()).
+		Where(sq.Eq{
+			"id":          b.id,
+			"job_id":      nil,
+			"pipeline_id": nil,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			return ErrBuildNameTaken
+		}
+
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrBuildDisappeared
+	}
+
+	_, err = tx.Exec(fmt.Sprintf(`
+		INSERT INTO team_build_events_%d
+		SELECT * FROM %s WHERE build_id = $1
+	`, team.ID(), b.eventsTable()), b.id)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Delete(b.eventsTable()).
+		Where(sq.Eq{"build_id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	b.teamID = team.ID()
+	b.teamName = team.Name()
+
+	return nil
+}
+
+// SetExpiry makes the one-off build expire after the given duration, after
+// which it is deleted along with its events once it has completed.
+func (b *build) SetExpiry(ttl time.Duration) error {
+	if b.jobID != 0 {
+		return ErrBuildNotOneOff
+	}
+
+	result, err := psql.Update("builds").
+		Set("expires_at", sq.Expr("now() + ? * interval '1 second'", ttl.Seconds())).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrBuildDisappeared
+	}
+
+	return nil
+}
+
+// ReapPolicy describes which builds may have their events reaped.
+type ReapPolicy struct {
+	// MinAge is how long ago a build must have finished. 0 allows builds
+	// regardless of when they finished.
+	MinAge time.Duration
+
+	// RequireDrained only allows builds whose events have been drained.
+	RequireDrained bool
+
+	// KeepInterceptible only allows builds which can no longer be
+	// intercepted.
+	KeepInterceptible bool
+}
+
+// Reapable returns whether the build's events may be reaped under the given
+// policy. Running and kept builds are never reapable.
+func (b *build) Reapable(policy ReapPolicy) bool {
+	if !b.completed || b.keep {
+		return false
+	}
+
+	if policy.RequireDrained && !b.drained {
+		return false
+	}
+
+	if policy.KeepInterceptible && b.interceptible {
+		return false
+	}
+
+	if policy.MinAge > 0 && b.endTime.Add(policy.MinAge).After(time.Now()) {
+		return false
+	}
+
+	return true
+}
+
+func (b *build) Pipeline() (Pipeline, bool, error) {
+	if b.pipelineID == 0 {
+		return nil, false, nil
+	}
+
+	row := pipelinesQuery.
+		Where(sq.Eq{"p.id": b.pipelineID}).
+		RunWith(b.conn).
+		QueryRow()
+
+	pipeline := newPipeline(b.conn, b.lockFactory)
+	err := scanPipeline(pipeline, row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	return pipeline, true, nil
+}
+
+// Job returns the build's job. Like looking the job up through Pipeline, it
+// is not found for one-off builds or if the job has since been removed from
+// the pipeline.
+func (b *build) Job() (Job, bool, error) {
+	if b.jobID == 0 {
+		return nil, false, nil
+	}
+
+	row := jobsQuery.
+		Where(sq.Eq{
+			"j.id":     b.jobID,
+			"j.active": true,
+		}).
+		RunWith(b.conn).
+		QueryRow()
+
+	job := &job{conn: b.conn, lockFactory: b.lockFactory}
+	err := scanJob(job, row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	return job, true, nil
+}
+
+// Reruns returns the builds which were created as reruns of the build, along
+// with their own reruns, in the order they were created.
+func (b *build) Reruns() ([]Build, error) {
+	rows, err := buildsQuery.
+		Where(sq.Expr(`b.id IN (
+			WITH RECURSIVE reruns(id) AS (
+				SELECT id FROM builds WHERE rerun_of = ?
+				UNION
+				SELECT r.id FROM builds r, reruns WHERE r.rerun_of = reruns.id
+			)
+			SELECT id FROM reruns
+		)`, b.id)).
+		OrderBy("b.id ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	reruns := []Build{}
+	for rows.Next() {
+		rerun := &build{conn: b.conn, lockFactory: b.lockFactory}
+		err = scanBuild(rerun, rows, b.conn.EncryptionStrategy())
+		if err != nil {
+			return nil, err
+		}
+
+		reruns = append(reruns, rerun)
+	}
+
+	return reruns, nil
+}
+
+// DownstreamJobs returns the jobs in the build's pipeline which have an input
+// passed through the build's job for a resource the build used or produced.
+func (b *build) DownstreamJobs() ([]Job, error) {
+	if b.jobID == 0 {
+		return []Job{}, nil
+	}
+
+	rows, err := psql.Select("DISTINCT r.name").
+		From("resources r").
+		Where(sq.Expr(`r.id IN (
+			SELECT resource_id FROM build_resource_config_version_inputs WHERE build_id = ?
+			UNION
+			SELECT resource_id FROM build_resource_config_version_outputs WHERE build_id = ?
+		)`, b.id, b.id)).
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	resourceNames := map[string]bool{}
+	for rows.Next() {
+		var name string
+		err = rows.Scan(&name)
+		if err != nil {
+			return nil, err
+		}
+
+		resourceNames[name] = true
+	}
+
+	pipeline, found, err := b.Pipeline()
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, ErrBuildHasNoPipeline
+	}
+
+	jobs, err := pipeline.Jobs()
+	if err != nil {
+		return nil, err
+	}
+
+	downstream := []Job{}
+	for _, job := range jobs {
+		if isDownstreamOf(job.Config(), b.jobName, resourceNames) {
+			downstream = append(downstream, job)
+		}
+	}
+
+	return downstream, nil
+}
+
+func isDownstreamOf(config atc.JobConfig, jobName string, resourceNames map[string]bool) bool {
+	for _, input := range config.Inputs() {
+		if !resourceNames[input.Resource] {
+			continue
+		}
+
+		for _, passed := range input.Passed {
+			if passed == jobName {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (b *build) SaveImageResourceVersion(rc UsedResourceCache) error {
+	_, err := psql.Insert("build_image_resource_caches").
+		Columns("resource_cache_id", "build_id").
+		Values(rc.ID(), b.id).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// RegisterResourceCacheUse records that the build uses the resource cache,
+// keeping it from being garbage collected until the ResourceCacheLifecycle's
+// grace period has passed since the build finished.
+func (b *build) RegisterResourceCacheUse(rc UsedResourceCache) error {
+	_, err := psql.Insert("build_resource_cache_uses").
+		Columns("build_id", "resource_cache_id").
+		Values(b.id, rc.ID()).
+		Suffix("ON CONFLICT (build_id, resource_cache_id) DO NOTHING").
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
+func (b *build) ResourceCacheUses() ([]UsedResourceCache, error) {
+	rows, err := psql.Select("resource_cache_id").
+		From("build_resource_cache_uses").
+		Where(sq.Eq{"build_id": b.id}).
+		OrderBy("resource_cache_id").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	cacheIDs := []int{}
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		cacheIDs = append(cacheIDs, id)
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	caches := []UsedResourceCache{}
+	for _, id := range cacheIDs {
+		rc, found, err := findResourceCacheByID(tx, id, b.lockFactory, b.conn)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			caches = append(caches, rc)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return caches, nil
+}
+
+func (b *build) AcquireTrackingLock(logger lager.Logger, interval time.Duration) (lock.Lock, bool, error) {
 	lock, acquired, err := b.lockFactory.Acquire(
 		logger.Session("lock", lager.Data{
 			"build_id": b.id,
@@ -548,13 +1517,19 @@ func (b *build) AcquireTrackingLock(logger lager.Logger, interval time.Duration)
 func (b *build) Preparation() (BuildPreparation, bool, error) {
 	if b.jobID == 0 || b.status != BuildStatusPending {
 		return BuildPreparation{
-			BuildID:             b.id,
-			PausedPipeline:      BuildPreparationStatusNotBlocking,
-			PausedJob:           BuildPreparationStatusNotBlocking,
-			MaxRunningBuilds:    BuildPreparationStatusNotBlocking,
-			Inputs:              map[string]BuildPreparationStatus{},
-			InputsSatisfied:     BuildPreparationStatusNotBlocking,
-			MissingInputReasons: MissingInputReasons{},
+			BuildID:               b.id,
+			PausedPipeline:        BuildPreparationStatusNotBlocking,
+			PausedJob:             BuildPreparationStatusNotBlocking,
+			MaxRunningBuilds:      BuildPreparationStatusNotBlocking,
+			Inputs:                map[string]BuildPreparationStatus{},
+			InputsSatisfied:       BuildPreparationStatusNotBlocking,
+			MissingInputReasons:   MissingInputReasons{},
+			MissingWorker:         BuildPreparationStatusNotBlocking,
+			MissingWorkerReasons:  MissingWorkerReasons{},
+			Held:                  BuildPreparationStatusNotBlocking,
+			SerialGroups:          BuildPreparationStatusNotBlocking,
+			AwaitingManualTrigger: BuildPreparationStatusNotBlocking,
+			FilteredInputs:        map[string]int{},
 		}, true, nil
 	}
 
@@ -564,15 +1539,18 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		maxInFlightReached bool
 		pipelineID         int
 		jobName            string
+		waitingForWorker   pq.StringArray
+		held               bool
+		serialGroupBusy    bool
 	)
-	err := psql.Select("p.paused, j.paused, j.max_in_flight_reached, j.pipeline_id, j.name").
+	err := psql.Select("p.paused, j.paused, j.max_in_flight_reached, j.pipeline_id, j.name, b.waiting_for_worker_tags, b.held", serialGroupBusyExpr).
 		From("builds b").
 		Join("jobs j ON b.job_id = j.id").
 		Join("pipelines p ON j.pipeline_id = p.id").
 		Where(sq.Eq{"b.id": b.id}).
 		RunWith(b.conn).
 		QueryRow().
-		Scan(&pausedPipeline, &pausedJob, &maxInFlightReached, &pipelineID, &jobName)
+		Scan(&pausedPipeline, &pausedJob, &maxInFlightReached, &pipelineID, &jobName, &waitingForWorker, &held, &serialGroupBusy)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return BuildPreparation{}, false, nil
@@ -595,6 +1573,23 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		maxInFlightReachedStatus = BuildPreparationStatusBlocking
 	}
 
+	heldStatus := BuildPreparationStatusNotBlocking
+	if held {
+		heldStatus = BuildPreparationStatusBlocking
+	}
+
+	serialGroupsStatus := BuildPreparationStatusNotBlocking
+	if serialGroupBusy {
+		serialGroupsStatus = BuildPreparationStatusBlocking
+	}
+
+	missingWorkerStatus := BuildPreparationStatusNotBlocking
+	missingWorkerReasons := MissingWorkerReasons{}
+	if waitingForWorker != nil {
+		missingWorkerStatus = BuildPreparationStatusBlocking
+		missingWorkerReasons.RegisterUnsatisfiableTags(waitingForWorker)
+	}
+
 	tf := NewTeamFactory(b.conn, b.lockFactory)
 	t, found, err := tf.FindTeam(b.teamName)
 	if err != nil {
@@ -633,6 +1628,7 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 	inputsSatisfiedStatus := BuildPreparationStatusBlocking
 	inputs := map[string]BuildPreparationStatus{}
 	missingInputReasons := MissingInputReasons{}
+	filteredInputs := map[string]int{}
 
 	if found {
 
@@ -659,6 +1655,27 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 				inputs[buildInput.Name] = BuildPreparationStatusNotBlocking
 			}
 		}
+
+		// a pinned version which was chosen for the next build but has since
+		// been removed takes its input mapping with it
+		for _, configInput := range configInputs {
+			if _, mapped := inputs[configInput.Name]; mapped {
+				continue
+			}
+
+			if configInput.Version == nil || configInput.Version.Pinned == nil {
+				continue
+			}
+
+			versionJSON, err := json.Marshal(configInput.Version.Pinned)
+			if err != nil {
+				return BuildPreparation{}, false, err
+			}
+
+			inputs[configInput.Name] = BuildPreparationStatusBlocking
+			missingInputReasons.RegisterPinnedVersionGone(configInput.Name, string(versionJSON))
+			inputsSatisfiedStatus = BuildPreparationStatusBlocking
+		}
 	} else {
 		buildInputs, err := job.GetIndependentBuildInputs()
 		if err != nil {
@@ -697,6 +1714,7 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 
 							if found {
 								missingInputReasons.RegisterPassedConstraint(configInput.Name)
+								filteredInputs[configInput.Name] = 1
 							} else {
 								missingInputReasons.RegisterPinnedVersionUnavailable(configInput.Name, string(versionJSON))
 							}
@@ -705,6 +1723,13 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 						}
 					} else {
 						missingInputReasons.RegisterPassedConstraint(configInput.Name)
+
+						filtered, err := b.countEnabledVersions(pipelineID, configInput.Resource)
+						if err != nil {
+							return BuildPreparation{}, false, err
+						}
+
+						filteredInputs[configInput.Name] = filtered
 					}
 				} else {
 					if configInput.Version != nil && configInput.Version.Pinned != nil {
@@ -722,60 +1747,531 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		}
 	}
 
+	awaitingManualTriggerStatus := BuildPreparationStatusNotBlocking
+	if inputsSatisfiedStatus == BuildPreparationStatusNotBlocking && !b.IsManuallyTriggered() {
+		awaitingManualTriggerStatus = BuildPreparationStatusBlocking
+		for _, configInput := range configInputs {
+			if configInput.Trigger {
+				awaitingManualTriggerStatus = BuildPreparationStatusNotBlocking
+				break
+			}
+		}
+	}
+
 	buildPreparation := BuildPreparation{
-		BuildID:             b.id,
-		PausedPipeline:      pausedPipelineStatus,
-		PausedJob:           pausedJobStatus,
-		MaxRunningBuilds:    maxInFlightReachedStatus,
-		Inputs:              inputs,
-		InputsSatisfied:     inputsSatisfiedStatus,
-		MissingInputReasons: missingInputReasons,
+		BuildID:               b.id,
+		PausedPipeline:        pausedPipelineStatus,
+		PausedJob:             pausedJobStatus,
+		MaxRunningBuilds:      maxInFlightReachedStatus,
+		Inputs:                inputs,
+		InputsSatisfied:       inputsSatisfiedStatus,
+		MissingInputReasons:   missingInputReasons,
+		MissingWorker:         missingWorkerStatus,
+		MissingWorkerReasons:  missingWorkerReasons,
+		Held:                  heldStatus,
+		SerialGroups:          serialGroupsStatus,
+		AwaitingManualTrigger: awaitingManualTriggerStatus,
+		FilteredInputs:        filteredInputs,
+	}
+
+	return buildPreparation, true, nil
+}
+
+// countEnabledVersions returns the number of checked versions of the named
+// resource of the pipeline which have not been disabled or deleted.
+func (b *build) countEnabledVersions(pipelineID int, resourceName string) (int, error) {
+	var count int
+	err := psql.Select("COUNT(*)").
+		From("resource_config_versions v").
+		Join("resources r ON r.resource_config_scope_id = v.resource_config_scope_id").
+		Where(sq.Eq{
+			"r.pipeline_id": pipelineID,
+			"r.name":        resourceName,
+		}).
+		Where(sq.NotEq{"v.check_order": 0}).
+		Where(sq.Eq{"v.deleted": false}).
+		Where(sq.Expr("(r.id, v.version_md5) NOT IN (SELECT resource_id, version_md5 FROM resource_disabled_versions)")).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&count)
+	return count, err
+}
+
+// serialGroupBusyExpr is true when another job of the pipeline which shares a
+// serial group with the job j has a scheduled build that has not completed.
+const serialGroupBusyExpr = `EXISTS (
+	SELECT 1
+	FROM jobs_serial_groups jsg
+	JOIN jobs_serial_groups ojsg ON ojsg.serial_group = jsg.serial_group AND ojsg.job_id != jsg.job_id
+	JOIN jobs oj ON oj.id = ojsg.job_id
+	JOIN builds ob ON ob.job_id = oj.id
+	WHERE jsg.job_id = j.id
+	AND oj.pipeline_id = j.pipeline_id
+	AND ob.scheduled
+	AND NOT ob.completed
+)`
+
+// enabledVersionsExpr summarizes the enabled versions of each resource of the
+// pipeline of the job j by their count and latest id, which change whenever a
+// version is saved, removed, deleted, disabled or enabled.
+const enabledVersionsExpr = `(
+	SELECT md5(string_agg(concat_ws(':', c.resource_id, c.versions, c.max_version_id), ',' ORDER BY c.resource_id))
+	FROM (
+		SELECT r.id AS resource_id, count(v.id) AS versions, max(v.id) AS max_version_id
+		FROM resources r
+		JOIN resource_config_versions v ON v.resource_config_scope_id = r.resource_config_scope_id
+		WHERE r.pipeline_id = j.pipeline_id
+		AND v.check_order != 0
+		AND NOT v.deleted
+		AND (r.id, v.version_md5) NOT IN (SELECT resource_id, version_md5 FROM resource_disabled_versions)
+		GROUP BY r.id
+	) c
+)`
+
+// buildPreparationKey identifies the state that a pending job build's
+// preparation is computed from, namely the job's config, its next and
+// independent input mappings, the enabled versions of the pipeline's
+// resources, whether the pipeline or job is paused or at max in flight,
+// whether the build is held or is waiting for a worker, and whether its
+// serial groups are busy. Manually triggered builds are never cached, so
+// whether the build was manually triggered is not part of the key.
+const buildPreparationKey = `concat_ws(',', b.status, b.held, p.paused, j.paused, j.max_in_flight_reached, j.inputs_determined, b.waiting_for_worker_tags, ` + serialGroupBusyExpr + `, ` + enabledVersionsExpr + `, md5(j.config), (
+	SELECT concat_ws(':', count(*), md5(string_agg(concat_ws(':', n.input_name, n.resource_config_version_id, n.resource_id, n.first_occurrence), ',' ORDER BY n.input_name)))
+	FROM next_build_inputs n
+	WHERE n.job_id = j.id
+), (
+	SELECT md5(string_agg(concat_ws(':', i.input_name, i.resource_config_version_id, i.resource_id, i.first_occurrence), ',' ORDER BY i.input_name))
+	FROM independent_build_inputs i
+	WHERE i.job_id = j.id
+))`
+
+// PreparationCached returns the build's preparation, reusing the last
+// computed preparation if the state it was computed from has not changed
+// since. The returned bool reports whether the cached preparation was used.
+//
+// Preparations for one-off and manually triggered builds are always
+// recomputed, as they are either trivial or depend on resource check times.
+func (b *build) PreparationCached() (BuildPreparation, bool, error) {
+	if b.jobID == 0 || b.isManuallyTriggered {
+		prep, found, err := b.Preparation()
+		if err != nil {
+			return BuildPreparation{}, false, err
+		}
+
+		if !found {
+			return BuildPreparation{}, false, ErrBuildDisappeared
+		}
+
+		return prep, false, nil
+	}
+
+	var (
+		cachedPreparation, cachedKey sql.NullString
+		key                          string
+	)
+	err := psql.Select("b.cached_preparation", "b.cached_preparation_key", buildPreparationKey).
+		From("builds b").
+		Join("jobs j ON b.job_id = j.id").
+		Join("pipelines p ON j.pipeline_id = p.id").
+		Where(sq.Eq{"b.id": b.id}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&cachedPreparation, &cachedKey, &key)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return BuildPreparation{}, false, ErrBuildDisappeared
+		}
+		return BuildPreparation{}, false, err
+	}
+
+	if cachedPreparation.Valid && cachedKey.String == key {
+		var prep BuildPreparation
+		err = json.Unmarshal([]byte(cachedPreparation.String), &prep)
+		if err != nil {
+			return BuildPreparation{}, false, err
+		}
+
+		return prep, true, nil
+	}
+
+	prep, found, err := b.Preparation()
+	if err != nil {
+		return BuildPreparation{}, false, err
+	}
+
+	if !found {
+		return BuildPreparation{}, false, ErrBuildDisappeared
+	}
+
+	payload, err := json.Marshal(prep)
+	if err != nil {
+		return BuildPreparation{}, false, err
+	}
+
+	_, err = psql.Update("builds").
+		Set("cached_preparation", string(payload)).
+		Set("cached_preparation_key", key).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return BuildPreparation{}, false, err
+	}
+
+	return prep, false, nil
+}
+
+// Events returns a source of the build's events starting at the given
+// offset. For completed builds, an offset beyond the saved events returns
+// ErrEventOffsetTooHigh, as no more events will arrive.
+func (b *build) Events(from uint) (EventSource, error) {
+	position := startOfEvents
+
+	if from > 0 {
+		var (
+			completed bool
+			count     uint
+			lastID    sql.NullInt64
+		)
+		err := psql.Select(
+			"b.completed",
+			"(SELECT COUNT(*) FROM "+b.eventsTable()+" e WHERE e.build_id = b.id)",
+			fmt.Sprintf("(SELECT e.event_id FROM %s e WHERE e.build_id = b.id ORDER BY e.event_id OFFSET %d LIMIT 1)", b.eventsTable(), from-1),
+		).
+			From("builds b").
+			Where(sq.Eq{"b.id": b.id}).
+			RunWith(b.conn).
+			QueryRow().
+			Scan(&completed, &count, &lastID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, ErrBuildDisappeared
+			}
+			return nil, err
+		}
+
+		if completed && from > count {
+			return nil, ErrEventOffsetTooHigh
+		}
+
+		if lastID.Valid {
+			position = afterEvent(lastID.Int64)
+		} else {
+			position = eventPosition{offset: from}
+		}
+	}
+
+	return b.eventsFrom(position, nil)
+}
+
+// EventsFromToken resumes the build's event stream from a token previously
+// returned by EventSource.ResumeToken. The token identifies the last event
+// read, so the stream resumes in the right place even if events before it
+// have since been removed.
+func (b *build) EventsFromToken(token string) (EventSource, error) {
+	buildID, position, err := decodeResumeToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if buildID != b.id {
+		return nil, ErrInvalidResumeToken
+	}
+
+	if !position.byID {
+		return b.Events(position.offset)
+	}
+
+	return b.eventsFrom(position, nil)
+}
+
+func (b *build) eventsFrom(position eventPosition, window *eventWindow) (EventSource, error) {
+	notifier, err := newConditionNotifier(b.conn.Bus(), buildEventsChannel(b.id), func() (bool, error) {
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newBuildEventSource(
+		b.id,
+		b.eventsTable(),
+		b.conn,
+		notifier,
+		position,
+		window,
+	), nil
+}
+
+// EventsFromID returns the build's event stream continuing after the event
+// with the given ID, as sent to clients by the event stream endpoints. Event
+// IDs count from 0; pass a negative ID to stream from the first event.
+func (b *build) EventsFromID(after int) (EventSource, error) {
+	if after < 0 {
+		return b.Events(0)
+	}
+
+	return b.Events(uint(after) + 1)
+}
+
+// ContainerHandles returns the handles of every container created for the
+// build's steps, including containers which are being destroyed.
+func (b *build) ContainerHandles() ([]string, error) {
+	rows, err := psql.Select("handle").
+		From("containers").
+		Where(sq.Eq{"build_id": b.id}).
+		OrderBy("id ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	handles := []string{}
+	for rows.Next() {
+		var handle string
+		err = rows.Scan(&handle)
+		if err != nil {
+			return nil, err
+		}
+
+		handles = append(handles, handle)
+	}
+
+	return handles, nil
+}
+
+// RedactedEvents is Events, with each event's payload passed through the
+// redactor before it is returned.
+func (b *build) RedactedEvents(from uint, redactor SecretRedactor) (EventSource, error) {
+	events, err := b.Events(from)
+	if err != nil {
+		return nil, err
+	}
+
+	return &redactingEventSource{
+		EventSource: events,
+		redactor:    redactor,
+	}, nil
+}
+
+// EventsBetween returns the build's events which were saved at or after start
+// and before end, in order. The stream ends once the build completes or the
+// window has passed. Its resume tokens continue the build's whole event
+// stream after the last event read.
+func (b *build) EventsBetween(start, end time.Time) (EventSource, error) {
+	return b.eventsFrom(startOfEvents, &eventWindow{start: start, end: end})
+}
+
+// EventsCompressed streams the build's events from the given offset as
+// gzip-compressed, newline-delimited JSON envelopes, for consumers with
+// little bandwidth. The stream ends once the build's events do. It can be
+// read with a CompressedEventDecoder.
+func (b *build) EventsCompressed(from uint) (io.ReadCloser, error) {
+	events, err := b.Events(from)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCompressedEventReader(events), nil
+}
+
+func (b *build) SaveEvent(event atc.Event) error {
+	return b.SaveEvents([]atc.Event{event})
+}
+
+// SaveEvents saves the events in order with a single insert, notifying
+// subscribers to the build's events once.
+func (b *build) SaveEvents(events []atc.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	err = b.saveEvents(tx, events)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return b.conn.Bus().Notify(buildEventsChannel(b.id))
+}
+
+// SaveEventWithSeq is SaveEvent for a client which numbers its own events, so
+// that retrying a save which may already have been committed is safe. An event
+// whose sequence number has already been saved for the build is skipped.
+func (b *build) SaveEventWithSeq(clientSeq int64, event atc.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	result, err := psql.Insert(b.eventsTable()).
+		Columns("event_id", "build_id", "type", "version", "payload", "client_seq").
+		Values(sq.Expr("nextval('"+buildEventSeq(b.id)+"')"), b.id, string(event.EventType()), string(event.Version()), payload, clientSeq).
+		Suffix("ON CONFLICT (build_id, client_seq) WHERE client_seq IS NOT NULL DO NOTHING").
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return nil
+	}
+
+	return b.conn.Bus().Notify(buildEventsChannel(b.id))
+}
+
+// EventOffsetAtFraction returns the offset of the event found at the given
+// fraction of the way through the build's event stream, suitable for passing
+// to Events. It is used by the UI to seek within a build's log.
+func (b *build) EventOffsetAtFraction(fraction float64) (uint, error) {
+	if math.IsNaN(fraction) || fraction < 0 || fraction > 1 {
+		return 0, ErrEventFractionOutOfRange
+	}
+
+	eventCount, err := b.EventCount()
+	if err != nil {
+		return 0, err
+	}
+
+	if eventCount == 0 {
+		return 0, nil
+	}
+
+	count := uint(eventCount)
+
+	offset := uint(fraction * float64(count))
+	if offset >= count {
+		offset = count - 1
+	}
+
+	return offset, nil
+}
+
+// EventCount returns the number of events saved for the build so far.
+func (b *build) EventCount() (int, error) {
+	var count int
+	err := psql.Select("COUNT(*)").
+		From(b.eventsTable()).
+		Where(sq.Eq{"build_id": b.id}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&count)
+	if err != nil {
+		return 0, err
 	}
 
-	return buildPreparation, true, nil
+	return count, nil
 }
 
-func (b *build) Events(from uint) (EventSource, error) {
-	notifier, err := newConditionNotifier(b.conn.Bus(), buildEventsChannel(b.id), func() (bool, error) {
-		return true, nil
-	})
+// LatestStatusEvent returns the build's most recently saved status event,
+// without reading through the rest of its events.
+func (b *build) LatestStatusEvent() (event.Envelope, bool, error) {
+	var (
+		t, v, p    string
+		insertedAt pq.NullTime
+	)
+
+	err := psql.Select("type", "version", "payload", "inserted_at").
+		From(b.eventsTable()).
+		Where(sq.Eq{
+			"build_id": b.id,
+			"type":     string(event.EventTypeStatus),
+		}).
+		OrderBy("event_id DESC").
+		Limit(1).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&t, &v, &p, &insertedAt)
 	if err != nil {
-		return nil, err
+		if err == sql.ErrNoRows {
+			return event.Envelope{}, false, nil
+		}
+		return event.Envelope{}, false, err
 	}
 
-	table := fmt.Sprintf("team_build_events_%d", b.teamID)
-	if b.pipelineID != 0 {
-		table = fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
+	data := json.RawMessage(p)
+
+	ev := event.Envelope{
+		Data:    &data,
+		Event:   atc.EventType(t),
+		Version: atc.EventVersion(v),
 	}
 
-	return newBuildEventSource(
-		b.id,
-		table,
-		b.conn,
-		notifier,
-		from,
-	), nil
-}
+	if insertedAt.Valid {
+		ev.InsertedAt = &insertedAt.Time
+	}
 
-func (b *build) SaveEvent(event atc.Event) error {
-	tx, err := b.conn.Begin()
+	ev, err = ev.Upgraded()
 	if err != nil {
-		return err
+		return event.Envelope{}, false, err
 	}
 
-	defer Rollback(tx)
+	return ev, true, nil
+}
 
-	err = b.saveEvent(tx, event)
+// trimmedLogPayload is what a trimmed log event's payload is replaced with.
+// The event itself is kept so that offsets into the event stream stay valid.
+const trimmedLogPayload = `(payload::jsonb || '{"payload": ""}')::text`
+
+// trimmableLogEvents matches the log events emitted before a cutoff time
+// which have not been trimmed yet. Events without a time are never matched.
+const trimmableLogEvents = `type = 'log'
+	AND (payload::json->>'time')::bigint > 0
+	AND (payload::json->>'time')::bigint < ?
+	AND payload::json->>'payload' <> ''`
+
+// TrimEvents empties the output of the build's log events which were emitted
+// before the given time, leaving all other events in place. The trimmed
+// events stay in the stream, so offsets and resume tokens are unaffected.
+func (b *build) TrimEvents(before time.Time) (int, error) {
+	result, err := psql.Update(b.eventsTable()).
+		Set("payload", sq.Expr(trimmedLogPayload)).
+		Where(sq.Eq{"build_id": b.id}).
+		Where(sq.Expr(trimmableLogEvents, before.Unix())).
+		RunWith(b.conn).
+		Exec()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	err = tx.Commit()
+	affected, err := result.RowsAffected()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	return b.conn.Bus().Notify(buildEventsChannel(b.id))
+	return int(affected), nil
 }
 
 func (b *build) Artifact(artifactID int) (WorkerArtifact, error) {
@@ -795,6 +2291,36 @@ func (b *build) Artifact(artifactID int) (WorkerArtifact, error) {
 	return &artifact, err
 }
 
+// ArtifactByName returns the most recently created artifact of the build with
+// the given name.
+func (b *build) ArtifactByName(name string) (WorkerArtifact, bool, error) {
+	artifact := artifact{
+		conn:    b.conn,
+		buildID: b.id,
+	}
+
+	err := psql.Select("id", "name", "created_at").
+		From("worker_artifacts").
+		Where(sq.Eq{
+			"build_id": b.id,
+			"name":     name,
+		}).
+		OrderBy("id DESC").
+		Limit(1).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&artifact.id, &artifact.name, &artifact.createdAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return &artifact, true, nil
+}
+
 func (b *build) Artifacts() ([]WorkerArtifact, error) {
 	artifacts := []WorkerArtifact{}
 
@@ -932,6 +2458,75 @@ func (b *build) SaveOutput(
 	return nil
 }
 
+// SaveOutputDeleted records that a put step of the build deleted the given
+// version of the resource. The version is marked as deleted so that it is no
+// longer used as an input, and is removed from the build's outputs. Whether
+// the version is disabled is left alone.
+func (b *build) SaveOutputDeleted(resourceName string, version atc.Version) error {
+	if b.pipelineID == 0 {
+		return ErrBuildHasNoPipeline
+	}
+
+	pipeline, found, err := b.Pipeline()
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return ErrBuildHasNoPipeline
+	}
+
+	resource, found, err := pipeline.Resource(resourceName)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return ResourceNotFoundInPipeline{resourceName, b.pipelineName}
+	}
+
+	versionBytes, err := json.Marshal(version)
+	if err != nil {
+		return err
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Update("resource_config_versions").
+		Set("deleted", true).
+		Where(sq.Eq{"resource_config_scope_id": resource.ResourceConfigScopeID()}).
+		Where(sq.Expr("version_md5 = md5(?)", string(versionBytes))).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Delete("build_resource_config_version_outputs").
+		Where(sq.Eq{
+			"build_id":    b.id,
+			"resource_id": resource.ID(),
+		}).
+		Where(sq.Expr("version_md5 = md5(?)", string(versionBytes))).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return bumpCacheIndexForPipelinesUsingResourceConfigScope(b.conn, resource.ResourceConfigScopeID())
+}
+
 func (b *build) UseInputs(inputs []BuildInput) error {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -955,6 +2550,18 @@ func (b *build) UseInputs(inputs []BuildInput) error {
 		}
 	}
 
+	var inputsDeterminedAt time.Time
+	err = psql.Update("builds").
+		Set("inputs_determined_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": b.id}).
+		Suffix("RETURNING inputs_determined_at").
+		RunWith(tx).
+		QueryRow().
+		Scan(&inputsDeterminedAt)
+	if err != nil {
+		return err
+	}
+
 	if b.pipelineID != 0 {
 		err = bumpCacheIndex(tx, b.pipelineID)
 		if err != nil {
@@ -962,10 +2569,27 @@ func (b *build) UseInputs(inputs []BuildInput) error {
 		}
 	}
 
-	return tx.Commit()
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	b.inputsDeterminedAt = inputsDeterminedAt
+
+	return nil
 }
 
 func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
+	return b.resources(false)
+}
+
+// ResourcesWithMetadata is like Resources, but also returns the metadata of
+// each input's version so that it does not have to be looked up separately.
+func (b *build) ResourcesWithMetadata() ([]BuildInput, []BuildOutput, error) {
+	return b.resources(true)
+}
+
+func (b *build) resources(withInputMetadata bool) ([]BuildInput, []BuildOutput, error) {
 	inputs := []BuildInput{}
 	outputs := []BuildOutput{}
 
@@ -981,7 +2605,7 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 			AND i.build_id < builds.id
 		)`
 
-	rows, err := psql.Select("inputs.name", "resources.id", "versions.version", firstOccurrence).
+	rows, err := psql.Select("inputs.name", "resources.id", "versions.version", firstOccurrence, "versions.metadata").
 		From("resource_config_versions versions, build_resource_config_version_inputs inputs, builds, resources").
 		Where(sq.Eq{"builds.id": b.id}).
 		Where(sq.NotEq{"versions.check_order": 0}).
@@ -1012,9 +2636,11 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 			versionBlob     string
 			version         atc.Version
 			resourceID      int
+			metadataBlob    sql.NullString
+			metadata        ResourceConfigMetadataFields
 		)
 
-		err = rows.Scan(&inputName, &resourceID, &versionBlob, &firstOccurrence)
+		err = rows.Scan(&inputName, &resourceID, &versionBlob, &firstOccurrence, &metadataBlob)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -1024,15 +2650,23 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 			return nil, nil, err
 		}
 
+		if withInputMetadata && metadataBlob.Valid {
+			err = json.Unmarshal([]byte(metadataBlob.String), &metadata)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
 		inputs = append(inputs, BuildInput{
 			Name:            inputName,
 			Version:         version,
 			ResourceID:      resourceID,
 			FirstOccurrence: firstOccurrence,
+			Metadata:        metadata,
 		})
 	}
 
-	rows, err = psql.Select("outputs.name", "versions.version").
+	rows, err = psql.Select("outputs.name", "resources.id", "versions.version", "versions.metadata").
 		From("resource_config_versions versions, build_resource_config_version_outputs outputs, builds, resources").
 		Where(sq.Eq{"builds.id": b.id}).
 		Where(sq.NotEq{"versions.check_order": 0}).
@@ -1051,12 +2685,15 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 
 	for rows.Next() {
 		var (
-			outputName  string
-			versionBlob string
-			version     atc.Version
+			outputName   string
+			resourceID   int
+			versionBlob  string
+			version      atc.Version
+			metadataBlob sql.NullString
+			metadata     ResourceConfigMetadataFields
 		)
 
-		err := rows.Scan(&outputName, &versionBlob)
+		err := rows.Scan(&outputName, &resourceID, &versionBlob, &metadataBlob)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -1066,15 +2703,148 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 			return nil, nil, err
 		}
 
+		if metadataBlob.Valid {
+			err = json.Unmarshal([]byte(metadataBlob.String), &metadata)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
 		outputs = append(outputs, BuildOutput{
-			Name:    outputName,
-			Version: version,
+			Name:       outputName,
+			Version:    version,
+			Metadata:   metadata,
+			ResourceID: resourceID,
 		})
 	}
 
 	return inputs, outputs, nil
 }
 
+// InputVersions returns the version used for each of the build's inputs,
+// keyed by input name. One-off builds have no inputs.
+func (b *build) InputVersions() (map[string]atc.Version, error) {
+	versions := map[string]atc.Version{}
+
+	if b.jobID == 0 {
+		return versions, nil
+	}
+
+	rows, err := psql.Select("inputs.name", "versions.version").
+		From("build_resource_config_version_inputs inputs").
+		Join("resources ON resources.id = inputs.resource_id").
+		Join("resource_config_versions versions ON versions.version_md5 = inputs.version_md5").
+		Where(sq.Expr("versions.resource_config_scope_id = resources.resource_config_scope_id")).
+		Where(sq.Eq{"inputs.build_id": b.id}).
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var (
+			inputName   string
+			versionBlob string
+			version     atc.Version
+		)
+
+		err = rows.Scan(&inputName, &versionBlob)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(versionBlob), &version)
+		if err != nil {
+			return nil, err
+		}
+
+		versions[inputName] = version
+	}
+
+	return versions, nil
+}
+
+// UpdateOutputMetadata replaces the metadata of the versions the build saved
+// as the named output, e.g. once an external process has learned more about
+// them after the build finished.
+func (b *build) UpdateOutputMetadata(outputName string, fields []ResourceConfigMetadataField) error {
+	metadataJSON, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	result, err := b.conn.Exec(`
+		UPDATE resource_config_versions v
+		SET metadata = $1
+		FROM build_resource_config_version_outputs o, resources r
+		WHERE o.build_id = $2
+		AND o.name = $3
+		AND r.id = o.resource_id
+		AND v.version_md5 = o.version_md5
+		AND v.resource_config_scope_id = r.resource_config_scope_id
+	`, string(metadataJSON), b.id, outputName)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrBuildOutputNotFound
+	}
+
+	return nil
+}
+
+// OutputsSince returns the build's outputs saved after the output with the
+// given ID, ordered by ID. Passing 0 returns all of the build's outputs.
+func (b *build) OutputsSince(outputID int) ([]BuildOutput, error) {
+	rows, err := psql.Select("outputs.id", "outputs.name", "versions.version").
+		From("resource_config_versions versions, build_resource_config_version_outputs outputs, resources").
+		Where(sq.Eq{"outputs.build_id": b.id}).
+		Where(sq.Gt{"outputs.id": outputID}).
+		Where(sq.NotEq{"versions.check_order": 0}).
+		Where(sq.Expr("outputs.version_md5 = versions.version_md5")).
+		Where(sq.Expr("outputs.resource_id = resources.id")).
+		Where(sq.Expr("resources.resource_config_scope_id = versions.resource_config_scope_id")).
+		OrderBy("outputs.id ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	outputs := []BuildOutput{}
+	for rows.Next() {
+		var (
+			output      BuildOutput
+			versionBlob string
+		)
+
+		err := rows.Scan(&output.ID, &output.Name, &versionBlob)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(versionBlob), &output.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs = append(outputs, output)
+	}
+
+	return outputs, nil
+}
+
 func (p *build) saveInputTx(tx Tx, buildID int, input BuildInput) error {
 	versionJSON, err := json.Marshal(input.Version)
 	if err != nil {
@@ -1107,17 +2877,21 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		jobID, pipelineID                                      sql.NullInt64
 		schema, privatePlan, jobName, pipelineName, publicPlan sql.NullString
 		createTime, startTime, endTime, reapTime               pq.NullTime
-		nonce                                                  sql.NullString
+		inputsDeterminedAt                                     pq.NullTime
+		nonce, abortReason                                     sql.NullString
 		drained, aborted, completed                            bool
-		status                                                 string
+		status, origin                                         string
+		tags                                                   pq.StringArray
+		interceptible                                          sql.NullBool
 	)
 
-	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &schema, &privatePlan, &publicPlan, &createTime, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce, &drained, &aborted, &completed)
+	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &schema, &privatePlan, &publicPlan, &createTime, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce, &drained, &aborted, &completed, &inputsDeterminedAt, &b.retryCount, &b.held, &origin, &abortReason, &tags, &b.keep, &interceptible, &b.inputsCount, &b.outputsCount)
 	if err != nil {
 		return err
 	}
 
 	b.status = BuildStatus(status)
+	b.origin = BuildOrigin(origin)
 	b.jobName = jobName.String
 	b.jobID = int(jobID.Int64)
 	b.pipelineName = pipelineName.String
@@ -1127,9 +2901,13 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 	b.startTime = startTime.Time
 	b.endTime = endTime.Time
 	b.reapTime = reapTime.Time
+	b.inputsDeterminedAt = inputsDeterminedAt.Time
 	b.drained = drained
 	b.aborted = aborted
+	b.abortReason = abortReason.String
 	b.completed = completed
+	b.tags = tags
+	b.interceptible = !interceptible.Valid || interceptible.Bool
 
 	var (
 		noncense      *string
@@ -1146,6 +2924,9 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		decryptedPlan = []byte(privatePlan.String)
 	}
 
+	b.privatePlan = atc.Plan{}
+	b.hasPrivatePlan = len(decryptedPlan) > 0
+
 	if len(decryptedPlan) > 0 {
 		err = json.Unmarshal(decryptedPlan, &b.privatePlan)
 		if err != nil {
@@ -1164,23 +2945,91 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 }
 
 func (b *build) saveEvent(tx Tx, event atc.Event) error {
-	payload, err := json.Marshal(event)
+	return b.saveEvents(tx, []atc.Event{event})
+}
+
+func (b *build) saveEvents(tx Tx, events []atc.Event) error {
+	events, err := b.withoutRepeatedStatuses(tx, events)
 	if err != nil {
 		return err
 	}
 
-	table := fmt.Sprintf("team_build_events_%d", b.teamID)
-	if b.pipelineID != 0 {
-		table = fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
+	if len(events) == 0 {
+		return nil
+	}
+
+	insert := psql.Insert(b.eventsTable()).
+		Columns("event_id", "build_id", "type", "version", "payload")
+
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		insert = insert.Values(sq.Expr("nextval('"+buildEventSeq(b.id)+"')"), b.id, string(event.EventType()), string(event.Version()), payload)
 	}
-	_, err = psql.Insert(table).
-		Columns("event_id", "build_id", "type", "version", "payload").
-		Values(sq.Expr("nextval('"+buildEventSeq(b.id)+"')"), b.id, string(event.EventType()), string(event.Version()), payload).
+
+	_, err = insert.
 		RunWith(tx).
 		Exec()
 	return err
 }
 
+// withoutRepeatedStatuses drops status events which repeat the status of the
+// build's preceding status event, as retried saves can produce them.
+func (b *build) withoutRepeatedStatuses(tx Tx, events []atc.Event) ([]atc.Event, error) {
+	var (
+		lastStatus string
+		loaded     bool
+	)
+
+	kept := make([]atc.Event, 0, len(events))
+	for _, ev := range events {
+		status, ok := ev.(event.Status)
+		if !ok {
+			kept = append(kept, ev)
+			continue
+		}
+
+		if !loaded {
+			err := psql.Select("payload::json->>'status'").
+				From(b.eventsTable()).
+				Where(sq.Eq{
+					"build_id": b.id,
+					"type":     string(event.EventTypeStatus),
+				}).
+				OrderBy("event_id DESC").
+				Limit(1).
+				RunWith(tx).
+				QueryRow().
+				Scan(&lastStatus)
+			if err != nil && err != sql.ErrNoRows {
+				return nil, err
+			}
+
+			loaded = true
+		}
+
+		if string(status.Status) == lastStatus {
+			continue
+		}
+
+		lastStatus = string(status.Status)
+		kept = append(kept, ev)
+	}
+
+	return kept, nil
+}
+
+func (b *build) eventsTable() string {
+	if b.pipelineID != 0 {
+		return fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
+	}
+
+	return fmt.Sprintf("team_build_events_%d", b.teamID)
+}
+
 func createBuild(tx Tx, build *build, vals map[string]interface{}) error {
 	var buildID int
 	err := psql.Insert("builds").
@@ -1218,6 +3067,10 @@ func buildAbortChannel(buildID int) string {
 	return fmt.Sprintf("build_abort_%d", buildID)
 }
 
+func buildCompletionChannel(buildID int) string {
+	return fmt.Sprintf("build_completion_%d", buildID)
+}
+
 func updateNextBuildForJob(tx Tx, jobID int) error {
 	_, err := tx.Exec(`
 		UPDATE jobs AS j
@@ -1,30 +1,106 @@
 package db
 
 import (
+	"compress/gzip"
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"sync"
+	"time"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/event"
+	"github.com/lib/pq"
 )
 
 var ErrEndOfBuildEventStream = errors.New("end of build event stream")
 var ErrBuildEventStreamClosed = errors.New("build event stream closed")
+var ErrInvalidResumeToken = errors.New("invalid event stream resume token")
+var ErrInvalidEventBatchSize = errors.New("event batch size must be at least 1")
 
 //go:generate counterfeiter . EventSource
 
 type EventSource interface {
 	Next() (event.Envelope, error)
+	NextContext(ctx context.Context) (event.Envelope, error)
+	NextBatch(max int) ([]event.Envelope, error)
+	NextBatchContext(ctx context.Context, max int) ([]event.Envelope, error)
+	ResumeToken() string
 	Close() error
 }
 
+//go:generate counterfeiter . SecretRedactor
+
+// SecretRedactor removes secrets from an event's JSON payload. The redacted
+// payload must still be valid JSON.
+type SecretRedactor interface {
+	Redact(payload []byte) []byte
+}
+
+type redactingEventSource struct {
+	EventSource
+
+	redactor SecretRedactor
+}
+
+func (source *redactingEventSource) Next() (event.Envelope, error) {
+	e, err := source.EventSource.Next()
+	if err != nil {
+		return e, err
+	}
+
+	return source.redact(e), nil
+}
+
+func (source *redactingEventSource) NextContext(ctx context.Context) (event.Envelope, error) {
+	e, err := source.EventSource.NextContext(ctx)
+	if err != nil {
+		return e, err
+	}
+
+	return source.redact(e), nil
+}
+
+func (source *redactingEventSource) NextBatch(max int) ([]event.Envelope, error) {
+	return source.NextBatchContext(context.Background(), max)
+}
+
+func (source *redactingEventSource) NextBatchContext(ctx context.Context, max int) ([]event.Envelope, error) {
+	batch, err := source.EventSource.NextBatchContext(ctx, max)
+	if err != nil {
+		return batch, err
+	}
+
+	redacted := make([]event.Envelope, len(batch))
+	for i, e := range batch {
+		redacted[i] = source.redact(e)
+	}
+
+	return redacted, nil
+}
+
+func (source *redactingEventSource) redact(e event.Envelope) event.Envelope {
+	if e.Data == nil {
+		return e
+	}
+
+	data := json.RawMessage(source.redactor.Redact(*e.Data))
+	e.Data = &data
+
+	return e
+}
+
 func newBuildEventSource(
 	buildID int,
 	table string,
 	conn Conn,
 	notifier Notifier,
-	from uint,
+	from eventPosition,
+	window *eventWindow,
 ) *buildEventSource {
 	wg := new(sync.WaitGroup)
 
@@ -36,7 +112,10 @@ func newBuildEventSource(
 
 		notifier: notifier,
 
-		events: make(chan event.Envelope, 2000),
+		position: from,
+		window:   window,
+
+		events: make(chan positionedEvent, 2000),
 		stop:   make(chan struct{}),
 		wg:     wg,
 	}
@@ -47,6 +126,35 @@ func newBuildEventSource(
 	return source
 }
 
+// eventPosition is a position in a build's event stream. Once an event has
+// been read, positions are kept as the ID of the last event read, so that
+// they are not affected by other events being removed. Until then, a stream
+// opened at an offset past the saved events is positioned by that offset.
+type eventPosition struct {
+	afterID int64
+	offset  uint
+	byID    bool
+}
+
+// startOfEvents is the position before the first event of a build.
+var startOfEvents = eventPosition{afterID: -1, byID: true}
+
+func afterEvent(eventID int64) eventPosition {
+	return eventPosition{afterID: eventID, byID: true}
+}
+
+type positionedEvent struct {
+	envelope event.Envelope
+	position eventPosition
+}
+
+// eventWindow limits a buildEventSource to events inserted at or after start
+// and before end.
+type eventWindow struct {
+	start time.Time
+	end   time.Time
+}
+
 type buildEventSource struct {
 	buildID int
 	table   string
@@ -54,19 +162,106 @@ type buildEventSource struct {
 	conn     Conn
 	notifier Notifier
 
-	events chan event.Envelope
+	positionL sync.Mutex
+	position  eventPosition
+
+	window *eventWindow
+
+	events chan positionedEvent
 	stop   chan struct{}
 	err    error
 	wg     *sync.WaitGroup
 }
 
 func (source *buildEventSource) Next() (event.Envelope, error) {
-	e, ok := <-source.events
+	return source.NextContext(context.Background())
+}
+
+// NextContext is Next, but gives up waiting with the context's error once the
+// context is done. The stream stays open and can still be read afterwards.
+func (source *buildEventSource) NextContext(ctx context.Context) (event.Envelope, error) {
+	var e positionedEvent
+	var ok bool
+
+	select {
+	case e, ok = <-source.events:
+	case <-ctx.Done():
+		return event.Envelope{}, ctx.Err()
+	}
+
 	if !ok {
 		return event.Envelope{}, source.err
 	}
 
-	return e, nil
+	source.positionL.Lock()
+	source.position = e.position
+	source.positionL.Unlock()
+
+	return e.envelope, nil
+}
+
+// NextBatch blocks until at least one event is available, then returns it
+// along with up to max-1 further events which are already buffered. A max
+// below 1 is rejected with ErrInvalidEventBatchSize. Once the stream has ended
+// or been closed, it returns the same error as Next.
+func (source *buildEventSource) NextBatch(max int) ([]event.Envelope, error) {
+	return source.NextBatchContext(context.Background(), max)
+}
+
+// NextBatchContext is NextBatch, but gives up waiting with the context's error
+// once the context is done, like NextContext.
+func (source *buildEventSource) NextBatchContext(ctx context.Context, max int) ([]event.Envelope, error) {
+	if max < 1 {
+		return nil, ErrInvalidEventBatchSize
+	}
+
+	var e positionedEvent
+	var ok bool
+
+	select {
+	case e, ok = <-source.events:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	if !ok {
+		return nil, source.err
+	}
+
+	batch := []event.Envelope{e.envelope}
+	last := e
+
+drain:
+	for len(batch) < max {
+		select {
+		case e, ok := <-source.events:
+			if !ok {
+				break drain
+			}
+
+			batch = append(batch, e.envelope)
+			last = e
+		default:
+			break drain
+		}
+	}
+
+	source.positionL.Lock()
+	source.position = last.position
+	source.positionL.Unlock()
+
+	return batch, nil
+}
+
+// ResumeToken returns an opaque token identifying the position after the last
+// event returned by Next, which can be passed to Build.EventsFromToken to
+// continue from the same place.
+func (source *buildEventSource) ResumeToken() string {
+	source.positionL.Lock()
+	position := source.position
+	source.positionL.Unlock()
+
+	return encodeResumeToken(source.buildID, position)
 }
 
 func (source *buildEventSource) Close() error {
@@ -82,7 +277,7 @@ func (source *buildEventSource) Close() error {
 	return source.notifier.Close()
 }
 
-func (source *buildEventSource) collectEvents(cursor uint) {
+func (source *buildEventSource) collectEvents(cursor eventPosition) {
 	defer source.wg.Done()
 
 	var batchSize = cap(source.events)
@@ -109,14 +304,30 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 			return
 		}
 
-		rows, err := source.conn.Query(`
-			SELECT type, version, payload
-			FROM `+source.table+`
-			WHERE build_id = $1
-			ORDER BY event_id ASC
-			OFFSET $2
-			LIMIT $3
-		`, source.buildID, cursor, batchSize)
+		query := psql.Select("event_id", "type", "version", "payload", "inserted_at").
+			From(source.table).
+			Where(sq.Eq{"build_id": source.buildID}).
+			OrderBy("event_id ASC").
+			Limit(uint64(batchSize))
+
+		if cursor.byID {
+			query = query.Where(sq.Gt{"event_id": cursor.afterID})
+		} else {
+			query = query.Offset(uint64(cursor.offset))
+		}
+
+		windowEnded := false
+		if source.window != nil {
+			// events are stamped on insert, so once the window has passed no
+			// more events can fall within it
+			windowEnded = time.Now().After(source.window.end)
+
+			query = query.
+				Where(sq.GtOrEq{"inserted_at": source.window.start}).
+				Where(sq.Lt{"inserted_at": source.window.end})
+		}
+
+		rows, err := query.RunWith(source.conn).Query()
 		if err != nil {
 			source.err = err
 			close(source.events)
@@ -128,10 +339,10 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 		for rows.Next() {
 			rowsReturned++
 
-			cursor++
-
+			var id int64
 			var t, v, p string
-			err := rows.Scan(&t, &v, &p)
+			var insertedAt pq.NullTime
+			err := rows.Scan(&id, &t, &v, &p, &insertedAt)
 			if err != nil {
 				_ = rows.Close()
 
@@ -140,6 +351,8 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 				return
 			}
 
+			cursor = afterEvent(id)
+
 			data := json.RawMessage(p)
 
 			ev := event.Envelope{
@@ -148,8 +361,21 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 				Version: atc.EventVersion(v),
 			}
 
+			if insertedAt.Valid {
+				ev.InsertedAt = &insertedAt.Time
+			}
+
+			ev, err = ev.Upgraded()
+			if err != nil {
+				_ = rows.Close()
+
+				source.err = err
+				close(source.events)
+				return
+			}
+
 			select {
-			case source.events <- ev:
+			case source.events <- positionedEvent{envelope: ev, position: cursor}:
 			case <-source.stop:
 				_ = rows.Close()
 
@@ -164,7 +390,7 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 			continue
 		}
 
-		if completed {
+		if completed || windowEnded {
 			source.err = ErrEndOfBuildEventStream
 			close(source.events)
 			return
@@ -179,3 +405,135 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 		}
 	}
 }
+
+func encodeResumeToken(buildID int, position eventPosition) string {
+	var payload string
+	if position.byID {
+		payload = fmt.Sprintf("%d:id:%d", buildID, position.afterID)
+	} else {
+		payload = fmt.Sprintf("%d:offset:%d", buildID, position.offset)
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(payload))
+}
+
+func decodeResumeToken(token string) (int, eventPosition, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, eventPosition{}, ErrInvalidResumeToken
+	}
+
+	var buildID int
+	var position eventPosition
+
+	_, err = fmt.Sscanf(string(payload), "%d:id:%d", &buildID, &position.afterID)
+	if err == nil && position.afterID >= -1 {
+		position.byID = true
+		return buildID, position, nil
+	}
+
+	_, err = fmt.Sscanf(string(payload), "%d:offset:%d", &buildID, &position.offset)
+	if err == nil {
+		return buildID, position, nil
+	}
+
+	return 0, eventPosition{}, ErrInvalidResumeToken
+}
+
+// newCompressedEventReader returns a reader of the events from the source as
+// gzip-compressed, newline-delimited JSON envelopes. Each event is flushed as
+// soon as it is read so that readers are not held up by buffering. Closing the
+// reader closes the source.
+func newCompressedEventReader(events EventSource) io.ReadCloser {
+	reader, writer := io.Pipe()
+
+	go func() {
+		gz := gzip.NewWriter(writer)
+		encoder := json.NewEncoder(gz)
+
+		// write the gzip header straight away so that readers can start
+		// decoding before the first event arrives
+		err := gz.Flush()
+		if err != nil {
+			_ = writer.CloseWithError(err)
+			return
+		}
+
+		for {
+			ev, err := events.Next()
+			if err != nil {
+				if err == ErrEndOfBuildEventStream {
+					err = gz.Close()
+				}
+
+				_ = writer.CloseWithError(err)
+				return
+			}
+
+			err = encoder.Encode(ev)
+			if err == nil {
+				err = gz.Flush()
+			}
+
+			if err != nil {
+				_ = writer.CloseWithError(err)
+				return
+			}
+		}
+	}()
+
+	return &compressedEventReader{
+		PipeReader: reader,
+		events:     events,
+	}
+}
+
+type compressedEventReader struct {
+	*io.PipeReader
+
+	events EventSource
+}
+
+func (reader *compressedEventReader) Close() error {
+	err := reader.events.Close()
+	if err != nil {
+		return err
+	}
+
+	return reader.PipeReader.Close()
+}
+
+// CompressedEventDecoder reads the events streamed by Build.EventsCompressed.
+type CompressedEventDecoder struct {
+	decoder *json.Decoder
+}
+
+// NewCompressedEventDecoder returns a decoder of the compressed events read
+// from r.
+func NewCompressedEventDecoder(r io.Reader) (*CompressedEventDecoder, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CompressedEventDecoder{
+		decoder: json.NewDecoder(gz),
+	}, nil
+}
+
+// Next returns the next event, or ErrEndOfBuildEventStream once every event
+// has been read.
+func (decoder *CompressedEventDecoder) Next() (event.Envelope, error) {
+	var ev event.Envelope
+
+	err := decoder.decoder.Decode(&ev)
+	if err == io.EOF {
+		return event.Envelope{}, ErrEndOfBuildEventStream
+	}
+
+	if err != nil {
+		return event.Envelope{}, err
+	}
+
+	return ev, nil
+}
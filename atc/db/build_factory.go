@@ -19,8 +19,10 @@ type BuildFactory interface {
 	PublicBuilds(Page) ([]Build, Pagination, error)
 	GetAllStartedBuilds() ([]Build, error)
 	GetDrainableBuilds() ([]Build, error)
+	NextBuildToScheduleFair() (Build, bool, error)
 	// TODO: move to BuildLifecycle, new interface (see WorkerLifecycle)
 	MarkNonInterceptibleBuilds() error
+	DeleteExpiredOneOffBuilds() error
 }
 
 type buildFactory struct {
@@ -105,6 +107,70 @@ func (f *buildFactory) MarkNonInterceptibleBuilds() error {
 	return err
 }
 
+// DeleteExpiredOneOffBuilds deletes the completed one-off builds whose expiry,
+// set by Build.SetExpiry, has passed, along with their events.
+func (f *buildFactory) DeleteExpiredOneOffBuilds() error {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	rows, err := psql.Select("id", "team_id").
+		From("builds").
+		Where(sq.Eq{
+			"job_id":    nil,
+			"completed": true,
+		}).
+		Where(sq.Expr("expires_at < now()")).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	buildIDs := []int{}
+	teamBuildIDs := map[int][]int{}
+	for rows.Next() {
+		var buildID, teamID int
+		err = rows.Scan(&buildID, &teamID)
+		if err != nil {
+			Close(rows)
+			return err
+		}
+
+		buildIDs = append(buildIDs, buildID)
+		teamBuildIDs[teamID] = append(teamBuildIDs[teamID], buildID)
+	}
+
+	Close(rows)
+
+	if len(buildIDs) == 0 {
+		return nil
+	}
+
+	for teamID, ids := range teamBuildIDs {
+		_, err = psql.Delete(fmt.Sprintf("team_build_events_%d", teamID)).
+			Where(sq.Eq{"build_id": ids}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = psql.Delete("builds").
+		Where(sq.Eq{"id": buildIDs}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func (f *buildFactory) GetDrainableBuilds() ([]Build, error) {
 	query := buildsQuery.Where(sq.Eq{
 		"b.completed": true,
@@ -122,6 +188,41 @@ func (f *buildFactory) GetAllStartedBuilds() ([]Build, error) {
 	return getBuilds(query, f.conn, f.lockFactory)
 }
 
+// NextBuildToScheduleFair returns the oldest pending build of the team which
+// has gone the longest without starting a build, so that pending builds are
+// round-robined across teams rather than scheduled strictly in order. Held
+// builds are skipped.
+func (f *buildFactory) NextBuildToScheduleFair() (Build, bool, error) {
+	build := &build{
+		conn:        f.conn,
+		lockFactory: f.lockFactory,
+	}
+
+	row := buildsQuery.
+		Where(sq.Eq{
+			"b.status":  BuildStatusPending,
+			"b.aborted": false,
+			"b.held":    false,
+		}).
+		OrderBy(
+			"(SELECT MAX(s.start_time) FROM builds s WHERE s.team_id = b.team_id) ASC NULLS FIRST",
+			"b.id ASC",
+		).
+		Limit(1).
+		RunWith(f.conn).
+		QueryRow()
+
+	err := scanBuild(build, row, f.conn.EncryptionStrategy())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	return build, true, nil
+}
+
 func getBuilds(buildsQuery sq.SelectBuilder, conn Conn, lockFactory lock.LockFactory) ([]Build, error) {
 	rows, err := buildsQuery.RunWith(conn).Query()
 	if err != nil {
@@ -1,8 +1,11 @@
 package db_test
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -40,6 +43,53 @@ var _ = Describe("BuildFactory", func() {
 		})
 	})
 
+	Describe("DeleteExpiredOneOffBuilds", func() {
+		It("deletes completed one-off builds which have expired, along with their events", func() {
+			expiredBuild, err := team.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(expiredBuild.SaveEvent(event.Log{Payload: "expired"})).To(Succeed())
+			Expect(expiredBuild.SetExpiry(-time.Minute)).To(Succeed())
+			Expect(expiredBuild.Finish(db.BuildStatusSucceeded)).To(Succeed())
+
+			unexpiredBuild, err := team.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(unexpiredBuild.SetExpiry(time.Hour)).To(Succeed())
+			Expect(unexpiredBuild.Finish(db.BuildStatusSucceeded)).To(Succeed())
+
+			runningBuild, err := team.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(runningBuild.SetExpiry(-time.Minute)).To(Succeed())
+
+			err = buildFactory.DeleteExpiredOneOffBuilds()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, found, err := buildFactory.Build(expiredBuild.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			var eventCount int
+			err = dbConn.QueryRow(`SELECT COUNT(*) FROM build_events WHERE build_id = $1`, expiredBuild.ID()).Scan(&eventCount)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(eventCount).To(BeZero())
+
+			_, found, err = buildFactory.Build(unexpiredBuild.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, found, err = buildFactory.Build(runningBuild.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("does not allow job builds to expire", func() {
+			build, err := defaultJob.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SetExpiry(time.Hour)
+			Expect(err).To(Equal(db.ErrBuildNotOneOff))
+		})
+	})
+
 	Describe("MarkNonInterceptibleBuilds", func() {
 		Context("one-off builds", func() {
 			DescribeTable("completed and within grace period",
@@ -402,4 +452,80 @@ var _ = Describe("BuildFactory", func() {
 			Expect(builds).To(ConsistOf(build1DB, build2DB))
 		})
 	})
+
+	Describe("NextBuildToScheduleFair", func() {
+		var otherTeam db.Team
+
+		BeforeEach(func() {
+			var err error
+			otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when there are no pending builds", func() {
+			It("returns not found", func() {
+				_, found, err := buildFactory.NextBuildToScheduleFair()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when both teams have pending builds", func() {
+			var teamBuilds, otherTeamBuilds []db.Build
+
+			BeforeEach(func() {
+				teamBuilds = nil
+				otherTeamBuilds = nil
+
+				for i := 0; i < 2; i++ {
+					build, err := team.CreateOneOffBuild()
+					Expect(err).NotTo(HaveOccurred())
+					teamBuilds = append(teamBuilds, build)
+				}
+
+				for i := 0; i < 2; i++ {
+					build, err := otherTeam.CreateOneOffBuild()
+					Expect(err).NotTo(HaveOccurred())
+					otherTeamBuilds = append(otherTeamBuilds, build)
+				}
+			})
+
+			It("alternates between the teams as builds are started", func() {
+				expected := []db.Build{
+					teamBuilds[0],
+					otherTeamBuilds[0],
+					teamBuilds[1],
+					otherTeamBuilds[1],
+				}
+
+				for _, expectedBuild := range expected {
+					build, found, err := buildFactory.NextBuildToScheduleFair()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(build.ID()).To(Equal(expectedBuild.ID()))
+
+					started, err := build.Start(atc.Plan{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(started).To(BeTrue())
+				}
+
+				_, found, err := buildFactory.NextBuildToScheduleFair()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			Context("when the next team's build is held", func() {
+				BeforeEach(func() {
+					Expect(teamBuilds[0].Hold()).To(Succeed())
+				})
+
+				It("skips the held build", func() {
+					build, found, err := buildFactory.NextBuildToScheduleFair()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(build.ID()).To(Equal(teamBuilds[1].ID()))
+				})
+			})
+		})
+	})
 })
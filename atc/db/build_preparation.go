@@ -1,6 +1,9 @@
 package db
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type BuildPreparationStatus string
 
@@ -17,8 +20,24 @@ const (
 	NoVersionsAvailable                  string = "no versions available"
 	NoResourceCheckFinished              string = "checking for latest available versions"
 	PinnedVersionUnavailable             string = "pinned version %s is not available"
+	PinnedVersionGone                    string = "pinned version %s is no longer available"
+)
+
+type MissingWorkerReasons map[string]string
+
+const (
+	NoWorkersAvailable     string = "no workers available"
+	NoWorkersSatisfiedTags string = "no workers satisfy tags %s"
 )
 
+func (mwr MissingWorkerReasons) RegisterUnsatisfiableTags(tags []string) {
+	if len(tags) == 0 {
+		mwr["tags"] = NoWorkersAvailable
+	} else {
+		mwr["tags"] = fmt.Sprintf(NoWorkersSatisfiedTags, strings.Join(tags, ", "))
+	}
+}
+
 func (mir MissingInputReasons) RegisterPassedConstraint(inputName string) {
 	mir[inputName] = NoVersionsSatisfiedPassedConstraints
 }
@@ -35,12 +54,28 @@ func (mir MissingInputReasons) RegisterPinnedVersionUnavailable(inputName string
 	mir[inputName] = fmt.Sprintf(PinnedVersionUnavailable, version)
 }
 
+func (mir MissingInputReasons) RegisterPinnedVersionGone(inputName string, version string) {
+	mir[inputName] = fmt.Sprintf(PinnedVersionGone, version)
+}
+
 type BuildPreparation struct {
-	BuildID             int
-	PausedPipeline      BuildPreparationStatus
-	PausedJob           BuildPreparationStatus
-	MaxRunningBuilds    BuildPreparationStatus
-	Inputs              map[string]BuildPreparationStatus
-	InputsSatisfied     BuildPreparationStatus
-	MissingInputReasons MissingInputReasons
+	BuildID              int
+	PausedPipeline       BuildPreparationStatus
+	PausedJob            BuildPreparationStatus
+	MaxRunningBuilds     BuildPreparationStatus
+	Inputs               map[string]BuildPreparationStatus
+	InputsSatisfied      BuildPreparationStatus
+	MissingInputReasons  MissingInputReasons
+	MissingWorker        BuildPreparationStatus
+	MissingWorkerReasons MissingWorkerReasons
+	Held                 BuildPreparationStatus
+	SerialGroups         BuildPreparationStatus
+
+	// AwaitingManualTrigger is blocking when the inputs are satisfied but
+	// none of them trigger the job and the build was not created by a user.
+	AwaitingManualTrigger BuildPreparationStatus
+
+	// FilteredInputs counts, for each input blocked by a passed constraint,
+	// the versions of its resource which exist but do not satisfy it.
+	FilteredInputs map[string]int
 }
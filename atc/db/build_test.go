@@ -1,15 +1,21 @@
 package db_test
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/algorithm"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/event"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
 )
 
 var _ = Describe("Build", func() {
@@ -30,6 +36,122 @@ var _ = Describe("Build", func() {
 		Expect(build.HasPlan()).To(BeFalse())
 	})
 
+	It("has no input versions when it is a one-off build", func() {
+		build, err := team.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		inputVersions, err := build.InputVersions()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inputVersions).To(BeEmpty())
+	})
+
+	Describe("HasPrivatePlan", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("is false while the build is pending", func() {
+			Expect(build.HasPrivatePlan()).To(BeFalse())
+		})
+
+		Context("when a plan is set on the pending build", func() {
+			BeforeEach(func() {
+				err := build.SetPlan(atc.Plan{ID: atc.PlanID("some-set-plan")})
+				Expect(err).ToNot(HaveOccurred())
+
+				found, err := build.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("is true before the build starts", func() {
+				Expect(build.HasPrivatePlan()).To(BeTrue())
+				Expect(build.PrivatePlan().ID).To(Equal(atc.PlanID("some-set-plan")))
+			})
+		})
+
+		Context("when the build has started", func() {
+			BeforeEach(func() {
+				started, err := build.Start(atc.Plan{ID: atc.PlanID("some-plan")})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(started).To(BeTrue())
+
+				found, err := build.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("is true", func() {
+				Expect(build.HasPrivatePlan()).To(BeTrue())
+				Expect(build.PrivatePlan().ID).To(Equal(atc.PlanID("some-plan")))
+			})
+
+			Context("when the build has finished", func() {
+				BeforeEach(func() {
+					err := build.Finish(db.BuildStatusSucceeded)
+					Expect(err).ToNot(HaveOccurred())
+
+					found, err := build.Reload()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+				})
+
+				It("is false again, while the public plan is kept", func() {
+					Expect(build.HasPrivatePlan()).To(BeFalse())
+					Expect(build.PrivatePlan()).To(Equal(atc.Plan{}))
+					Expect(build.HasPlan()).To(BeTrue())
+				})
+			})
+		})
+	})
+
+	Describe("PrivatePlanForResume", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("errors while no plan is stored", func() {
+			_, _, err := build.PrivatePlanForResume()
+			Expect(err).To(Equal(db.ErrBuildHasNoPrivatePlan))
+		})
+
+		Context("when the build is running", func() {
+			BeforeEach(func() {
+				started, err := build.Start(atc.Plan{ID: atc.PlanID("some-plan")})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(started).To(BeTrue())
+			})
+
+			It("returns the stored plan", func() {
+				plan, found, err := build.PrivatePlanForResume()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(plan.ID).To(Equal(atc.PlanID("some-plan")))
+			})
+
+			Context("when the build has finished", func() {
+				BeforeEach(func() {
+					err := build.Finish(db.BuildStatusSucceeded)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("returns false", func() {
+					_, found, err := build.PrivatePlanForResume()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+			})
+		})
+	})
+
 	Describe("Reload", func() {
 		It("updates the model", func() {
 			build, err := team.CreateOneOffBuild()
@@ -47,6 +169,130 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("SetName", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("renames the build", func() {
+			err := build.SetName("some-name")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.Name()).To(Equal("some-name"))
+
+			reloaded, found, err := buildFactory.Build(build.ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(reloaded.Name()).To(Equal("some-name"))
+		})
+
+		It("rejects a name taken by another one-off build of the team", func() {
+			otherBuild, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = otherBuild.SetName("some-name")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SetName("some-name")
+			Expect(err).To(Equal(db.ErrBuildNameTaken))
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Name()).ToNot(Equal("some-name"))
+		})
+
+		It("allows the same name in another team", func() {
+			otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+			Expect(err).NotTo(HaveOccurred())
+
+			otherBuild, err := otherTeam.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(otherBuild.SetName("some-name")).To(Succeed())
+			Expect(build.SetName("some-name")).To(Succeed())
+		})
+
+		It("rejects numeric names", func() {
+			err := build.SetName("42")
+			Expect(err).To(Equal(db.ErrBuildNameNumeric))
+		})
+
+		It("does not rename job builds", func() {
+			jobBuild, err := defaultJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = jobBuild.SetName("some-name")
+			Expect(err).To(Equal(db.ErrBuildNotOneOff))
+		})
+	})
+
+	Describe("MoveToTeam", func() {
+		var (
+			build     db.Build
+			otherTeam db.Team
+		)
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("moves the build and its events to the other team", func() {
+			err := build.SaveEvent(event.Log{Payload: "some-log"})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.MoveToTeam(otherTeam)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.TeamName()).To(Equal("some-other-team"))
+
+			otherTeamBuilds, _, err := otherTeam.Builds(db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(otherTeamBuilds).To(HaveLen(1))
+			Expect(otherTeamBuilds[0].ID()).To(Equal(build.ID()))
+
+			teamBuilds, _, err := team.Builds(db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			for _, teamBuild := range teamBuilds {
+				Expect(teamBuild.ID()).ToNot(Equal(build.ID()))
+			}
+
+			reloaded, found, err := buildFactory.Build(build.ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			events, err := reloaded.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			Expect(events.Next()).To(matchEnvelope(event.Log{Payload: "some-log"}))
+		})
+
+		It("does not move job builds", func() {
+			jobBuild, err := defaultJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = jobBuild.MoveToTeam(otherTeam)
+			Expect(err).To(Equal(db.ErrBuildNotOneOff))
+		})
+
+		It("does not move one-off builds of a pipeline", func() {
+			pipelineBuild, err := defaultPipeline.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = pipelineBuild.MoveToTeam(otherTeam)
+			Expect(err).To(Equal(db.ErrBuildBelongsToPipeline))
+		})
+	})
+
 	Describe("Drain", func() {
 		It("defaults drain to false in the beginning", func() {
 			build, err := team.CreateOneOffBuild()
@@ -71,6 +317,159 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("Reapable", func() {
+		var (
+			build  db.Build
+			policy db.ReapPolicy
+		)
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			policy = db.ReapPolicy{MinAge: time.Hour}
+		})
+
+		It("is not reapable while running", func() {
+			Expect(build.Reapable(policy)).To(BeFalse())
+		})
+
+		Context("when the build has finished", func() {
+			BeforeEach(func() {
+				err := build.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("is not reapable until it is older than the policy's minimum age", func() {
+				Expect(build.Reapable(policy)).To(BeFalse())
+				Expect(build.Reapable(db.ReapPolicy{})).To(BeTrue())
+			})
+
+			Context("when it finished long enough ago", func() {
+				BeforeEach(func() {
+					_, err := dbConn.Exec(`UPDATE builds SET end_time = now() - interval '2 hours' WHERE id = $1`, build.ID())
+					Expect(err).NotTo(HaveOccurred())
+
+					found, err := build.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+				})
+
+				It("is reapable", func() {
+					Expect(build.Reapable(policy)).To(BeTrue())
+				})
+
+				It("is not reapable when it is kept", func() {
+					err := build.SetKeep(true)
+					Expect(err).NotTo(HaveOccurred())
+
+					found, err := build.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					Expect(build.IsKept()).To(BeTrue())
+					Expect(build.Reapable(policy)).To(BeFalse())
+				})
+
+				It("is not reapable until drained when the policy requires it", func() {
+					policy.RequireDrained = true
+					Expect(build.Reapable(policy)).To(BeFalse())
+
+					err := build.SetDrained(true)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(build.Reapable(policy)).To(BeTrue())
+				})
+
+				It("is not reapable while interceptible when the policy requires it", func() {
+					policy.KeepInterceptible = true
+					Expect(build.Reapable(policy)).To(BeFalse())
+
+					err := build.SetInterceptible(false)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(build.Reapable(policy)).To(BeTrue())
+				})
+			})
+		})
+	})
+
+	Describe("ReloadChanged", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when nothing has changed", func() {
+			It("reports no change", func() {
+				changed, found, err := build.ReloadChanged()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(changed).To(BeFalse())
+			})
+		})
+
+		Context("when the status has changed", func() {
+			BeforeEach(func() {
+				otherBuild, found, err := buildFactory.Build(build.ID())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = otherBuild.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports a change, and then no change on the next reload", func() {
+				changed, found, err := build.ReloadChanged()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(changed).To(BeTrue())
+				Expect(build.Status()).To(Equal(db.BuildStatusSucceeded))
+
+				changed, found, err = build.ReloadChanged()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(changed).To(BeFalse())
+			})
+		})
+
+		Context("when the build has been held", func() {
+			BeforeEach(func() {
+				otherBuild, found, err := buildFactory.Build(build.ID())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = otherBuild.Hold()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports a change", func() {
+				changed, found, err := build.ReloadChanged()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(changed).To(BeTrue())
+				Expect(build.IsHeld()).To(BeTrue())
+			})
+		})
+
+		Context("when the build has been deleted", func() {
+			BeforeEach(func() {
+				_, err := build.Delete()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports not found", func() {
+				_, found, err := build.ReloadChanged()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("Start", func() {
 		var err error
 		var started bool
@@ -146,10 +545,10 @@ var _ = Describe("Build", func() {
 
 				defer db.Close(events)
 
-				Expect(events.Next()).To(Equal(envelope(event.Status{
+				Expect(events.Next()).To(matchEnvelope(event.Status{
 					Status: atc.StatusStarted,
 					Time:   build.StartTime().Unix(),
-				})))
+				}))
 			})
 
 			It("updates build status", func() {
@@ -169,7 +568,74 @@ var _ = Describe("Build", func() {
 		})
 	})
 
-	Describe("Finish", func() {
+	Describe("SetPlan", func() {
+		var build db.Build
+		var plan atc.Plan
+
+		BeforeEach(func() {
+			plan = atc.Plan{
+				ID: atc.PlanID("42"),
+				Task: &atc.TaskPlan{
+					Name:   "some-task",
+					Params: atc.Params{"some": "modified-params"},
+				},
+			}
+
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is pending", func() {
+			BeforeEach(func() {
+				err := build.SetPlan(plan)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("uses the stored plan when started without a plan", func() {
+				started, err := build.Start(atc.Plan{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.PrivatePlan()).To(Equal(plan))
+				Expect(build.PublicPlan()).To(Equal(plan.Public()))
+			})
+
+			It("uses the given plan when started with one", func() {
+				otherPlan := atc.Plan{
+					ID:   atc.PlanID("43"),
+					Task: &atc.TaskPlan{Name: "other-task"},
+				}
+
+				started, err := build.Start(otherPlan)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.PrivatePlan()).To(Equal(otherPlan))
+			})
+		})
+
+		Context("when the build has already started", func() {
+			BeforeEach(func() {
+				started, err := build.Start(atc.Plan{ID: atc.PlanID("1")})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+			})
+
+			It("returns an error", func() {
+				err := build.SetPlan(plan)
+				Expect(err).To(Equal(db.ErrBuildNotPending))
+			})
+		})
+	})
+
+	Describe("Finish", func() {
 		var build db.Build
 		BeforeEach(func() {
 			var err error
@@ -191,10 +657,10 @@ var _ = Describe("Build", func() {
 
 			defer db.Close(events)
 
-			Expect(events.Next()).To(Equal(envelope(event.Status{
+			Expect(events.Next()).To(matchEnvelope(event.Status{
 				Status: atc.StatusSucceeded,
 				Time:   build.EndTime().Unix(),
-			})))
+			}))
 		})
 
 		It("updates build status", func() {
@@ -223,6 +689,206 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("FinishWithOptions", func() {
+		var build db.Build
+		var plan atc.Plan
+
+		BeforeEach(func() {
+			plan = atc.Plan{
+				ID:   atc.PlanID("56"),
+				Task: &atc.TaskPlan{Name: "some-task"},
+			}
+
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			started, err := build.Start(plan)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+		})
+
+		Context("when clearing the public plan of a succeeded build", func() {
+			BeforeEach(func() {
+				err := build.FinishWithOptions(db.BuildStatusSucceeded, db.FinishOptions{ClearPublicPlan: true})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("keeps the public plan", func() {
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.HasPlan()).To(BeTrue())
+				Expect(build.PublicPlan()).To(Equal(plan.Public()))
+			})
+		})
+
+		Context("when clearing the public plan of an errored build", func() {
+			BeforeEach(func() {
+				err := build.FinishWithOptions(db.BuildStatusErrored, db.FinishOptions{ClearPublicPlan: true})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("clears the public plan", func() {
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.Status()).To(Equal(db.BuildStatusErrored))
+				Expect(build.HasPlan()).To(BeFalse())
+				Expect(*build.PublicPlan()).To(MatchJSON("{}"))
+			})
+		})
+
+		Context("when not clearing the public plan of an errored build", func() {
+			BeforeEach(func() {
+				err := build.FinishWithOptions(db.BuildStatusErrored, db.FinishOptions{})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("keeps the public plan", func() {
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.PublicPlan()).To(Equal(plan.Public()))
+			})
+		})
+	})
+
+	Describe("FinishWithEvents", func() {
+		var build db.Build
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.FinishWithEvents(db.BuildStatusFailed, []atc.Event{
+				event.Log{Payload: "final "},
+				event.Log{Payload: "logs"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("saves the final events before the status event", func() {
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			Expect(events.Next()).To(matchEnvelope(event.Log{
+				Payload: "final ",
+			}))
+
+			Expect(events.Next()).To(matchEnvelope(event.Log{
+				Payload: "logs",
+			}))
+
+			Expect(events.Next()).To(matchEnvelope(event.Status{
+				Status: atc.StatusFailed,
+				Time:   build.EndTime().Unix(),
+			}))
+
+			Expect(events.Next()).To(matchEnvelope(buildSummary(build)))
+
+			_, err = events.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+		})
+
+		It("updates build status", func() {
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Status()).To(Equal(db.BuildStatusFailed))
+			Expect(build.IsCompleted()).To(BeTrue())
+		})
+	})
+
+	Describe("NotifyOnCompletion", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when subscribed before the build completes", func() {
+			It("notifies once the build finishes", func() {
+				notifier, err := build.NotifyOnCompletion()
+				Expect(err).NotTo(HaveOccurred())
+
+				defer notifier.Close()
+
+				Consistently(notifier.Notify()).ShouldNot(Receive())
+
+				err = build.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(notifier.Notify()).Should(Receive())
+			})
+		})
+
+		Context("when subscribed after the build has completed", func() {
+			BeforeEach(func() {
+				err := build.Finish(db.BuildStatusFailed)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("notifies immediately", func() {
+				notifier, err := build.NotifyOnCompletion()
+				Expect(err).NotTo(HaveOccurred())
+
+				defer notifier.Close()
+
+				Eventually(notifier.Notify()).Should(Receive())
+			})
+		})
+	})
+
+	Describe("ArtifactByName", func() {
+		var build db.Build
+		var artifact db.WorkerArtifact
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, name := range []string{"some-artifact", "some-other-artifact"} {
+				creatingVolume, err := volumeRepository.CreateVolume(team.ID(), defaultWorker.Name(), db.VolumeTypeArtifact)
+				Expect(err).NotTo(HaveOccurred())
+
+				createdVolume, err := creatingVolume.Created()
+				Expect(err).NotTo(HaveOccurred())
+
+				created, err := createdVolume.InitializeArtifact(name, build.ID())
+				Expect(err).NotTo(HaveOccurred())
+
+				if name == "some-artifact" {
+					artifact = created
+				}
+			}
+		})
+
+		It("returns the artifact with the given name", func() {
+			found, exists, err := build.ArtifactByName("some-artifact")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+			Expect(found.ID()).To(Equal(artifact.ID()))
+			Expect(found.Name()).To(Equal("some-artifact"))
+			Expect(found.BuildID()).To(Equal(build.ID()))
+		})
+
+		It("returns not found for an unknown name", func() {
+			_, exists, err := build.ArtifactByName("bogus-artifact")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+		})
+	})
+
 	Describe("Abort", func() {
 		var build db.Build
 		BeforeEach(func() {
@@ -240,6 +906,32 @@ var _ = Describe("Build", func() {
 			Expect(found).To(BeTrue())
 			Expect(build.IsAborted()).To(BeTrue())
 		})
+
+		It("records the user as the reason", func() {
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.AbortReason()).To(Equal(db.AbortReasonUser))
+		})
+
+		Context("when aborted because of a timeout", func() {
+			BeforeEach(func() {
+				var err error
+				build, err = team.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.MarkAsAbortedWithReason(db.AbortReasonTimeout)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("records the timeout as the reason", func() {
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.IsAborted()).To(BeTrue())
+				Expect(build.AbortReason()).To(Equal(db.AbortReasonTimeout))
+			})
+		})
 	})
 
 	Describe("Events", func() {
@@ -262,10 +954,10 @@ var _ = Describe("Build", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			Expect(events.Next()).To(Equal(envelope(event.Status{
+			Expect(events.Next()).To(matchEnvelope(event.Status{
 				Status: atc.StatusStarted,
 				Time:   build.StartTime().Unix(),
-			})))
+			}))
 
 			By("emitting a status event when finished")
 			err = build.Finish(db.BuildStatusSucceeded)
@@ -275,10 +967,12 @@ var _ = Describe("Build", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			Expect(events.Next()).To(Equal(envelope(event.Status{
+			Expect(events.Next()).To(matchEnvelope(event.Status{
 				Status: atc.StatusSucceeded,
 				Time:   build.EndTime().Unix(),
-			})))
+			}))
+
+			Expect(events.Next()).To(matchEnvelope(buildSummary(build)))
 
 			By("ending the stream when finished")
 			_, err = events.Next()
@@ -286,849 +980,3258 @@ var _ = Describe("Build", func() {
 		})
 	})
 
-	Describe("SaveEvent", func() {
-		It("saves and propagates events correctly", func() {
-			build, err := team.CreateOneOffBuild()
+	Describe("TrimEvents", func() {
+		var build db.Build
+		var cutoff time.Time
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
 			Expect(err).NotTo(HaveOccurred())
 
-			By("allowing you to subscribe when no events have yet occurred")
-			events, err := build.Events(0)
+			started, err := build.Start(atc.Plan{})
 			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
 
-			defer db.Close(events)
+			cutoff = time.Now().Add(-time.Hour)
 
-			By("saving them in order")
 			err = build.SaveEvent(event.Log{
-				Payload: "some ",
+				Time:    cutoff.Add(-time.Minute).Unix(),
+				Payload: "old log",
 			})
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(events.Next()).To(Equal(envelope(event.Log{
-				Payload: "some ",
-			})))
+			err = build.SaveEvent(event.Log{
+				Time:    cutoff.Add(time.Minute).Unix(),
+				Payload: "new log",
+			})
+			Expect(err).NotTo(HaveOccurred())
 
 			err = build.SaveEvent(event.Log{
-				Payload: "log",
+				Payload: "untimed log",
 			})
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(events.Next()).To(Equal(envelope(event.Log{
-				Payload: "log",
-			})))
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
 
-			By("allowing you to subscribe from an offset")
-			eventsFrom1, err := build.Events(1)
+			found, err := build.Reload()
 			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
 
-			defer db.Close(eventsFrom1)
+		It("empties logs older than the cutoff in place and keeps other events", func() {
+			removed, err := build.TrimEvents(cutoff)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed).To(Equal(1))
 
-			Expect(eventsFrom1.Next()).To(Equal(envelope(event.Log{
-				Payload: "log",
-			})))
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
 
-			By("notifying those waiting on events as soon as they're saved")
-			nextEvent := make(chan event.Envelope)
-			nextErr := make(chan error)
+			defer db.Close(events)
 
-			go func() {
-				event, err := events.Next()
-				if err != nil {
-					nextErr <- err
-				} else {
-					nextEvent <- event
-				}
-			}()
+			Expect(events.Next()).To(matchEnvelope(event.Status{
+				Status: atc.StatusStarted,
+				Time:   build.StartTime().Unix(),
+			}))
 
-			Consistently(nextEvent).ShouldNot(Receive())
-			Consistently(nextErr).ShouldNot(Receive())
+			trimmed, err := events.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(event.ParseEvent(trimmed.Version, trimmed.Event, *trimmed.Data)).To(Equal(event.Log{
+				Time:    cutoff.Add(-time.Minute).Unix(),
+				Payload: "",
+			}))
 
-			err = build.SaveEvent(event.Log{
-				Payload: "log 2",
-			})
+			Expect(events.Next()).To(matchEnvelope(event.Log{
+				Time:    cutoff.Add(time.Minute).Unix(),
+				Payload: "new log",
+			}))
+
+			Expect(events.Next()).To(matchEnvelope(event.Log{
+				Payload: "untimed log",
+			}))
+
+			Expect(events.Next()).To(matchEnvelope(event.Status{
+				Status: atc.StatusSucceeded,
+				Time:   build.EndTime().Unix(),
+			}))
+
+			Expect(events.Next()).To(matchEnvelope(buildSummary(build)))
+
+			_, err = events.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+		})
+
+		It("keeps the offsets of later events", func() {
+			countBefore, err := build.EventCount()
 			Expect(err).NotTo(HaveOccurred())
 
-			Eventually(nextEvent).Should(Receive(Equal(envelope(event.Log{
-				Payload: "log 2",
-			}))))
+			_, err = build.TrimEvents(cutoff)
+			Expect(err).NotTo(HaveOccurred())
 
-			By("returning ErrBuildEventStreamClosed for Next calls after Close")
-			events3, err := build.Events(0)
+			Expect(build.EventCount()).To(Equal(countBefore))
+
+			events, err := build.Events(2)
 			Expect(err).NotTo(HaveOccurred())
 
-			err = events3.Close()
+			defer db.Close(events)
+
+			Expect(events.Next()).To(matchEnvelope(event.Log{
+				Time:    cutoff.Add(time.Minute).Unix(),
+				Payload: "new log",
+			}))
+		})
+
+		It("does not trim the same events twice", func() {
+			_, err := build.TrimEvents(cutoff)
 			Expect(err).NotTo(HaveOccurred())
 
-			Eventually(func() error {
-				_, err := events3.Next()
-				return err
-			}).Should(Equal(db.ErrBuildEventStreamClosed))
+			removed, err := build.TrimEvents(cutoff)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed).To(BeZero())
 		})
 	})
 
-	Describe("SaveOutput", func() {
-		var pipeline db.Pipeline
-		var job db.Job
-		var resourceConfigScope db.ResourceConfigScope
+	Describe("EventCount", func() {
+		var build db.Build
 
 		BeforeEach(func() {
-			pipelineConfig := atc.Config{
-				Jobs: atc.JobConfigs{
-					{
-						Name: "some-job",
-					},
-				},
-				Resources: atc.ResourceConfigs{
-					{
-						Name: "some-implicit-resource",
-						Type: "some-type",
-					},
-					{
-						Name:   "some-explicit-resource",
-						Type:   "some-type",
-						Source: atc.Source{"some": "explicit-source"},
-					},
-				},
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("is zero for a build without events", func() {
+			Expect(build.EventCount()).To(BeZero())
+		})
+
+		It("counts the events saved so far while the build is running", func() {
+			for _, payload := range []string{"one", "two", "three"} {
+				err := build.SaveEvent(event.Log{Payload: payload})
+				Expect(err).NotTo(HaveOccurred())
 			}
 
+			Expect(build.EventCount()).To(Equal(3))
+		})
+
+		It("includes the status and summary events of a finished build", func() {
+			err := build.SaveEvent(event.Log{Payload: "one"})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(build.EventCount()).To(Equal(3))
+		})
+	})
+
+	Describe("LatestStatusEvent", func() {
+		var build db.Build
+
+		BeforeEach(func() {
 			var err error
-			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), false)
-			Expect(err).ToNot(HaveOccurred())
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
 
-			var found bool
-			job, found, err = pipeline.Job("some-job")
-			Expect(err).ToNot(HaveOccurred())
-			Expect(found).To(BeTrue())
+		It("is not found for a build without status events", func() {
+			_, found, err := build.LatestStatusEvent()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
 
-			setupTx, err := dbConn.Begin()
-			Expect(err).ToNot(HaveOccurred())
+		It("returns the finish status event of a finished build", func() {
+			started, err := build.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
 
-			brt := db.BaseResourceType{
-				Name: "some-type",
-			}
+			err = build.SaveEvent(event.Log{Payload: "some-log"})
+			Expect(err).NotTo(HaveOccurred())
 
-			_, err = brt.FindOrCreate(setupTx, false)
+			err = build.Finish(db.BuildStatusSucceeded)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(setupTx.Commit()).To(Succeed())
 
-			resource, found, err := pipeline.Resource("some-explicit-resource")
-			Expect(err).ToNot(HaveOccurred())
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			resourceConfigScope, err = resource.SetResourceConfig(atc.Source{"some": "explicit-source"}, atc.VersionedResourceTypes{})
-			Expect(err).ToNot(HaveOccurred())
+			env, found, err := build.LatestStatusEvent()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(env).To(matchEnvelope(event.Status{
+				Status: atc.StatusSucceeded,
+				Time:   build.EndTime().Unix(),
+			}))
 		})
+	})
 
-		Context("when the version does not exist", func() {
-			It("can save a build's output", func() {
-				build, err := job.CreateBuild()
-				Expect(err).ToNot(HaveOccurred())
+	Describe("Events offset validation", func() {
+		var build db.Build
 
-				err = build.SaveOutput("some-type", atc.Source{"some": "explicit-source"}, atc.VersionedResourceTypes{}, atc.Version{"some": "version"}, []db.ResourceConfigMetadataField{
-					{
-						Name:  "meta1",
-						Value: "data1",
-					},
-					{
-						Name:  "meta2",
-						Value: "data2",
-					},
-				}, "output-name", "some-explicit-resource")
-				Expect(err).ToNot(HaveOccurred())
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
 
-				rcv, found, err := resourceConfigScope.FindVersion(atc.Version{"some": "version"})
-				Expect(err).ToNot(HaveOccurred())
-				Expect(found).To(BeTrue())
+			err = build.SaveEvent(event.Log{Payload: "one"})
+			Expect(err).NotTo(HaveOccurred())
+		})
 
-				_, buildOutputs, err := build.Resources()
-				Expect(err).ToNot(HaveOccurred())
-				Expect(len(buildOutputs)).To(Equal(1))
-				Expect(buildOutputs[0].Name).To(Equal("output-name"))
-				Expect(buildOutputs[0].Version).To(Equal(atc.Version(rcv.Version())))
+		Context("when the build is running", func() {
+			It("waits for events beyond the saved ones", func() {
+				events, err := build.Events(5)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer db.Close(events)
+
+				nextErr := make(chan error, 1)
+				go func() {
+					_, err := events.Next()
+					nextErr <- err
+				}()
+
+				Consistently(nextErr).ShouldNot(Receive())
 			})
 		})
 
-		Context("when the version already exists", func() {
-			var rcv db.ResourceConfigVersion
-
+		Context("when the build is completed", func() {
 			BeforeEach(func() {
-				err := resourceConfigScope.SaveVersions([]atc.Version{{"some": "version"}})
-				Expect(err).ToNot(HaveOccurred())
-
-				var found bool
-				rcv, found, err = resourceConfigScope.FindVersion(atc.Version{"some": "version"})
-				Expect(err).ToNot(HaveOccurred())
-				Expect(found).To(BeTrue())
+				err := build.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
 			})
 
-			It("does not increment the check order", func() {
-				build, err := job.CreateBuild()
-				Expect(err).ToNot(HaveOccurred())
+			It("returns ErrEventOffsetTooHigh for offsets beyond the saved events", func() {
+				_, err := build.Events(4)
+				Expect(err).To(Equal(db.ErrEventOffsetTooHigh))
+			})
 
-				err = build.SaveOutput("some-type", atc.Source{"some": "explicit-source"}, atc.VersionedResourceTypes{}, atc.Version{"some": "version"}, []db.ResourceConfigMetadataField{
-					{
-						Name:  "meta1",
-						Value: "data1",
-					},
-					{
-						Name:  "meta2",
-						Value: "data2",
-					},
-				}, "output-name", "some-explicit-resource")
-				Expect(err).ToNot(HaveOccurred())
+			It("allows the offset just past the last event", func() {
+				events, err := build.Events(3)
+				Expect(err).NotTo(HaveOccurred())
 
-				newRCV, found, err := resourceConfigScope.FindVersion(atc.Version{"some": "version"})
-				Expect(err).ToNot(HaveOccurred())
-				Expect(found).To(BeTrue())
+				defer db.Close(events)
 
-				Expect(newRCV.CheckOrder()).To(Equal(rcv.CheckOrder()))
+				_, err = events.Next()
+				Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
 			})
 		})
 	})
 
-	Describe("Resources", func() {
-		var (
-			pipeline             db.Pipeline
-			job                  db.Job
-			resourceConfigScope1 db.ResourceConfigScope
-			resource1            db.Resource
-		)
+	Describe("Events NextBatch", func() {
+		var build db.Build
 
 		BeforeEach(func() {
-			setupTx, err := dbConn.Begin()
-			Expect(err).ToNot(HaveOccurred())
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
 
-			brt := db.BaseResourceType{
-				Name: "some-type",
+			for _, payload := range []string{"one", "two", "three"} {
+				err = build.SaveEvent(event.Log{Payload: payload})
+				Expect(err).NotTo(HaveOccurred())
 			}
 
-			_, err = brt.FindOrCreate(setupTx, false)
+			err = build.Finish(db.BuildStatusSucceeded)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(setupTx.Commit()).To(Succeed())
 
-			pipelineConfig := atc.Config{
-				Jobs: atc.JobConfigs{
-					{
-						Name: "some-job",
-					},
-				},
-				Resources: atc.ResourceConfigs{
-					{
-						Name:   "some-resource",
-						Type:   "some-type",
-						Source: atc.Source{"some": "source"},
-					},
-					{
-						Name:   "some-other-resource",
-						Type:   "some-type",
-						Source: atc.Source{"some": "source-2"},
-					},
-					{
-						Name:   "some-unused-resource",
-						Type:   "some-type",
-						Source: atc.Source{"some": "source-3"},
-					},
-				},
-			}
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
 
-			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), false)
-			Expect(err).ToNot(HaveOccurred())
+		readAll := func(events db.EventSource, max int) ([]event.Envelope, [][]event.Envelope) {
+			all := []event.Envelope{}
+			batches := [][]event.Envelope{}
 
-			var found bool
-			job, found, err = pipeline.Job("some-job")
-			Expect(err).ToNot(HaveOccurred())
-			Expect(found).To(BeTrue())
+			for {
+				batch, err := events.NextBatch(max)
+				if err == db.ErrEndOfBuildEventStream {
+					return all, batches
+				}
 
-			resource1, found, err = pipeline.Resource("some-resource")
-			Expect(err).ToNot(HaveOccurred())
-			Expect(found).To(BeTrue())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(batch).NotTo(BeEmpty())
 
-			resource2, found, err := pipeline.Resource("some-other-resource")
-			Expect(err).ToNot(HaveOccurred())
-			Expect(found).To(BeTrue())
+				all = append(all, batch...)
+				batches = append(batches, batch)
+			}
+		}
 
-			resourceConfigScope1, err = resource1.SetResourceConfig(atc.Source{"some": "source-1"}, atc.VersionedResourceTypes{})
-			Expect(err).ToNot(HaveOccurred())
+		It("returns the saved events in batches of at most max events", func() {
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
 
-			_, err = resource2.SetResourceConfig(atc.Source{"some": "source-2"}, atc.VersionedResourceTypes{})
-			Expect(err).ToNot(HaveOccurred())
+			defer db.Close(events)
 
-			err = resourceConfigScope1.SaveVersions([]atc.Version{
-				{"ver": "1"},
-				{"ver": "2"},
-			})
-			Expect(err).ToNot(HaveOccurred())
+			all, batches := readAll(events, 2)
+			Expect(all).To(WithTransform(withoutInsertTimes, Equal([]event.Envelope{
+				envelope(event.Log{Payload: "one"}),
+				envelope(event.Log{Payload: "two"}),
+				envelope(event.Log{Payload: "three"}),
+				envelope(event.Status{
+					Status: atc.StatusSucceeded,
+					Time:   build.EndTime().Unix(),
+				}),
+				envelope(buildSummary(build)),
+			})))
 
-			// This version should not be returned by the Resources method because it has a check order of 0
-			created, err := resource1.SaveUncheckedVersion(atc.Version{"ver": "not-returned"}, nil, resourceConfigScope1.ResourceConfig(), atc.VersionedResourceTypes{})
-			Expect(err).ToNot(HaveOccurred())
-			Expect(created).To(BeTrue())
+			for _, batch := range batches {
+				Expect(len(batch)).To(BeNumerically("<=", 2))
+			}
+
+			_, err = events.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
 		})
 
-		It("returns build inputs and outputs", func() {
-			build, err := job.CreateBuild()
+		It("returns several buffered events in one call", func() {
+			events, err := build.Events(0)
 			Expect(err).NotTo(HaveOccurred())
 
-			// save a normal 'get'
-			err = build.UseInputs([]db.BuildInput{
-				db.BuildInput{
-					Name:       "some-input",
-					Version:    atc.Version{"ver": "1"},
-					ResourceID: resource1.ID(),
-				},
-			})
+			defer db.Close(events)
+
+			all, batches := readAll(events, 10)
+			Expect(all).To(HaveLen(5))
+			Expect(len(batches)).To(BeNumerically("<", len(all)))
+		})
+
+		It("advances the resume token past the whole batch", func() {
+			events, err := build.Events(0)
 			Expect(err).NotTo(HaveOccurred())
 
-			// save explicit output from 'put'
-			err = build.SaveOutput("some-type", atc.Source{"some": "source-2"}, atc.VersionedResourceTypes{}, atc.Version{"ver": "2"}, nil, "some-output-name", "some-other-resource")
+			batch, err := events.NextBatch(10)
 			Expect(err).NotTo(HaveOccurred())
 
-			inputs, outputs, err := build.Resources()
+			token := events.ResumeToken()
+			Expect(events.Close()).To(Succeed())
+
+			resumed, err := build.EventsFromToken(token)
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(inputs).To(ConsistOf([]db.BuildInput{
-				{Name: "some-input", Version: atc.Version{"ver": "1"}, ResourceID: resource1.ID(), FirstOccurrence: true},
-			}))
+			defer db.Close(resumed)
 
-			Expect(outputs).To(ConsistOf([]db.BuildOutput{
-				{
-					Name:    "some-output-name",
-					Version: atc.Version{"ver": "2"},
-				},
-			}))
+			rest, _ := readAll(resumed, 10)
+			Expect(len(batch) + len(rest)).To(Equal(5))
 		})
 
-		It("can't get no satisfaction (resources from a one-off build)", func() {
-			oneOffBuild, err := team.CreateOneOffBuild()
+		It("returns the closed error once the stream is closed", func() {
+			events, err := build.Events(0)
 			Expect(err).NotTo(HaveOccurred())
 
-			inputs, outputs, err := oneOffBuild.Resources()
-			Expect(err).NotTo(HaveOccurred())
+			Expect(events.Close()).To(Succeed())
 
-			Expect(inputs).To(BeEmpty())
-			Expect(outputs).To(BeEmpty())
+			Eventually(func() error {
+				_, err := events.NextBatch(10)
+				return err
+			}).Should(Equal(db.ErrBuildEventStreamClosed))
 		})
-	})
 
-	Describe("Pipeline", func() {
-		var (
-			build           db.Build
-			foundPipeline   db.Pipeline
-			createdPipeline db.Pipeline
-			found           bool
-		)
+		It("rejects a max below 1", func() {
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
 
-		JustBeforeEach(func() {
-			var err error
-			foundPipeline, found, err = build.Pipeline()
-			Expect(err).ToNot(HaveOccurred())
+			defer db.Close(events)
+
+			_, err = events.NextBatch(0)
+			Expect(err).To(Equal(db.ErrInvalidEventBatchSize))
 		})
 
-		Context("when a job build", func() {
-			BeforeEach(func() {
-				var err error
-				createdPipeline, _, err = team.SavePipeline("some-pipeline", atc.Config{
-					Jobs: atc.JobConfigs{
-						{
-							Name: "some-job",
-						},
-					},
-				}, db.ConfigVersion(1), false)
-				Expect(err).ToNot(HaveOccurred())
+		It("returns the context's error when cancelled while waiting for events", func() {
+			pending, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
 
-				job, found, err := createdPipeline.Job("some-job")
-				Expect(err).ToNot(HaveOccurred())
-				Expect(found).To(BeTrue())
+			events, err := pending.Events(0)
+			Expect(err).NotTo(HaveOccurred())
 
-				build, err = job.CreateBuild()
-				Expect(err).ToNot(HaveOccurred())
-			})
+			defer db.Close(events)
 
-			It("returns the correct pipeline", func() {
-				Expect(found).To(BeTrue())
-				Expect(foundPipeline).To(Equal(createdPipeline))
-			})
-		})
+			ctx, cancel := context.WithCancel(context.Background())
 
-		Context("when a one off build", func() {
-			BeforeEach(func() {
-				var err error
-				build, err = team.CreateOneOffBuild()
-				Expect(err).ToNot(HaveOccurred())
-			})
+			errs := make(chan error, 1)
+			go func() {
+				_, err := events.NextBatchContext(ctx, 10)
+				errs <- err
+			}()
 
-			It("does not return a pipeline", func() {
-				Expect(found).To(BeFalse())
-				Expect(foundPipeline).To(BeNil())
-			})
+			Consistently(errs).ShouldNot(Receive())
+
+			cancel()
+
+			Eventually(errs).Should(Receive(Equal(context.Canceled)))
 		})
 	})
 
-	Describe("Preparation", func() {
-		var (
-			build             db.Build
-			err               error
-			expectedBuildPrep db.BuildPreparation
-		)
+	Describe("Events NextContext", func() {
+		var build db.Build
+
 		BeforeEach(func() {
-			expectedBuildPrep = db.BuildPreparation{
-				BuildID:             123456789,
-				PausedPipeline:      db.BuildPreparationStatusNotBlocking,
-				PausedJob:           db.BuildPreparationStatusNotBlocking,
-				MaxRunningBuilds:    db.BuildPreparationStatusNotBlocking,
-				Inputs:              map[string]db.BuildPreparationStatus{},
-				InputsSatisfied:     db.BuildPreparationStatusNotBlocking,
-				MissingInputReasons: db.MissingInputReasons{},
-			}
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
 		})
 
-		Context("for one-off build", func() {
-			BeforeEach(func() {
-				build, err = team.CreateOneOffBuild()
-				Expect(err).NotTo(HaveOccurred())
+		It("returns the context's error when cancelled while waiting for events", func() {
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
 
-				expectedBuildPrep.BuildID = build.ID()
-			})
+			defer db.Close(events)
 
-			It("returns build preparation", func() {
-				buildPrep, found, err := build.Preparation()
-				Expect(err).NotTo(HaveOccurred())
-				Expect(found).To(BeTrue())
-				Expect(buildPrep).To(Equal(expectedBuildPrep))
-			})
+			ctx, cancel := context.WithCancel(context.Background())
 
-			Context("when the build is started", func() {
-				BeforeEach(func() {
-					started, err := build.Start(atc.Plan{})
-					Expect(started).To(BeTrue())
-					Expect(err).NotTo(HaveOccurred())
+			errs := make(chan error, 1)
+			go func() {
+				_, err := events.NextContext(ctx)
+				errs <- err
+			}()
 
-					stillExists, err := build.Reload()
-					Expect(stillExists).To(BeTrue())
-					Expect(err).NotTo(HaveOccurred())
-				})
+			Consistently(errs).ShouldNot(Receive())
 
-				It("returns build preparation", func() {
-					buildPrep, found, err := build.Preparation()
-					Expect(err).NotTo(HaveOccurred())
-					Expect(found).To(BeTrue())
-					Expect(buildPrep).To(Equal(expectedBuildPrep))
-				})
-			})
-		})
+			cancel()
 
-		Context("for job build", func() {
-			var (
-				pipeline db.Pipeline
-				job      db.Job
-			)
+			Eventually(errs).Should(Receive(Equal(context.Canceled)))
+		})
 
-			BeforeEach(func() {
-				var err error
-				pipeline, _, err = team.SavePipeline("some-pipeline", atc.Config{
-					Resources: atc.ResourceConfigs{
-						{
-							Name: "some-resource",
-							Type: "some-type",
-							Source: atc.Source{
-								"source-config": "some-value",
-							},
-						},
-					},
-					Jobs: atc.JobConfigs{
-						{
-							Name: "some-job",
-						},
-					},
-				}, db.ConfigVersion(1), false)
-				Expect(err).ToNot(HaveOccurred())
+		It("returns events as Next does", func() {
+			err := build.SaveEvent(event.Log{Payload: "one"})
+			Expect(err).NotTo(HaveOccurred())
 
-				var found bool
-				job, found, err = pipeline.Job("some-job")
-				Expect(err).ToNot(HaveOccurred())
-				Expect(found).To(BeTrue())
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
 
-				build, err = job.CreateBuild()
-				Expect(err).NotTo(HaveOccurred())
+			defer db.Close(events)
 
-				expectedBuildPrep.BuildID = build.ID()
+			ev, err := events.NextContext(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ev).To(matchEnvelope(event.Log{Payload: "one"}))
+		})
 
-				job, found, err = pipeline.Job("some-job")
-				Expect(err).NotTo(HaveOccurred())
-				Expect(found).To(BeTrue())
-			})
+		It("carries the time the event was saved", func() {
+			err := build.SaveEvent(event.Log{Payload: "one"})
+			Expect(err).NotTo(HaveOccurred())
 
-			Context("when inputs are satisfied", func() {
-				var (
-					resourceConfigScope db.ResourceConfigScope
-					resource            db.Resource
-					found               bool
-					rcv                 db.ResourceConfigVersion
-					err                 error
-				)
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
 
-				BeforeEach(func() {
-					setupTx, err := dbConn.Begin()
-					Expect(err).ToNot(HaveOccurred())
+			defer db.Close(events)
 
-					brt := db.BaseResourceType{
-						Name: "some-type",
-					}
+			ev, err := events.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ev.InsertedAt).NotTo(BeNil())
+			Expect(*ev.InsertedAt).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+	})
 
-					_, err = brt.FindOrCreate(setupTx, false)
-					Expect(err).NotTo(HaveOccurred())
-					Expect(setupTx.Commit()).To(Succeed())
+	Describe("ContainerHandles", func() {
+		It("returns the handles of the build's containers in every state", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
 
-					resource, found, err = pipeline.Resource("some-resource")
-					Expect(err).NotTo(HaveOccurred())
-					Expect(found).To(BeTrue())
+			creatingContainer, err := defaultWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("some-plan"), team.ID()), db.ContainerMetadata{})
+			Expect(err).NotTo(HaveOccurred())
 
-					resourceConfigScope, err = resource.SetResourceConfig(atc.Source{"some": "source"}, atc.VersionedResourceTypes{})
-					Expect(err).NotTo(HaveOccurred())
+			otherContainer, err := defaultWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("some-other-plan"), team.ID()), db.ContainerMetadata{})
+			Expect(err).NotTo(HaveOccurred())
 
-					err = resourceConfigScope.SaveVersions([]atc.Version{{"version": "v5"}})
-					Expect(err).NotTo(HaveOccurred())
+			createdContainer, err := otherContainer.Created()
+			Expect(err).NotTo(HaveOccurred())
 
-					rcv, found, err = resourceConfigScope.FindVersion(atc.Version{"version": "v5"})
-					Expect(found).To(BeTrue())
-					Expect(err).NotTo(HaveOccurred())
-				})
+			_, err = createdContainer.Destroying()
+			Expect(err).NotTo(HaveOccurred())
 
-				Context("when resource check finished after build created", func() {
-					BeforeEach(func() {
-						updated, err := resourceConfigScope.UpdateLastCheckEndTime()
-						Expect(err).NotTo(HaveOccurred())
-						Expect(updated).To(BeTrue())
+			otherBuild, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
 
-						reloaded, err := resource.Reload()
-						Expect(err).NotTo(HaveOccurred())
-						Expect(reloaded).To(BeTrue())
+			_, err = defaultWorker.CreateContainer(db.NewBuildStepContainerOwner(otherBuild.ID(), atc.PlanID("some-plan"), team.ID()), db.ContainerMetadata{})
+			Expect(err).NotTo(HaveOccurred())
 
-						lastCheckEndTime := resource.LastCheckEndTime()
-						Expect(lastCheckEndTime.IsZero()).To(BeFalse())
+			handles, err := build.ContainerHandles()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(handles).To(Equal([]string{creatingContainer.Handle(), otherContainer.Handle()}))
+		})
+	})
 
-						err = job.SaveNextInputMapping(algorithm.InputMapping{
-							"some-input": {VersionID: rcv.ID(), ResourceID: resource.ID(), FirstOccurrence: true},
-						})
-						Expect(err).NotTo(HaveOccurred())
+	Describe("RedactedEvents", func() {
+		var build db.Build
+		var redactor *dbfakes.FakeSecretRedactor
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{Payload: "token is hunter2"})
+			Expect(err).NotTo(HaveOccurred())
+
+			redactor = new(dbfakes.FakeSecretRedactor)
+			redactor.RedactStub = func(payload []byte) []byte {
+				return bytes.Replace(payload, []byte("hunter2"), []byte("((redacted))"), -1)
+			}
+		})
+
+		It("returns events with their payloads redacted", func() {
+			events, err := build.RedactedEvents(0, redactor)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			Expect(events.Next()).To(matchEnvelope(event.Log{Payload: "token is ((redacted))"}))
+			Expect(redactor.RedactCallCount()).To(Equal(1))
+		})
+
+		It("redacts each event of a batch", func() {
+			events, err := build.RedactedEvents(0, redactor)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			batch, err := events.NextBatch(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(withoutInsertTimes(batch)).To(Equal([]event.Envelope{
+				envelope(event.Log{Payload: "token is ((redacted))"}),
+			}))
+		})
+	})
+
+	Describe("EventsFromToken", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, payload := range []string{"one", "two", "three"} {
+				err = build.SaveEvent(event.Log{Payload: payload})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("continues from the position the token was captured at", func() {
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events.Next()).To(matchEnvelope(event.Log{Payload: "one"}))
+			Expect(events.Next()).To(matchEnvelope(event.Log{Payload: "two"}))
+
+			token := events.ResumeToken()
+			Expect(events.Close()).To(Succeed())
+
+			resumed, err := build.EventsFromToken(token)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(resumed)
+
+			Expect(resumed.Next()).To(matchEnvelope(event.Log{Payload: "three"}))
+		})
+
+		It("resumes after the same event when earlier events have been removed", func() {
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events.Next()).To(matchEnvelope(event.Log{Payload: "one"}))
+			Expect(events.Next()).To(matchEnvelope(event.Log{Payload: "two"}))
+
+			token := events.ResumeToken()
+			Expect(events.Close()).To(Succeed())
+
+			_, err = dbConn.Exec(
+				fmt.Sprintf("DELETE FROM team_build_events_%d WHERE build_id = $1 AND payload::jsonb->>'payload' = 'one'", team.ID()),
+				build.ID(),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			resumed, err := build.EventsFromToken(token)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(resumed)
+
+			Expect(resumed.Next()).To(matchEnvelope(event.Log{Payload: "three"}))
+		})
+
+		It("resumes the whole stream from a token taken within a window", func() {
+			_, err := dbConn.Exec(
+				fmt.Sprintf("UPDATE team_build_events_%d SET inserted_at = now() - interval '1 hour' WHERE build_id = $1 AND payload::jsonb->>'payload' = 'two'", team.ID()),
+				build.ID(),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := build.EventsBetween(time.Now().Add(-2*time.Hour), time.Now().Add(-30*time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events.Next()).To(matchEnvelope(event.Log{Payload: "two"}))
+
+			token := events.ResumeToken()
+			Expect(events.Close()).To(Succeed())
+
+			resumed, err := build.EventsFromToken(token)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(resumed)
+
+			Expect(resumed.Next()).To(matchEnvelope(event.Log{Payload: "three"}))
+		})
+
+		It("rejects a token for another build", func() {
+			otherBuild, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := otherBuild.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			token := events.ResumeToken()
+			Expect(events.Close()).To(Succeed())
+
+			_, err = build.EventsFromToken(token)
+			Expect(err).To(Equal(db.ErrInvalidResumeToken))
+		})
+
+		It("rejects a malformed token", func() {
+			_, err := build.EventsFromToken("not a token")
+			Expect(err).To(Equal(db.ErrInvalidResumeToken))
+		})
+	})
+
+	Describe("EventsFromID", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, payload := range []string{"one", "two", "three", "four"} {
+				err = build.SaveEvent(event.Log{Payload: payload})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("streams from the first event when given a negative ID", func() {
+			events, err := build.EventsFromID(-1)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			Expect(events.Next()).To(matchEnvelope(event.Log{Payload: "one"}))
+		})
+
+		It("seeks past the given event and continues with new events", func() {
+			events, err := build.EventsFromID(1)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			Expect(events.Next()).To(matchEnvelope(event.Log{Payload: "three"}))
+			Expect(events.Next()).To(matchEnvelope(event.Log{Payload: "four"}))
+
+			err = build.SaveEvent(event.Log{Payload: "five"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events.Next()).To(matchEnvelope(event.Log{Payload: "five"}))
+		})
+	})
+
+	Describe("EventsCompressed", func() {
+		It("round-trips the build's events through the compressed stream", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			logs := []event.Log{
+				{Payload: "some "},
+				{Payload: "log "},
+				{Payload: "output"},
+			}
+
+			for _, log := range logs {
+				Expect(build.SaveEvent(log)).To(Succeed())
+			}
+
+			Expect(build.Finish(db.BuildStatusSucceeded)).To(Succeed())
+
+			stream, err := build.EventsCompressed(1)
+			Expect(err).NotTo(HaveOccurred())
+			defer db.Close(stream)
+
+			decoder, err := db.NewCompressedEventDecoder(stream)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, log := range logs[1:] {
+				ev, err := decoder.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ev).To(matchEnvelope(log))
+			}
+
+			ev, err := decoder.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ev.Event).To(Equal(event.EventTypeStatus))
+
+			ev, err = decoder.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ev.Event).To(Equal(event.EventTypeBuildSummary))
+
+			_, err = decoder.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+		})
+	})
+
+	Describe("EventsBetween", func() {
+		var build db.Build
+		var now time.Time
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			now = time.Now()
+
+			for i, payload := range []string{"one", "two", "three", "four"} {
+				err = build.SaveEvent(event.Log{Payload: payload})
+				Expect(err).NotTo(HaveOccurred())
+
+				insertedAt := now.Add(time.Duration(i-4) * time.Hour)
+				_, err = dbConn.Exec(
+					fmt.Sprintf("UPDATE team_build_events_%d SET inserted_at = $1 WHERE build_id = $2 AND payload::jsonb->>'payload' = $3", team.ID()),
+					insertedAt, build.ID(), payload,
+				)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("returns only the events saved within the window, in order", func() {
+			events, err := build.EventsBetween(now.Add(-3*time.Hour), now.Add(-time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			Expect(events.Next()).To(matchEnvelope(event.Log{Payload: "two"}))
+			Expect(events.Next()).To(matchEnvelope(event.Log{Payload: "three"}))
+
+			_, err = events.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+		})
+	})
+
+	Describe("Events migrations", func() {
+		BeforeEach(func() {
+			event.RegisterMigration("old-event", "1.0", "2.0", func(payload []byte) ([]byte, error) {
+				var old oldEvent
+				err := json.Unmarshal(payload, &old)
+				if err != nil {
+					return nil, err
+				}
+
+				return json.Marshal(map[string]string{"greeting": old.Hello})
+			})
+		})
+
+		It("upgrades saved events before returning them", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(oldEvent{Hello: "sup"})
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			env, err := events.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.Event).To(Equal(atc.EventType("old-event")))
+			Expect(env.Version).To(Equal(atc.EventVersion("2.0")))
+			Expect(*env.Data).To(MatchJSON(`{"greeting":"sup"}`))
+		})
+	})
+
+	Describe("SaveEvent", func() {
+		It("skips a status event repeating the preceding status", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Status{Status: atc.StatusStarted, Time: 1})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{Payload: "some-log"})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Status{Status: atc.StatusStarted, Time: 1})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{Payload: "some-log"})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Status{Status: atc.StatusSucceeded, Time: 2})
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			Expect(events.Next()).To(matchEnvelope(event.Status{Status: atc.StatusStarted, Time: 1}))
+			Expect(events.Next()).To(matchEnvelope(event.Log{Payload: "some-log"}))
+			Expect(events.Next()).To(matchEnvelope(event.Log{Payload: "some-log"}))
+			Expect(events.Next()).To(matchEnvelope(event.Status{Status: atc.StatusSucceeded, Time: 2}))
+
+			count, err := build.EventCount()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(4))
+		})
+
+		It("saves and reads back image events", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			imageGet := event.ImageGet{
+				Origin:         event.Origin{ID: "some-plan-id"},
+				Time:           123,
+				ResourceType:   "registry-image",
+				FetchedVersion: atc.Version{"digest": "sha256:abc"},
+			}
+
+			err = build.SaveEvent(imageGet)
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			env, err := events.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.Event).To(Equal(event.EventTypeImageGet))
+
+			parsed, err := event.ParseEvent(env.Version, env.Event, *env.Data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed).To(Equal(imageGet))
+		})
+
+		It("saves and reads back put step events", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			finishPut := event.FinishPut{
+				Origin:          event.Origin{ID: "some-plan-id"},
+				Time:            123,
+				ExitStatus:      0,
+				CreatedVersion:  atc.Version{"ref": "abc"},
+				CreatedMetadata: []atc.MetadataField{{Name: "commit", Value: "abc"}},
+			}
+
+			err = build.SaveEvent(finishPut)
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			env, err := events.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.Event).To(Equal(event.EventTypeFinishPut))
+
+			parsed, err := event.ParseEvent(env.Version, env.Event, *env.Data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed).To(Equal(finishPut))
+		})
+
+		It("saves and reads back secret access events", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			secretAccessed := event.SecretAccessed{
+				Origin: event.Origin{ID: "some-plan-id"},
+				Time:   123,
+				Path:   "/concourse/some-team/some-secret",
+			}
+
+			err = build.SaveEvent(secretAccessed)
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			env, err := events.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.Event).To(Equal(event.EventTypeSecretAccessed))
+
+			parsed, err := event.ParseEvent(env.Version, env.Event, *env.Data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed).To(Equal(secretAccessed))
+		})
+
+		It("saves many events at once, in order", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			err = build.SaveEvents([]atc.Event{
+				event.Log{Payload: "one"},
+				event.Log{Payload: "two"},
+				event.Log{Payload: "three"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events.Next()).To(matchEnvelope(event.Log{Payload: "one"}))
+			Expect(events.Next()).To(matchEnvelope(event.Log{Payload: "two"}))
+			Expect(events.Next()).To(matchEnvelope(event.Log{Payload: "three"}))
+
+			count, err := build.EventCount()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(3))
+		})
+
+		It("skips re-saving an event with the same client sequence number", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			for i := 0; i < 2; i++ {
+				err = build.SaveEventWithSeq(1, event.Log{Payload: "once"})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			err = build.SaveEventWithSeq(2, event.Log{Payload: "twice"})
+			Expect(err).NotTo(HaveOccurred())
+
+			count, err := build.EventCount()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(2))
+
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			Expect(events.Next()).To(matchEnvelope(event.Log{Payload: "once"}))
+			Expect(events.Next()).To(matchEnvelope(event.Log{Payload: "twice"}))
+		})
+
+		It("saves and propagates events correctly", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			By("allowing you to subscribe when no events have yet occurred")
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			By("saving them in order")
+			err = build.SaveEvent(event.Log{
+				Payload: "some ",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events.Next()).To(matchEnvelope(event.Log{
+				Payload: "some ",
+			}))
+
+			err = build.SaveEvent(event.Log{
+				Payload: "log",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events.Next()).To(matchEnvelope(event.Log{
+				Payload: "log",
+			}))
+
+			By("allowing you to subscribe from an offset")
+			eventsFrom1, err := build.Events(1)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(eventsFrom1)
+
+			Expect(eventsFrom1.Next()).To(matchEnvelope(event.Log{
+				Payload: "log",
+			}))
+
+			By("notifying those waiting on events as soon as they're saved")
+			nextEvent := make(chan event.Envelope)
+			nextErr := make(chan error)
+
+			go func() {
+				event, err := events.Next()
+				if err != nil {
+					nextErr <- err
+				} else {
+					nextEvent <- event
+				}
+			}()
+
+			Consistently(nextEvent).ShouldNot(Receive())
+			Consistently(nextErr).ShouldNot(Receive())
+
+			err = build.SaveEvent(event.Log{
+				Payload: "log 2",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(nextEvent).Should(Receive(matchEnvelope(event.Log{
+				Payload: "log 2",
+			})))
+
+			By("returning ErrBuildEventStreamClosed for Next calls after Close")
+			events3, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = events3.Close()
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func() error {
+				_, err := events3.Next()
+				return err
+			}).Should(Equal(db.ErrBuildEventStreamClosed))
+		})
+	})
+
+	Describe("EventOffsetAtFraction", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns 0 when the build has no events", func() {
+			offset, err := build.EventOffsetAtFraction(0.5)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(offset).To(BeZero())
+		})
+
+		Context("when the build has 100 events", func() {
+			BeforeEach(func() {
+				for i := 0; i < 100; i++ {
+					err := build.SaveEvent(event.Log{
+						Payload: fmt.Sprintf("log %d", i),
+					})
+					Expect(err).NotTo(HaveOccurred())
+				}
+			})
+
+			It("returns an offset near the midpoint", func() {
+				offset, err := build.EventOffsetAtFraction(0.5)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(offset).To(BeNumerically("~", 50, 1))
+
+				events, err := build.Events(offset)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer db.Close(events)
+
+				Expect(events.Next()).To(matchEnvelope(event.Log{
+					Payload: fmt.Sprintf("log %d", offset),
+				}))
+			})
+
+			It("returns the first and last offsets at the bounds", func() {
+				offset, err := build.EventOffsetAtFraction(0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(offset).To(BeZero())
+
+				offset, err = build.EventOffsetAtFraction(1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(offset).To(Equal(uint(99)))
+			})
+		})
+
+		It("rejects fractions outside of 0 to 1 and NaN", func() {
+			_, err := build.EventOffsetAtFraction(1.5)
+			Expect(err).To(Equal(db.ErrEventFractionOutOfRange))
+
+			_, err = build.EventOffsetAtFraction(-0.1)
+			Expect(err).To(Equal(db.ErrEventFractionOutOfRange))
+
+			_, err = build.EventOffsetAtFraction(math.NaN())
+			Expect(err).To(Equal(db.ErrEventFractionOutOfRange))
+		})
+	})
+
+	Describe("SaveOutput", func() {
+		var pipeline db.Pipeline
+		var job db.Job
+		var resourceConfigScope db.ResourceConfigScope
+
+		BeforeEach(func() {
+			pipelineConfig := atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+					},
+				},
+				Resources: atc.ResourceConfigs{
+					{
+						Name: "some-implicit-resource",
+						Type: "some-type",
+					},
+					{
+						Name:   "some-explicit-resource",
+						Type:   "some-type",
+						Source: atc.Source{"some": "explicit-source"},
+					},
+				},
+			}
+
+			var err error
+			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), false)
+			Expect(err).ToNot(HaveOccurred())
+
+			var found bool
+			job, found, err = pipeline.Job("some-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			setupTx, err := dbConn.Begin()
+			Expect(err).ToNot(HaveOccurred())
+
+			brt := db.BaseResourceType{
+				Name: "some-type",
+			}
+
+			_, err = brt.FindOrCreate(setupTx, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(setupTx.Commit()).To(Succeed())
+
+			resource, found, err := pipeline.Resource("some-explicit-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			resourceConfigScope, err = resource.SetResourceConfig(atc.Source{"some": "explicit-source"}, atc.VersionedResourceTypes{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the version does not exist", func() {
+			It("can save a build's output", func() {
+				build, err := job.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.SaveOutput("some-type", atc.Source{"some": "explicit-source"}, atc.VersionedResourceTypes{}, atc.Version{"some": "version"}, []db.ResourceConfigMetadataField{
+					{
+						Name:  "meta1",
+						Value: "data1",
+					},
+					{
+						Name:  "meta2",
+						Value: "data2",
+					},
+				}, "output-name", "some-explicit-resource")
+				Expect(err).ToNot(HaveOccurred())
+
+				rcv, found, err := resourceConfigScope.FindVersion(atc.Version{"some": "version"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, buildOutputs, err := build.Resources()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(buildOutputs)).To(Equal(1))
+				Expect(buildOutputs[0].Name).To(Equal("output-name"))
+				Expect(buildOutputs[0].Version).To(Equal(atc.Version(rcv.Version())))
+			})
+		})
+
+		Context("when the resource is not in the build's pipeline", func() {
+			It("returns an error without saving an output", func() {
+				build, err := job.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.SaveOutput("some-type", atc.Source{"some": "explicit-source"}, atc.VersionedResourceTypes{}, atc.Version{"some": "version"}, nil, "output-name", "some-bogus-resource")
+				Expect(err).To(Equal(db.ResourceNotFoundInPipeline{Resource: "some-bogus-resource", Pipeline: "some-pipeline"}))
+
+				outputs, err := build.OutputsSince(0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(outputs).To(BeEmpty())
+			})
+		})
+
+		Context("when the build is a one-off build", func() {
+			It("returns an error", func() {
+				build, err := team.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.SaveOutput("some-type", atc.Source{"some": "explicit-source"}, atc.VersionedResourceTypes{}, atc.Version{"some": "version"}, nil, "output-name", "some-explicit-resource")
+				Expect(err).To(Equal(db.ErrBuildHasNoPipeline))
+			})
+		})
+
+		Context("when the same version is saved twice under the same name", func() {
+			It("saves only one output", func() {
+				build, err := job.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				for i := 0; i < 2; i++ {
+					err = build.SaveOutput("some-type", atc.Source{"some": "explicit-source"}, atc.VersionedResourceTypes{}, atc.Version{"some": "version"}, nil, "output-name", "some-explicit-resource")
+					Expect(err).ToNot(HaveOccurred())
+				}
+
+				outputs, err := build.OutputsSince(0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(outputs).To(HaveLen(1))
+			})
+
+			It("still saves distinct versions separately", func() {
+				build, err := job.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.SaveOutput("some-type", atc.Source{"some": "explicit-source"}, atc.VersionedResourceTypes{}, atc.Version{"some": "version"}, nil, "output-name", "some-explicit-resource")
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.SaveOutput("some-type", atc.Source{"some": "explicit-source"}, atc.VersionedResourceTypes{}, atc.Version{"some": "other-version"}, nil, "output-name", "some-explicit-resource")
+				Expect(err).ToNot(HaveOccurred())
+
+				outputs, err := build.OutputsSince(0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(outputs).To(HaveLen(2))
+			})
+		})
+
+		Context("when the output's metadata is updated after the build finished", func() {
+			var build db.Build
+
+			BeforeEach(func() {
+				var err error
+				build, err = job.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.SaveOutput("some-type", atc.Source{"some": "explicit-source"}, atc.VersionedResourceTypes{}, atc.Version{"some": "version"}, []db.ResourceConfigMetadataField{
+					{Name: "commit", Value: "abc123"},
+				}, "output-name", "some-explicit-resource")
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.Finish(db.BuildStatusSucceeded)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the new metadata with the build's outputs", func() {
+				err := build.UpdateOutputMetadata("output-name", []db.ResourceConfigMetadataField{
+					{Name: "commit", Value: "abc123"},
+					{Name: "artifact", Value: "https://example.com/signed", Type: "link"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				_, outputs, err := build.Resources()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(outputs).To(HaveLen(1))
+				Expect(outputs[0].Metadata).To(Equal(db.ResourceConfigMetadataFields{
+					{Name: "commit", Value: "abc123"},
+					{Name: "artifact", Value: "https://example.com/signed", Type: "link"},
+				}))
+			})
+
+			It("errors for an unknown output", func() {
+				err := build.UpdateOutputMetadata("bogus-output", []db.ResourceConfigMetadataField{
+					{Name: "commit", Value: "abc123"},
+				})
+				Expect(err).To(Equal(db.ErrBuildOutputNotFound))
+			})
+		})
+
+		Context("when the metadata has typed fields", func() {
+			It("saves and returns the field types", func() {
+				build, err := job.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				metadata := []db.ResourceConfigMetadataField{
+					{
+						Name:  "url",
+						Value: "https://example.com/some-commit",
+						Type:  "link",
+					},
+					{
+						Name:  "message",
+						Value: "some message",
+					},
+				}
+
+				err = build.SaveOutput("some-type", atc.Source{"some": "explicit-source"}, atc.VersionedResourceTypes{}, atc.Version{"some": "typed-version"}, metadata, "output-name", "some-explicit-resource")
+				Expect(err).ToNot(HaveOccurred())
+
+				rcv, found, err := resourceConfigScope.FindVersion(atc.Version{"some": "typed-version"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(rcv.Metadata()).To(Equal(db.ResourceConfigMetadataFields(metadata)))
+				Expect(rcv.Metadata().ToATCMetadata()).To(Equal([]atc.MetadataField{
+					{Name: "url", Value: "https://example.com/some-commit", Type: "link"},
+					{Name: "message", Value: "some message"},
+				}))
+			})
+		})
+
+		Context("when the version already exists", func() {
+			var rcv db.ResourceConfigVersion
+
+			BeforeEach(func() {
+				err := resourceConfigScope.SaveVersions([]atc.Version{{"some": "version"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				var found bool
+				rcv, found, err = resourceConfigScope.FindVersion(atc.Version{"some": "version"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("does not increment the check order", func() {
+				build, err := job.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.SaveOutput("some-type", atc.Source{"some": "explicit-source"}, atc.VersionedResourceTypes{}, atc.Version{"some": "version"}, []db.ResourceConfigMetadataField{
+					{
+						Name:  "meta1",
+						Value: "data1",
+					},
+					{
+						Name:  "meta2",
+						Value: "data2",
+					},
+				}, "output-name", "some-explicit-resource")
+				Expect(err).ToNot(HaveOccurred())
+
+				newRCV, found, err := resourceConfigScope.FindVersion(atc.Version{"some": "version"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(newRCV.CheckOrder()).To(Equal(rcv.CheckOrder()))
+			})
+		})
+	})
+
+	Describe("DownstreamJobs", func() {
+		var upstreamJob db.Job
+		var resource db.Resource
+
+		BeforeEach(func() {
+			setupTx, err := dbConn.Begin()
+			Expect(err).ToNot(HaveOccurred())
+
+			brt := db.BaseResourceType{
+				Name: "some-type",
+			}
+
+			_, err = brt.FindOrCreate(setupTx, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(setupTx.Commit()).To(Succeed())
+
+			pipeline, _, err := team.SavePipeline("some-pipeline", atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name: "job-a",
+						Plan: atc.PlanSequence{
+							{Get: "some-resource"},
+						},
+					},
+					{
+						Name: "job-b",
+						Plan: atc.PlanSequence{
+							{Get: "some-resource", Passed: []string{"job-a"}},
+						},
+					},
+					{
+						Name: "job-c",
+						Plan: atc.PlanSequence{
+							{Get: "some-resource"},
+							{Get: "some-other-resource", Passed: []string{"job-a"}},
+						},
+					},
+				},
+				Resources: atc.ResourceConfigs{
+					{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"some": "source"},
+					},
+					{
+						Name:   "some-other-resource",
+						Type:   "some-type",
+						Source: atc.Source{"some": "other-source"},
+					},
+				},
+			}, db.ConfigVersion(0), false)
+			Expect(err).ToNot(HaveOccurred())
+
+			var found bool
+			upstreamJob, found, err = pipeline.Job("job-a")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			resource, found, err = pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			resourceConfigScope, err := resource.SetResourceConfig(atc.Source{"some": "source"}, atc.VersionedResourceTypes{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = resourceConfigScope.SaveVersions([]atc.Version{{"ver": "1"}})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the jobs which pass the build's resources from its job", func() {
+			build, err := upstreamJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.UseInputs([]db.BuildInput{
+				{
+					Name:       "some-resource",
+					Version:    atc.Version{"ver": "1"},
+					ResourceID: resource.ID(),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			jobs, err := build.DownstreamJobs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jobs).To(HaveLen(1))
+			Expect(jobs[0].Name()).To(Equal("job-b"))
+		})
+
+		It("returns no jobs for a one-off build", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			jobs, err := build.DownstreamJobs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jobs).To(BeEmpty())
+		})
+	})
+
+	Describe("Resources", func() {
+		var (
+			pipeline             db.Pipeline
+			job                  db.Job
+			resourceConfigScope1 db.ResourceConfigScope
+			resource1            db.Resource
+			resource2            db.Resource
+		)
+
+		BeforeEach(func() {
+			setupTx, err := dbConn.Begin()
+			Expect(err).ToNot(HaveOccurred())
+
+			brt := db.BaseResourceType{
+				Name: "some-type",
+			}
+
+			_, err = brt.FindOrCreate(setupTx, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(setupTx.Commit()).To(Succeed())
+
+			pipelineConfig := atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+					},
+				},
+				Resources: atc.ResourceConfigs{
+					{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"some": "source"},
+					},
+					{
+						Name:   "some-other-resource",
+						Type:   "some-type",
+						Source: atc.Source{"some": "source-2"},
+					},
+					{
+						Name:   "some-unused-resource",
+						Type:   "some-type",
+						Source: atc.Source{"some": "source-3"},
+					},
+				},
+			}
+
+			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), false)
+			Expect(err).ToNot(HaveOccurred())
+
+			var found bool
+			job, found, err = pipeline.Job("some-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			resource1, found, err = pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			resource2, found, err = pipeline.Resource("some-other-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			resourceConfigScope1, err = resource1.SetResourceConfig(atc.Source{"some": "source-1"}, atc.VersionedResourceTypes{})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = resource2.SetResourceConfig(atc.Source{"some": "source-2"}, atc.VersionedResourceTypes{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = resourceConfigScope1.SaveVersions([]atc.Version{
+				{"ver": "1"},
+				{"ver": "2"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			// This version should not be returned by the Resources method because it has a check order of 0
+			created, err := resource1.SaveUncheckedVersion(atc.Version{"ver": "not-returned"}, nil, resourceConfigScope1.ResourceConfig(), atc.VersionedResourceTypes{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeTrue())
+		})
+
+		It("returns build inputs and outputs", func() {
+			build, err := job.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			// save a normal 'get'
+			err = build.UseInputs([]db.BuildInput{
+				db.BuildInput{
+					Name:       "some-input",
+					Version:    atc.Version{"ver": "1"},
+					ResourceID: resource1.ID(),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			// save explicit output from 'put'
+			err = build.SaveOutput("some-type", atc.Source{"some": "source-2"}, atc.VersionedResourceTypes{}, atc.Version{"ver": "2"}, nil, "some-output-name", "some-other-resource")
+			Expect(err).NotTo(HaveOccurred())
+
+			inputs, outputs, err := build.Resources()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(inputs).To(ConsistOf([]db.BuildInput{
+				{Name: "some-input", Version: atc.Version{"ver": "1"}, ResourceID: resource1.ID(), FirstOccurrence: true},
+			}))
+
+			Expect(outputs).To(ConsistOf([]db.BuildOutput{
+				{
+					Name:       "some-output-name",
+					Version:    atc.Version{"ver": "2"},
+					ResourceID: resource2.ID(),
+				},
+			}))
+		})
+
+		It("records the input and output counts when the build finishes", func() {
+			build, err := job.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.UseInputs([]db.BuildInput{
+				{
+					Name:       "some-input",
+					Version:    atc.Version{"ver": "1"},
+					ResourceID: resource1.ID(),
+				},
+				{
+					Name:       "some-other-input",
+					Version:    atc.Version{"ver": "2"},
+					ResourceID: resource1.ID(),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveOutput("some-type", atc.Source{"some": "source-2"}, atc.VersionedResourceTypes{}, atc.Version{"ver": "2"}, nil, "some-output-name", "some-other-resource")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(build.InputCount()).To(BeZero())
+			Expect(build.OutputCount()).To(BeZero())
+
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			inputs, outputs, err := build.Resources()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(build.InputCount()).To(Equal(len(inputs)))
+			Expect(build.OutputCount()).To(Equal(len(outputs)))
+
+			reloaded, found, err := buildFactory.Build(build.ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(reloaded.InputCount()).To(Equal(2))
+			Expect(reloaded.OutputCount()).To(Equal(1))
+		})
+
+		It("emits a summary with the counts after the finish status event", func() {
+			build, err := job.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			started, err := build.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			err = build.UseInputs([]db.BuildInput{
+				{
+					Name:       "some-input",
+					Version:    atc.Version{"ver": "1"},
+					ResourceID: resource1.ID(),
+				},
+				{
+					Name:       "some-other-input",
+					Version:    atc.Version{"ver": "2"},
+					ResourceID: resource1.ID(),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveOutput("some-type", atc.Source{"some": "source-2"}, atc.VersionedResourceTypes{}, atc.Version{"ver": "2"}, nil, "some-output-name", "some-other-resource")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.Finish(db.BuildStatusFailed)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			events, err := build.Events(1)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			Expect(events.Next()).To(matchEnvelope(event.Status{
+				Status: atc.StatusFailed,
+				Time:   build.EndTime().Unix(),
+			}))
+
+			Expect(events.Next()).To(matchEnvelope(event.BuildSummary{
+				Status:   atc.StatusFailed,
+				Time:     build.EndTime().Unix(),
+				Duration: int64(build.EndTime().Sub(build.StartTime()) / time.Second),
+				Inputs:   2,
+				Outputs:  1,
+			}))
+
+			_, err = events.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+		})
+
+		Describe("ResourcesWithMetadata", func() {
+			It("returns the metadata of each input's version", func() {
+				metadata := db.ResourceConfigMetadataFields{
+					{Name: "commit", Value: "abc"},
+				}
+
+				updated, err := resource1.UpdateMetadata(atc.Version{"ver": "1"}, metadata)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updated).To(BeTrue())
+
+				build, err := job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.UseInputs([]db.BuildInput{
+					{
+						Name:       "some-input",
+						Version:    atc.Version{"ver": "1"},
+						ResourceID: resource1.ID(),
+					},
+					{
+						Name:       "some-other-input",
+						Version:    atc.Version{"ver": "2"},
+						ResourceID: resource1.ID(),
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				inputs, _, err := build.ResourcesWithMetadata()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(inputs).To(ConsistOf([]db.BuildInput{
+					{Name: "some-input", Version: atc.Version{"ver": "1"}, ResourceID: resource1.ID(), FirstOccurrence: true, Metadata: metadata},
+					{Name: "some-other-input", Version: atc.Version{"ver": "2"}, ResourceID: resource1.ID(), FirstOccurrence: true},
+				}))
+
+				By("leaving it out of Resources")
+				inputs, _, err = build.Resources()
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, input := range inputs {
+					Expect(input.Metadata).To(BeEmpty())
+				}
+			})
+		})
+
+		Describe("SaveOutputDeleted", func() {
+			var build db.Build
+
+			versionDeleted := func(version string) bool {
+				var deleted bool
+				err := dbConn.QueryRow(`
+					SELECT deleted FROM resource_config_versions
+					WHERE resource_config_scope_id = (SELECT resource_config_scope_id FROM resources WHERE id = $1)
+					AND version_md5 = md5($2)`, resource2.ID(), version).Scan(&deleted)
+				Expect(err).NotTo(HaveOccurred())
+				return deleted
+			}
+
+			BeforeEach(func() {
+				var err error
+				build, err = job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveOutput("some-type", atc.Source{"some": "source-2"}, atc.VersionedResourceTypes{}, atc.Version{"ver": "2"}, nil, "some-output-name", "some-other-resource")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveOutput("some-type", atc.Source{"some": "source-2"}, atc.VersionedResourceTypes{}, atc.Version{"ver": "3"}, nil, "some-output-name", "some-other-resource")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("removes the deleted version from the build's outputs and marks it as deleted", func() {
+				err := build.SaveOutputDeleted("some-other-resource", atc.Version{"ver": "2"})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, outputs, err := build.Resources()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(outputs).To(ConsistOf([]db.BuildOutput{
+					{
+						Name:       "some-output-name",
+						Version:    atc.Version{"ver": "3"},
+						ResourceID: resource2.ID(),
+					},
+				}))
+
+				Expect(versionDeleted(`{"ver":"2"}`)).To(BeTrue())
+				Expect(versionDeleted(`{"ver":"3"}`)).To(BeFalse())
+			})
+
+			It("leaves the version enabled", func() {
+				err := build.SaveOutputDeleted("some-other-resource", atc.Version{"ver": "2"})
+				Expect(err).NotTo(HaveOccurred())
+
+				var disabled bool
+				err = dbConn.QueryRow(`
+					SELECT EXISTS (
+						SELECT 1 FROM resource_disabled_versions
+						WHERE resource_id = $1 AND version_md5 = md5($2)
+					)`, resource2.ID(), `{"ver":"2"}`).Scan(&disabled)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(disabled).To(BeFalse())
+			})
+
+			It("brings the version back when it is saved again", func() {
+				err := build.SaveOutputDeleted("some-other-resource", atc.Version{"ver": "2"})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveOutput("some-type", atc.Source{"some": "source-2"}, atc.VersionedResourceTypes{}, atc.Version{"ver": "2"}, nil, "some-output-name", "some-other-resource")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(versionDeleted(`{"ver":"2"}`)).To(BeFalse())
+			})
+
+			It("fails for a resource which is not in the pipeline", func() {
+				err := build.SaveOutputDeleted("bogus-resource", atc.Version{"ver": "2"})
+				Expect(err).To(Equal(db.ResourceNotFoundInPipeline{Resource: "bogus-resource", Pipeline: "some-pipeline"}))
+			})
+		})
+
+		It("can't get no satisfaction (resources from a one-off build)", func() {
+			oneOffBuild, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			inputs, outputs, err := oneOffBuild.Resources()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(inputs).To(BeEmpty())
+			Expect(outputs).To(BeEmpty())
+		})
+	})
+
+	Describe("OutputsSince", func() {
+		var (
+			pipeline db.Pipeline
+			job      db.Job
+		)
+
+		BeforeEach(func() {
+			setupTx, err := dbConn.Begin()
+			Expect(err).ToNot(HaveOccurred())
+
+			brt := db.BaseResourceType{
+				Name: "some-type",
+			}
+
+			_, err = brt.FindOrCreate(setupTx, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(setupTx.Commit()).To(Succeed())
+
+			pipelineConfig := atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+					},
+				},
+				Resources: atc.ResourceConfigs{
+					{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"some": "source"},
+					},
+				},
+			}
+
+			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), false)
+			Expect(err).ToNot(HaveOccurred())
+
+			var found bool
+			job, found, err = pipeline.Job("some-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("returns only the outputs saved after the given output", func() {
+			build, err := job.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveOutput("some-type", atc.Source{"some": "source"}, atc.VersionedResourceTypes{}, atc.Version{"ver": "1"}, nil, "first-output", "some-resource")
+			Expect(err).NotTo(HaveOccurred())
+
+			outputs, err := build.OutputsSince(0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outputs).To(HaveLen(1))
+			Expect(outputs[0].Name).To(Equal("first-output"))
+			Expect(outputs[0].Version).To(Equal(atc.Version{"ver": "1"}))
+
+			cursor := outputs[0].ID
+
+			err = build.SaveOutput("some-type", atc.Source{"some": "source"}, atc.VersionedResourceTypes{}, atc.Version{"ver": "2"}, nil, "second-output", "some-resource")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveOutput("some-type", atc.Source{"some": "source"}, atc.VersionedResourceTypes{}, atc.Version{"ver": "3"}, nil, "third-output", "some-resource")
+			Expect(err).NotTo(HaveOccurred())
+
+			outputs, err = build.OutputsSince(cursor)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outputs).To(HaveLen(2))
+			Expect(outputs[0].Name).To(Equal("second-output"))
+			Expect(outputs[0].Version).To(Equal(atc.Version{"ver": "2"}))
+			Expect(outputs[1].Name).To(Equal("third-output"))
+			Expect(outputs[1].Version).To(Equal(atc.Version{"ver": "3"}))
+			Expect(outputs[1].ID).To(BeNumerically(">", outputs[0].ID))
+
+			outputs, err = build.OutputsSince(outputs[1].ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outputs).To(BeEmpty())
+		})
+
+		It("returns nothing for a one-off build", func() {
+			oneOffBuild, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			outputs, err := oneOffBuild.OutputsSince(0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outputs).To(BeEmpty())
+		})
+	})
+
+	Describe("Pipeline", func() {
+		var (
+			build           db.Build
+			foundPipeline   db.Pipeline
+			createdPipeline db.Pipeline
+			found           bool
+		)
+
+		JustBeforeEach(func() {
+			var err error
+			foundPipeline, found, err = build.Pipeline()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when a job build", func() {
+			BeforeEach(func() {
+				var err error
+				createdPipeline, _, err = team.SavePipeline("some-pipeline", atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name: "some-job",
+						},
+					},
+				}, db.ConfigVersion(1), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				job, found, err := createdPipeline.Job("some-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				build, err = job.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the correct pipeline", func() {
+				Expect(found).To(BeTrue())
+				Expect(foundPipeline).To(Equal(createdPipeline))
+			})
+		})
+
+		Context("when a one off build", func() {
+			BeforeEach(func() {
+				var err error
+				build, err = team.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not return a pipeline", func() {
+				Expect(found).To(BeFalse())
+				Expect(foundPipeline).To(BeNil())
+			})
+		})
+	})
+
+	Describe("WorkerNames", func() {
+		It("returns each worker the build ran on once, in the order they were saved", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			workerNames, err := build.WorkerNames()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workerNames).To(BeEmpty())
+
+			Expect(build.SaveWorkerName("some-worker")).To(Succeed())
+			Expect(build.SaveWorkerName("some-other-worker")).To(Succeed())
+			Expect(build.SaveWorkerName("some-worker")).To(Succeed())
+
+			workerNames, err = build.WorkerNames()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workerNames).To(Equal([]string{"some-worker", "some-other-worker"}))
+		})
+	})
+
+	Describe("Reruns", func() {
+		var parent db.Build
+
+		BeforeEach(func() {
+			var err error
+			parent, err = defaultJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns no builds when the build has not been rerun", func() {
+			reruns, err := parent.Reruns()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reruns).To(BeEmpty())
+		})
+
+		It("returns the chain of reruns in the order they were created", func() {
+			firstRerun, err := defaultJob.CreateRetryBuild(parent)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = defaultJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			secondRerun, err := defaultJob.CreateRetryBuild(firstRerun)
+			Expect(err).NotTo(HaveOccurred())
+
+			reruns, err := parent.Reruns()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reruns).To(HaveLen(2))
+			Expect(reruns[0].ID()).To(Equal(firstRerun.ID()))
+			Expect(reruns[1].ID()).To(Equal(secondRerun.ID()))
+
+			reruns, err = firstRerun.Reruns()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reruns).To(HaveLen(1))
+			Expect(reruns[0].ID()).To(Equal(secondRerun.ID()))
+		})
+	})
+
+	Describe("Job", func() {
+		var (
+			build      db.Build
+			foundJob   db.Job
+			createdJob db.Job
+			found      bool
+		)
+
+		JustBeforeEach(func() {
+			var err error
+			foundJob, found, err = build.Job()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when a job build", func() {
+			BeforeEach(func() {
+				pipeline, _, err := team.SavePipeline("some-pipeline", atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name: "some-job",
+						},
+					},
+				}, db.ConfigVersion(1), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				createdJob, found, err = pipeline.Job("some-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				build, err = createdJob.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = createdJob.Reload()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the build's job", func() {
+				Expect(found).To(BeTrue())
+				Expect(foundJob).To(Equal(createdJob))
+			})
+		})
+
+		Context("when a one off build", func() {
+			BeforeEach(func() {
+				var err error
+				build, err = team.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not return a job", func() {
+				Expect(found).To(BeFalse())
+				Expect(foundJob).To(BeNil())
+			})
+		})
+	})
+
+	Describe("Preparation", func() {
+		var (
+			build             db.Build
+			err               error
+			expectedBuildPrep db.BuildPreparation
+		)
+		BeforeEach(func() {
+			expectedBuildPrep = db.BuildPreparation{
+				BuildID:               123456789,
+				PausedPipeline:        db.BuildPreparationStatusNotBlocking,
+				PausedJob:             db.BuildPreparationStatusNotBlocking,
+				MaxRunningBuilds:      db.BuildPreparationStatusNotBlocking,
+				Inputs:                map[string]db.BuildPreparationStatus{},
+				InputsSatisfied:       db.BuildPreparationStatusNotBlocking,
+				MissingInputReasons:   db.MissingInputReasons{},
+				MissingWorker:         db.BuildPreparationStatusNotBlocking,
+				MissingWorkerReasons:  db.MissingWorkerReasons{},
+				Held:                  db.BuildPreparationStatusNotBlocking,
+				SerialGroups:          db.BuildPreparationStatusNotBlocking,
+				AwaitingManualTrigger: db.BuildPreparationStatusNotBlocking,
+				FilteredInputs:        map[string]int{},
+			}
+		})
+
+		Context("for a job sharing a serial group with another job", func() {
+			var otherJob db.Job
+
+			BeforeEach(func() {
+				pipeline, _, err := team.SavePipeline("serial-pipeline", atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name:         "some-job",
+							SerialGroups: []string{"some-serial-group"},
+						},
+						{
+							Name:         "some-other-job",
+							SerialGroups: []string{"some-serial-group"},
+						},
+					},
+				}, db.ConfigVersion(1), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				job, found, err := pipeline.Job("some-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				otherJob, found, err = pipeline.Job("some-other-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				build, err = job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("is not blocked by the serial group while the other job is idle", func() {
+				buildPrep, found, err := build.Preparation()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(buildPrep.SerialGroups).To(Equal(db.BuildPreparationStatusNotBlocking))
+			})
+
+			Context("when the other job has a running build", func() {
+				var otherBuild db.Build
+
+				BeforeEach(func() {
+					otherBuild, err = otherJob.CreateBuild()
+					Expect(err).NotTo(HaveOccurred())
+
+					scheduled, err := otherBuild.Schedule()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(scheduled).To(BeTrue())
+
+					started, err := otherBuild.Start(atc.Plan{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(started).To(BeTrue())
+				})
+
+				It("is blocked by the serial group", func() {
+					buildPrep, found, err := build.Preparation()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(buildPrep.SerialGroups).To(Equal(db.BuildPreparationStatusBlocking))
+				})
+
+				It("is unblocked once the other build finishes", func() {
+					err := otherBuild.Finish(db.BuildStatusSucceeded)
+					Expect(err).NotTo(HaveOccurred())
+
+					buildPrep, found, err := build.Preparation()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(buildPrep.SerialGroups).To(Equal(db.BuildPreparationStatusNotBlocking))
+				})
+			})
+		})
+
+		Context("for a job whose inputs do not trigger it", func() {
+			var (
+				job      db.Job
+				trigger  bool
+				resource db.Resource
+				rcv      db.ResourceConfigVersion
+			)
+
+			BeforeEach(func() {
+				trigger = false
+			})
+
+			JustBeforeEach(func() {
+				setupTx, err := dbConn.Begin()
+				Expect(err).ToNot(HaveOccurred())
+
+				brt := db.BaseResourceType{
+					Name: "some-type",
+				}
+
+				_, err = brt.FindOrCreate(setupTx, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(setupTx.Commit()).To(Succeed())
+
+				pipeline, _, err := team.SavePipeline("manual-pipeline", atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-resource",
+							Type:   "some-type",
+							Source: atc.Source{"some": "source"},
+						},
+					},
+					Jobs: atc.JobConfigs{
+						{
+							Name: "some-job",
+							Plan: atc.PlanSequence{
+								{Get: "some-input", Resource: "some-resource", Trigger: trigger},
+							},
+						},
+					},
+				}, db.ConfigVersion(1), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				var found bool
+				job, found, err = pipeline.Job("some-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				resource, found, err = pipeline.Resource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				resourceConfigScope, err := resource.SetResourceConfig(atc.Source{"some": "source"}, atc.VersionedResourceTypes{})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = resourceConfigScope.SaveVersions([]atc.Version{{"version": "v1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				rcv, found, err = resourceConfigScope.FindVersion(atc.Version{"version": "v1"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = job.SaveNextInputMapping(algorithm.InputMapping{
+					"some-input": {VersionID: rcv.ID(), ResourceID: resource.ID(), FirstOccurrence: true},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = job.EnsurePendingBuildExists()
+				Expect(err).NotTo(HaveOccurred())
+
+				pendingBuilds, err := job.GetPendingBuilds()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pendingBuilds).To(HaveLen(1))
+
+				build = pendingBuilds[0]
+			})
+
+			It("is awaiting a manual trigger although its inputs are satisfied", func() {
+				buildPrep, found, err := build.Preparation()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(buildPrep.InputsSatisfied).To(Equal(db.BuildPreparationStatusNotBlocking))
+				Expect(buildPrep.AwaitingManualTrigger).To(Equal(db.BuildPreparationStatusBlocking))
+			})
+
+			Context("when an input triggers the job", func() {
+				BeforeEach(func() {
+					trigger = true
+				})
+
+				It("is not awaiting a manual trigger", func() {
+					buildPrep, found, err := build.Preparation()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(buildPrep.AwaitingManualTrigger).To(Equal(db.BuildPreparationStatusNotBlocking))
+				})
+			})
+
+			Context("when the build was created manually", func() {
+				JustBeforeEach(func() {
+					build, err = job.CreateBuild()
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("is not awaiting a manual trigger", func() {
+					buildPrep, found, err := build.Preparation()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(buildPrep.AwaitingManualTrigger).To(Equal(db.BuildPreparationStatusNotBlocking))
+				})
+			})
+		})
+
+		Context("for one-off build", func() {
+			BeforeEach(func() {
+				build, err = team.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				expectedBuildPrep.BuildID = build.ID()
+			})
+
+			It("returns build preparation", func() {
+				buildPrep, found, err := build.Preparation()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(buildPrep).To(Equal(expectedBuildPrep))
+			})
+
+			Context("when the build is started", func() {
+				BeforeEach(func() {
+					started, err := build.Start(atc.Plan{})
+					Expect(started).To(BeTrue())
+					Expect(err).NotTo(HaveOccurred())
+
+					stillExists, err := build.Reload()
+					Expect(stillExists).To(BeTrue())
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns build preparation", func() {
+					buildPrep, found, err := build.Preparation()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(buildPrep).To(Equal(expectedBuildPrep))
+				})
+			})
+		})
+
+		Context("for job build", func() {
+			var (
+				pipeline db.Pipeline
+				job      db.Job
+			)
+
+			BeforeEach(func() {
+				var err error
+				pipeline, _, err = team.SavePipeline("some-pipeline", atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name: "some-resource",
+							Type: "some-type",
+							Source: atc.Source{
+								"source-config": "some-value",
+							},
+						},
+					},
+					Jobs: atc.JobConfigs{
+						{
+							Name: "some-job",
+						},
+					},
+				}, db.ConfigVersion(1), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				var found bool
+				job, found, err = pipeline.Job("some-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				build, err = job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				expectedBuildPrep.BuildID = build.ID()
+
+				job, found, err = pipeline.Job("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			Context("when inputs are satisfied", func() {
+				var (
+					resourceConfigScope db.ResourceConfigScope
+					resource            db.Resource
+					found               bool
+					rcv                 db.ResourceConfigVersion
+					err                 error
+				)
+
+				BeforeEach(func() {
+					setupTx, err := dbConn.Begin()
+					Expect(err).ToNot(HaveOccurred())
+
+					brt := db.BaseResourceType{
+						Name: "some-type",
+					}
+
+					_, err = brt.FindOrCreate(setupTx, false)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(setupTx.Commit()).To(Succeed())
+
+					resource, found, err = pipeline.Resource("some-resource")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					resourceConfigScope, err = resource.SetResourceConfig(atc.Source{"some": "source"}, atc.VersionedResourceTypes{})
+					Expect(err).NotTo(HaveOccurred())
+
+					err = resourceConfigScope.SaveVersions([]atc.Version{{"version": "v5"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					rcv, found, err = resourceConfigScope.FindVersion(atc.Version{"version": "v5"})
+					Expect(found).To(BeTrue())
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				Context("when resource check finished after build created", func() {
+					BeforeEach(func() {
+						updated, err := resourceConfigScope.UpdateLastCheckEndTime()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(updated).To(BeTrue())
+
+						reloaded, err := resource.Reload()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(reloaded).To(BeTrue())
+
+						lastCheckEndTime := resource.LastCheckEndTime()
+						Expect(lastCheckEndTime.IsZero()).To(BeFalse())
+
+						err = job.SaveNextInputMapping(algorithm.InputMapping{
+							"some-input": {VersionID: rcv.ID(), ResourceID: resource.ID(), FirstOccurrence: true},
+						})
+						Expect(err).NotTo(HaveOccurred())
 
 						expectedBuildPrep.Inputs = map[string]db.BuildPreparationStatus{
 							"some-input": db.BuildPreparationStatusNotBlocking,
 						}
 					})
 
-					Context("when the build is started", func() {
-						BeforeEach(func() {
-							started, err := build.Start(atc.Plan{})
-							Expect(started).To(BeTrue())
-							Expect(err).NotTo(HaveOccurred())
+					Context("when the build is started", func() {
+						BeforeEach(func() {
+							started, err := build.Start(atc.Plan{})
+							Expect(started).To(BeTrue())
+							Expect(err).NotTo(HaveOccurred())
+
+							stillExists, err := build.Reload()
+							Expect(stillExists).To(BeTrue())
+							Expect(err).NotTo(HaveOccurred())
+
+							expectedBuildPrep.Inputs = map[string]db.BuildPreparationStatus{}
+						})
+
+						It("returns build preparation", func() {
+							buildPrep, found, err := build.Preparation()
+							Expect(err).NotTo(HaveOccurred())
+							Expect(found).To(BeTrue())
+							Expect(buildPrep).To(Equal(expectedBuildPrep))
+						})
+					})
+
+					Context("when pipeline is paused", func() {
+						BeforeEach(func() {
+							err := pipeline.Pause()
+							Expect(err).NotTo(HaveOccurred())
+
+							expectedBuildPrep.PausedPipeline = db.BuildPreparationStatusBlocking
+						})
+
+						It("returns build preparation with paused pipeline", func() {
+							buildPrep, found, err := build.Preparation()
+							Expect(err).NotTo(HaveOccurred())
+							Expect(found).To(BeTrue())
+							Expect(buildPrep).To(Equal(expectedBuildPrep))
+						})
+					})
+
+					Context("when job is paused", func() {
+						BeforeEach(func() {
+							err := job.Pause()
+							Expect(err).NotTo(HaveOccurred())
+
+							expectedBuildPrep.PausedJob = db.BuildPreparationStatusBlocking
+						})
+
+						It("returns build preparation with paused pipeline", func() {
+							buildPrep, found, err := build.Preparation()
+							Expect(err).NotTo(HaveOccurred())
+							Expect(found).To(BeTrue())
+							Expect(buildPrep).To(Equal(expectedBuildPrep))
+						})
+					})
+
+					Context("when max running builds is reached", func() {
+						BeforeEach(func() {
+							err := job.SetMaxInFlightReached(true)
+							Expect(err).NotTo(HaveOccurred())
+
+							expectedBuildPrep.MaxRunningBuilds = db.BuildPreparationStatusBlocking
+						})
+
+						It("returns build preparation with max in flight reached", func() {
+							buildPrep, found, err := build.Preparation()
+							Expect(err).NotTo(HaveOccurred())
+							Expect(found).To(BeTrue())
+							Expect(buildPrep).To(Equal(expectedBuildPrep))
+						})
+					})
+
+					Context("when the build is held", func() {
+						BeforeEach(func() {
+							err := build.Hold()
+							Expect(err).NotTo(HaveOccurred())
+
+							expectedBuildPrep.Held = db.BuildPreparationStatusBlocking
+						})
+
+						It("returns build preparation with held blocking", func() {
+							buildPrep, found, err := build.Preparation()
+							Expect(err).NotTo(HaveOccurred())
+							Expect(found).To(BeTrue())
+							Expect(buildPrep).To(Equal(expectedBuildPrep))
+						})
+					})
+
+					Context("when no worker satisfies the build's tags", func() {
+						BeforeEach(func() {
+							err := build.SetWaitingForWorker([]string{"some-tag", "other-tag"})
+							Expect(err).NotTo(HaveOccurred())
+
+							expectedBuildPrep.MissingWorker = db.BuildPreparationStatusBlocking
+							expectedBuildPrep.MissingWorkerReasons = db.MissingWorkerReasons{
+								"tags": fmt.Sprintf(db.NoWorkersSatisfiedTags, "some-tag, other-tag"),
+							}
+						})
+
+						It("returns build preparation with missing worker", func() {
+							buildPrep, found, err := build.Preparation()
+							Expect(err).NotTo(HaveOccurred())
+							Expect(found).To(BeTrue())
+							Expect(buildPrep).To(Equal(expectedBuildPrep))
+						})
+
+						Context("when the build is no longer waiting for a worker", func() {
+							BeforeEach(func() {
+								err := build.SetWaitingForWorker(nil)
+								Expect(err).NotTo(HaveOccurred())
+
+								expectedBuildPrep.MissingWorker = db.BuildPreparationStatusNotBlocking
+								expectedBuildPrep.MissingWorkerReasons = db.MissingWorkerReasons{}
+							})
+
+							It("returns build preparation without missing worker", func() {
+								buildPrep, found, err := build.Preparation()
+								Expect(err).NotTo(HaveOccurred())
+								Expect(found).To(BeTrue())
+								Expect(buildPrep).To(Equal(expectedBuildPrep))
+							})
+						})
+					})
+
+					Context("when max running builds is de-reached", func() {
+						BeforeEach(func() {
+							err := job.SetMaxInFlightReached(true)
+							Expect(err).NotTo(HaveOccurred())
+
+							err = job.SetMaxInFlightReached(false)
+							Expect(err).NotTo(HaveOccurred())
+						})
+
+						It("returns build preparation with max in flight not reached", func() {
+							buildPrep, found, err := build.Preparation()
+							Expect(err).NotTo(HaveOccurred())
+							Expect(found).To(BeTrue())
+							Expect(buildPrep).To(Equal(expectedBuildPrep))
+						})
+					})
+				})
+
+				Context("when no resource check finished after build created", func() {
+					BeforeEach(func() {
+						err = job.SaveNextInputMapping(algorithm.InputMapping{
+							"some-input": {VersionID: rcv.ID(), ResourceID: resource.ID(), FirstOccurrence: true},
+						})
+						Expect(err).NotTo(HaveOccurred())
+
+						expectedBuildPrep.Inputs = map[string]db.BuildPreparationStatus{
+							"some-input": db.BuildPreparationStatusBlocking,
+						}
+						expectedBuildPrep.InputsSatisfied = db.BuildPreparationStatusBlocking
+						expectedBuildPrep.MissingInputReasons = db.MissingInputReasons{
+							"some-input": db.NoResourceCheckFinished,
+						}
+					})
+
+					It("returns build preparation with missing input reason", func() {
+						buildPrep, found, err := build.Preparation()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(found).To(BeTrue())
+						Expect(buildPrep).To(Equal(expectedBuildPrep))
+					})
+				})
+			})
+
+			Context("when inputs are not satisfied", func() {
+				BeforeEach(func() {
+					pipeline, _, err = team.SavePipeline("some-pipeline", atc.Config{
+						Resources: atc.ResourceConfigs{
+							{
+								Name: "some-resource",
+								Type: "some-type",
+								Source: atc.Source{
+									"source-config": "some-value",
+								},
+							},
+						},
+						Jobs: atc.JobConfigs{
+							{
+								Name: "some-job",
+							},
+						},
+					}, db.ConfigVersion(2), false)
+					Expect(err).ToNot(HaveOccurred())
+					expectedBuildPrep.InputsSatisfied = db.BuildPreparationStatusBlocking
+				})
+
+				It("returns blocking inputs satisfied", func() {
+					buildPrep, found, err := build.Preparation()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(buildPrep).To(Equal(expectedBuildPrep))
+				})
+			})
+
+			Context("when some inputs are not satisfied", func() {
+				BeforeEach(func() {
+					pipelineConfig := atc.Config{
+						Jobs: atc.JobConfigs{
+							{
+								Name: "some-job",
+								Plan: atc.PlanSequence{
+									{
+										Get:     "input1",
+										Version: &atc.VersionConfig{Pinned: atc.Version{"version": "v1"}},
+									},
+									{Get: "input2"},
+									{Get: "input3", Passed: []string{"some-upstream-job"}},
+									{ // version doesn't exist
+										Get:     "input4",
+										Version: &atc.VersionConfig{Pinned: atc.Version{"version": "v4"}},
+									},
+									{ // version doesn't exist so constraint is irrelevant
+										Get:     "input5",
+										Passed:  []string{"some-upstream-job"},
+										Version: &atc.VersionConfig{Pinned: atc.Version{"version": "v5"}},
+									},
+									{ // version exists but doesn't satisfy constraint
+										Get:     "input6",
+										Passed:  []string{"some-upstream-job"},
+										Version: &atc.VersionConfig{Pinned: atc.Version{"version": "v6"}},
+									},
+								},
+							},
+						},
+						Resources: atc.ResourceConfigs{
+							{Name: "input1", Type: "some-type", Source: atc.Source{"some": "source-1"}},
+							{Name: "input2", Type: "some-type", Source: atc.Source{"some": "source-2"}},
+							{Name: "input3", Type: "some-type", Source: atc.Source{"some": "source-3"}},
+							{Name: "input4", Type: "some-type", Source: atc.Source{"some": "source-4"}},
+							{Name: "input5", Type: "some-type", Source: atc.Source{"some": "source-5"}},
+							{Name: "input6", Type: "some-type", Source: atc.Source{"some": "source-6"}},
+						},
+					}
+
+					pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(2), false)
+					Expect(err).ToNot(HaveOccurred())
+
+					setupTx, err := dbConn.Begin()
+					Expect(err).ToNot(HaveOccurred())
+
+					brt := db.BaseResourceType{
+						Name: "some-type",
+					}
+
+					_, err = brt.FindOrCreate(setupTx, false)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(setupTx.Commit()).To(Succeed())
+
+					resource6, found, err := pipeline.Resource("input6")
+					Expect(found).To(BeTrue())
+					Expect(err).NotTo(HaveOccurred())
+
+					resourceConfig6, err := resource6.SetResourceConfig(atc.Source{"some": "source-6"}, atc.VersionedResourceTypes{})
+					Expect(err).NotTo(HaveOccurred())
+
+					err = resourceConfig6.SaveVersions([]atc.Version{{"version": "v6"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					job, found, err := pipeline.Job("some-job")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					resource1, found, err := pipeline.Resource("input1")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					resourceConfig1, err := resource1.SetResourceConfig(atc.Source{"some": "source-1"}, atc.VersionedResourceTypes{})
+					Expect(err).NotTo(HaveOccurred())
+
+					err = resourceConfig1.SaveVersions([]atc.Version{{"version": "v1"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					versions, _, found, err := resource1.Versions(db.Page{Limit: 1})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(versions).To(HaveLen(1))
+
+					err = job.SaveIndependentInputMapping(algorithm.InputMapping{
+						"input1": {VersionID: versions[0].ID, ResourceID: resource1.ID(), FirstOccurrence: true},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					expectedBuildPrep.Inputs = map[string]db.BuildPreparationStatus{
+						"input1": db.BuildPreparationStatusNotBlocking,
+						"input2": db.BuildPreparationStatusBlocking,
+						"input3": db.BuildPreparationStatusBlocking,
+						"input4": db.BuildPreparationStatusBlocking,
+						"input5": db.BuildPreparationStatusBlocking,
+						"input6": db.BuildPreparationStatusBlocking,
+					}
+					expectedBuildPrep.InputsSatisfied = db.BuildPreparationStatusBlocking
+					expectedBuildPrep.MissingInputReasons = db.MissingInputReasons{
+						"input2": db.NoVersionsAvailable,
+						"input3": db.NoVersionsSatisfiedPassedConstraints,
+						"input4": fmt.Sprintf(db.PinnedVersionUnavailable, `{"version":"v4"}`),
+						"input5": fmt.Sprintf(db.PinnedVersionUnavailable, `{"version":"v5"}`),
+						"input6": db.NoVersionsSatisfiedPassedConstraints,
+					}
+					expectedBuildPrep.FilteredInputs = map[string]int{
+						"input3": 0,
+						"input6": 1,
+					}
+				})
+
+				It("returns blocking inputs satisfied", func() {
+					buildPrep, found, err := build.Preparation()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(buildPrep).To(Equal(expectedBuildPrep))
+				})
+			})
+
+			Context("when a passed constraint filters out every version", func() {
+				BeforeEach(func() {
+					pipeline, _, err = team.SavePipeline("some-pipeline", atc.Config{
+						Jobs: atc.JobConfigs{
+							{
+								Name: "some-upstream-job",
+							},
+							{
+								Name: "some-job",
+								Plan: atc.PlanSequence{
+									{Get: "some-input", Passed: []string{"some-upstream-job"}},
+								},
+							},
+						},
+						Resources: atc.ResourceConfigs{
+							{Name: "some-input", Type: "some-type", Source: atc.Source{"some": "source"}},
+						},
+					}, db.ConfigVersion(2), false)
+					Expect(err).ToNot(HaveOccurred())
+
+					setupTx, err := dbConn.Begin()
+					Expect(err).ToNot(HaveOccurred())
+
+					brt := db.BaseResourceType{
+						Name: "some-type",
+					}
+
+					_, err = brt.FindOrCreate(setupTx, false)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(setupTx.Commit()).To(Succeed())
+
+					resource, found, err := pipeline.Resource("some-input")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					resourceConfig, err := resource.SetResourceConfig(atc.Source{"some": "source"}, atc.VersionedResourceTypes{})
+					Expect(err).NotTo(HaveOccurred())
+
+					err = resourceConfig.SaveVersions([]atc.Version{
+						{"version": "v1"},
+						{"version": "v2"},
+						{"version": "v3"},
+					})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("counts the versions which exist but do not satisfy the constraint", func() {
+					buildPrep, found, err := build.Preparation()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(buildPrep.MissingInputReasons).To(Equal(db.MissingInputReasons{
+						"some-input": db.NoVersionsSatisfiedPassedConstraints,
+					}))
+					Expect(buildPrep.FilteredInputs).To(Equal(map[string]int{
+						"some-input": 3,
+					}))
+				})
+			})
+
+			Context("when the pinned version chosen for an input has been removed", func() {
+				BeforeEach(func() {
+					pipeline, _, err = team.SavePipeline("some-pipeline", atc.Config{
+						Jobs: atc.JobConfigs{
+							{
+								Name: "some-job",
+								Plan: atc.PlanSequence{
+									{
+										Get:     "some-input",
+										Version: &atc.VersionConfig{Pinned: atc.Version{"version": "v1"}},
+									},
+								},
+							},
+						},
+						Resources: atc.ResourceConfigs{
+							{Name: "some-input", Type: "some-type", Source: atc.Source{"some": "source"}},
+						},
+					}, db.ConfigVersion(2), false)
+					Expect(err).ToNot(HaveOccurred())
+
+					setupTx, err := dbConn.Begin()
+					Expect(err).ToNot(HaveOccurred())
+
+					brt := db.BaseResourceType{
+						Name: "some-type",
+					}
+
+					_, err = brt.FindOrCreate(setupTx, false)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(setupTx.Commit()).To(Succeed())
+
+					resource, found, err := pipeline.Resource("some-input")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					resourceConfigScope, err := resource.SetResourceConfig(atc.Source{"some": "source"}, atc.VersionedResourceTypes{})
+					Expect(err).NotTo(HaveOccurred())
+
+					err = resourceConfigScope.SaveVersions([]atc.Version{{"version": "v1"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					rcv, found, err := resourceConfigScope.FindVersion(atc.Version{"version": "v1"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					err = job.SaveNextInputMapping(algorithm.InputMapping{
+						"some-input": {VersionID: rcv.ID(), ResourceID: resource.ID(), FirstOccurrence: true},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = dbConn.Exec("DELETE FROM resource_config_versions WHERE id = $1", rcv.ID())
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("reports that the pinned version is gone", func() {
+					buildPrep, found, err := build.Preparation()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(buildPrep.InputsSatisfied).To(Equal(db.BuildPreparationStatusBlocking))
+					Expect(buildPrep.Inputs).To(Equal(map[string]db.BuildPreparationStatus{
+						"some-input": db.BuildPreparationStatusBlocking,
+					}))
+					Expect(buildPrep.MissingInputReasons).To(Equal(db.MissingInputReasons{
+						"some-input": fmt.Sprintf(db.PinnedVersionGone, `{"version":"v1"}`),
+					}))
+				})
+			})
+		})
+
+		Describe("Schedule", func() {
+			var (
+				build db.Build
+				found bool
+				f     bool
+			)
+
+			BeforeEach(func() {
+				pipeline, _, err := team.SavePipeline("some-pipeline", atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name: "some-job",
+						},
+					},
+				}, db.ConfigVersion(1), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				job, found, err := pipeline.Job("some-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				build, err = job.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(build.IsScheduled()).To(BeFalse())
+			})
+
+			JustBeforeEach(func() {
+				found, err = build.Schedule()
+				Expect(err).ToNot(HaveOccurred())
+
+				f, err = build.Reload()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			Context("when build exists", func() {
+				It("sets the build to scheduled", func() {
+					Expect(f).To(BeTrue())
+					Expect(found).To(BeTrue())
+					Expect(build.IsScheduled()).To(BeTrue())
+				})
+
+				It("keeps the build pending until it is started", func() {
+					Expect(build.Status()).To(Equal(db.BuildStatusPending))
+
+					started, err := build.Start(atc.Plan{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(started).To(BeTrue())
+
+					found, err := build.Reload()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(build.IsScheduled()).To(BeTrue())
+					Expect(build.Status()).To(Equal(db.BuildStatusStarted))
+				})
+			})
 
-							stillExists, err := build.Reload()
-							Expect(stillExists).To(BeTrue())
-							Expect(err).NotTo(HaveOccurred())
+			Context("when the build does not exist", func() {
+				var found2 bool
+				BeforeEach(func() {
+					var err error
+					found2, err = build.Delete()
+					Expect(err).ToNot(HaveOccurred())
+				})
 
-							expectedBuildPrep.Inputs = map[string]db.BuildPreparationStatus{}
-						})
+				It("returns false", func() {
+					Expect(f).To(BeFalse())
+					Expect(found2).To(BeTrue())
+					Expect(found).To(BeFalse())
+				})
+			})
 
-						It("returns build preparation", func() {
-							buildPrep, found, err := build.Preparation()
-							Expect(err).NotTo(HaveOccurred())
-							Expect(found).To(BeTrue())
-							Expect(buildPrep).To(Equal(expectedBuildPrep))
-						})
-					})
+			Context("when the build is held", func() {
+				BeforeEach(func() {
+					err := build.Hold()
+					Expect(err).ToNot(HaveOccurred())
+				})
 
-					Context("when pipeline is paused", func() {
-						BeforeEach(func() {
-							err := pipeline.Pause()
-							Expect(err).NotTo(HaveOccurred())
+				It("does not schedule the build", func() {
+					Expect(f).To(BeTrue())
+					Expect(found).To(BeFalse())
+					Expect(build.IsHeld()).To(BeTrue())
+					Expect(build.IsScheduled()).To(BeFalse())
+				})
 
-							expectedBuildPrep.PausedPipeline = db.BuildPreparationStatusBlocking
-						})
+				Context("when the build is released", func() {
+					BeforeEach(func() {
+						err := build.Release()
+						Expect(err).ToNot(HaveOccurred())
+					})
 
-						It("returns build preparation with paused pipeline", func() {
-							buildPrep, found, err := build.Preparation()
-							Expect(err).NotTo(HaveOccurred())
-							Expect(found).To(BeTrue())
-							Expect(buildPrep).To(Equal(expectedBuildPrep))
-						})
+					It("schedules the build", func() {
+						Expect(found).To(BeTrue())
+						Expect(build.IsHeld()).To(BeFalse())
+						Expect(build.IsScheduled()).To(BeTrue())
 					})
+				})
+			})
 
-					Context("when job is paused", func() {
-						BeforeEach(func() {
-							err := job.Pause()
-							Expect(err).NotTo(HaveOccurred())
+			Context("when the build is no longer pending", func() {
+				BeforeEach(func() {
+					err := build.Finish(db.BuildStatusAborted)
+					Expect(err).ToNot(HaveOccurred())
+				})
 
-							expectedBuildPrep.PausedJob = db.BuildPreparationStatusBlocking
-						})
+				It("cannot be held", func() {
+					Expect(build.Hold()).To(Equal(db.ErrBuildNotPending))
+				})
+			})
+		})
+	})
 
-						It("returns build preparation with paused pipeline", func() {
-							buildPrep, found, err := build.Preparation()
-							Expect(err).NotTo(HaveOccurred())
-							Expect(found).To(BeTrue())
-							Expect(buildPrep).To(Equal(expectedBuildPrep))
-						})
-					})
+	Describe("ErrorUnresolvedInputs", func() {
+		var build db.Build
 
-					Context("when max running builds is reached", func() {
-						BeforeEach(func() {
-							err := job.SetMaxInFlightReached(true)
-							Expect(err).NotTo(HaveOccurred())
+		BeforeEach(func() {
+			pipeline, _, err := team.SavePipeline("some-pipeline", atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						Plan: atc.PlanSequence{
+							{
+								Get:      "some-input",
+								Resource: "some-resource",
+							},
+						},
+					},
+				},
+				Resources: atc.ResourceConfigs{
+					{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"some": "source"},
+					},
+				},
+			}, db.ConfigVersion(1), false)
+			Expect(err).ToNot(HaveOccurred())
 
-							expectedBuildPrep.MaxRunningBuilds = db.BuildPreparationStatusBlocking
-						})
+			job, found, err := pipeline.Job("some-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
 
-						It("returns build preparation with max in flight reached", func() {
-							buildPrep, found, err := build.Preparation()
-							Expect(err).NotTo(HaveOccurred())
-							Expect(found).To(BeTrue())
-							Expect(buildPrep).To(Equal(expectedBuildPrep))
-						})
-					})
+			build, err = job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+		})
 
-					Context("when max running builds is de-reached", func() {
-						BeforeEach(func() {
-							err := job.SetMaxInFlightReached(true)
-							Expect(err).NotTo(HaveOccurred())
+		It("errors the build with an event naming the unresolved inputs", func() {
+			err := build.ErrorUnresolvedInputs("gave up after 10 attempts")
+			Expect(err).ToNot(HaveOccurred())
 
-							err = job.SetMaxInFlightReached(false)
-							Expect(err).NotTo(HaveOccurred())
-						})
+			found, err := build.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Status()).To(Equal(db.BuildStatusErrored))
 
-						It("returns build preparation with max in flight not reached", func() {
-							buildPrep, found, err := build.Preparation()
-							Expect(err).NotTo(HaveOccurred())
-							Expect(found).To(BeTrue())
-							Expect(buildPrep).To(Equal(expectedBuildPrep))
-						})
-					})
-				})
+			events, err := build.Events(0)
+			Expect(err).ToNot(HaveOccurred())
 
-				Context("when no resource check finished after build created", func() {
-					BeforeEach(func() {
-						err = job.SaveNextInputMapping(algorithm.InputMapping{
-							"some-input": {VersionID: rcv.ID(), ResourceID: resource.ID(), FirstOccurrence: true},
-						})
-						Expect(err).NotTo(HaveOccurred())
+			defer db.Close(events)
 
-						expectedBuildPrep.Inputs = map[string]db.BuildPreparationStatus{
-							"some-input": db.BuildPreparationStatusBlocking,
-						}
-						expectedBuildPrep.InputsSatisfied = db.BuildPreparationStatusBlocking
-						expectedBuildPrep.MissingInputReasons = db.MissingInputReasons{
-							"some-input": db.NoResourceCheckFinished,
-						}
-					})
+			ev, err := events.Next()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ev.Event).To(Equal(event.EventTypeError))
 
-					It("returns build preparation with missing input reason", func() {
-						buildPrep, found, err := build.Preparation()
-						Expect(err).NotTo(HaveOccurred())
-						Expect(found).To(BeTrue())
-						Expect(buildPrep).To(Equal(expectedBuildPrep))
-					})
-				})
+			var errEvent event.Error
+			err = json.Unmarshal(*ev.Data, &errEvent)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(errEvent.Message).To(ContainSubstring("some-input (" + db.NoVersionsAvailable + ")"))
+			Expect(errEvent.Message).To(ContainSubstring("gave up after 10 attempts"))
+		})
+
+		It("does not error builds which are no longer pending", func() {
+			started, err := build.Start(atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			err = build.ErrorUnresolvedInputs("too late")
+			Expect(err).To(Equal(db.ErrBuildNotPending))
+		})
+	})
+
+	Describe("PreparationCached", func() {
+		var (
+			build    db.Build
+			job      db.Job
+			resource db.Resource
+			rcv1     db.ResourceConfigVersion
+			rcv2     db.ResourceConfigVersion
+		)
+
+		BeforeEach(func() {
+			setupTx, err := dbConn.Begin()
+			Expect(err).ToNot(HaveOccurred())
+
+			brt := db.BaseResourceType{
+				Name: "some-type",
+			}
+
+			_, err = brt.FindOrCreate(setupTx, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(setupTx.Commit()).To(Succeed())
+
+			pipeline, _, err := team.SavePipeline("some-pipeline", atc.Config{
+				Resources: atc.ResourceConfigs{
+					{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"some": "source"},
+					},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+					},
+				},
+			}, db.ConfigVersion(1), false)
+			Expect(err).ToNot(HaveOccurred())
+
+			var found bool
+			job, found, err = pipeline.Job("some-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			resource, found, err = pipeline.Resource("some-resource")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			resourceConfigScope, err := resource.SetResourceConfig(atc.Source{"some": "source"}, atc.VersionedResourceTypes{})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = resourceConfigScope.SaveVersions([]atc.Version{{"version": "v1"}, {"version": "v2"}})
+			Expect(err).NotTo(HaveOccurred())
+
+			rcv1, found, err = resourceConfigScope.FindVersion(atc.Version{"version": "v1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			rcv2, found, err = resourceConfigScope.FindVersion(atc.Version{"version": "v2"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = job.EnsurePendingBuildExists()
+			Expect(err).NotTo(HaveOccurred())
+
+			pendingBuilds, err := job.GetPendingBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pendingBuilds).To(HaveLen(1))
+
+			build = pendingBuilds[0]
+
+			err = job.SaveNextInputMapping(algorithm.InputMapping{
+				"some-input": {VersionID: rcv1.ID(), ResourceID: resource.ID(), FirstOccurrence: true},
 			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("computes the preparation the first time", func() {
+			prep, cached, err := build.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cached).To(BeFalse())
+
+			expectedPrep, found, err := build.Preparation()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(prep).To(Equal(expectedPrep))
+		})
+
+		It("returns the cached preparation when the mapping is unchanged", func() {
+			firstPrep, _, err := build.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
+
+			prep, cached, err := build.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cached).To(BeTrue())
+			Expect(prep).To(Equal(firstPrep))
+
+			By("sharing the cache across build objects")
+			reloadedBuild, found, err := job.Build(build.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			prep, cached, err = reloadedBuild.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cached).To(BeTrue())
+			Expect(prep).To(Equal(firstPrep))
+		})
+
+		It("recomputes the preparation after the mapping is updated", func() {
+			_, _, err := build.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = job.SaveNextInputMapping(algorithm.InputMapping{
+				"some-input": {VersionID: rcv2.ID(), ResourceID: resource.ID(), FirstOccurrence: true},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, cached, err := build.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cached).To(BeFalse())
+
+			_, cached, err = build.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cached).To(BeTrue())
+		})
+
+		It("recomputes the preparation after the job is paused", func() {
+			_, _, err := build.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = job.Pause()
+			Expect(err).NotTo(HaveOccurred())
 
-			Context("when inputs are not satisfied", func() {
-				BeforeEach(func() {
-					pipeline, _, err = team.SavePipeline("some-pipeline", atc.Config{
-						Resources: atc.ResourceConfigs{
-							{
-								Name: "some-resource",
-								Type: "some-type",
-								Source: atc.Source{
-									"source-config": "some-value",
-								},
-							},
-						},
-						Jobs: atc.JobConfigs{
-							{
-								Name: "some-job",
-							},
-						},
-					}, db.ConfigVersion(2), false)
-					Expect(err).ToNot(HaveOccurred())
-					expectedBuildPrep.InputsSatisfied = db.BuildPreparationStatusBlocking
-				})
+			prep, cached, err := build.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cached).To(BeFalse())
+			Expect(prep.PausedJob).To(Equal(db.BuildPreparationStatusBlocking))
+		})
 
-				It("returns blocking inputs satisfied", func() {
-					buildPrep, found, err := build.Preparation()
-					Expect(err).NotTo(HaveOccurred())
-					Expect(found).To(BeTrue())
-					Expect(buildPrep).To(Equal(expectedBuildPrep))
-				})
+		It("recomputes the preparation after the independent mapping is updated", func() {
+			_, _, err := build.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = job.SaveIndependentInputMapping(algorithm.InputMapping{
+				"some-input": {VersionID: rcv2.ID(), ResourceID: resource.ID(), FirstOccurrence: true},
 			})
+			Expect(err).NotTo(HaveOccurred())
 
-			Context("when some inputs are not satisfied", func() {
-				BeforeEach(func() {
-					pipelineConfig := atc.Config{
-						Jobs: atc.JobConfigs{
-							{
-								Name: "some-job",
-								Plan: atc.PlanSequence{
-									{
-										Get:     "input1",
-										Version: &atc.VersionConfig{Pinned: atc.Version{"version": "v1"}},
-									},
-									{Get: "input2"},
-									{Get: "input3", Passed: []string{"some-upstream-job"}},
-									{ // version doesn't exist
-										Get:     "input4",
-										Version: &atc.VersionConfig{Pinned: atc.Version{"version": "v4"}},
-									},
-									{ // version doesn't exist so constraint is irrelevant
-										Get:     "input5",
-										Passed:  []string{"some-upstream-job"},
-										Version: &atc.VersionConfig{Pinned: atc.Version{"version": "v5"}},
-									},
-									{ // version exists but doesn't satisfy constraint
-										Get:     "input6",
-										Passed:  []string{"some-upstream-job"},
-										Version: &atc.VersionConfig{Pinned: atc.Version{"version": "v6"}},
-									},
-								},
-							},
-						},
-						Resources: atc.ResourceConfigs{
-							{Name: "input1", Type: "some-type", Source: atc.Source{"some": "source-1"}},
-							{Name: "input2", Type: "some-type", Source: atc.Source{"some": "source-2"}},
-							{Name: "input3", Type: "some-type", Source: atc.Source{"some": "source-3"}},
-							{Name: "input4", Type: "some-type", Source: atc.Source{"some": "source-4"}},
-							{Name: "input5", Type: "some-type", Source: atc.Source{"some": "source-5"}},
-							{Name: "input6", Type: "some-type", Source: atc.Source{"some": "source-6"}},
-						},
-					}
+			_, cached, err := build.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cached).To(BeFalse())
+		})
 
-					pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(2), false)
-					Expect(err).ToNot(HaveOccurred())
+		It("recomputes the preparation after the job config changes", func() {
+			_, _, err := build.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
 
-					setupTx, err := dbConn.Begin()
-					Expect(err).ToNot(HaveOccurred())
+			_, err = dbConn.Exec(`UPDATE jobs SET config = $1 WHERE id = $2`, `{"name":"some-job","serial":true}`, job.ID())
+			Expect(err).NotTo(HaveOccurred())
 
-					brt := db.BaseResourceType{
-						Name: "some-type",
-					}
+			_, cached, err := build.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cached).To(BeFalse())
+		})
 
-					_, err = brt.FindOrCreate(setupTx, false)
-					Expect(err).NotTo(HaveOccurred())
-					Expect(setupTx.Commit()).To(Succeed())
+		It("recomputes the preparation after a version is disabled", func() {
+			_, _, err := build.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
 
-					resource6, found, err := pipeline.Resource("input6")
-					Expect(found).To(BeTrue())
-					Expect(err).NotTo(HaveOccurred())
+			err = resource.DisableVersion(rcv2.ID())
+			Expect(err).NotTo(HaveOccurred())
 
-					resourceConfig6, err := resource6.SetResourceConfig(atc.Source{"some": "source-6"}, atc.VersionedResourceTypes{})
-					Expect(err).NotTo(HaveOccurred())
+			_, cached, err := build.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cached).To(BeFalse())
+		})
 
-					err = resourceConfig6.SaveVersions([]atc.Version{{"version": "v6"}})
-					Expect(err).NotTo(HaveOccurred())
+		It("recomputes the preparation after a new version is saved", func() {
+			_, _, err := build.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
 
-					job, found, err := pipeline.Job("some-job")
-					Expect(err).NotTo(HaveOccurred())
-					Expect(found).To(BeTrue())
+			resourceConfigScope, err := resource.SetResourceConfig(atc.Source{"some": "source"}, atc.VersionedResourceTypes{})
+			Expect(err).NotTo(HaveOccurred())
 
-					resource1, found, err := pipeline.Resource("input1")
-					Expect(err).NotTo(HaveOccurred())
-					Expect(found).To(BeTrue())
+			err = resourceConfigScope.SaveVersions([]atc.Version{{"version": "v3"}})
+			Expect(err).NotTo(HaveOccurred())
 
-					resourceConfig1, err := resource1.SetResourceConfig(atc.Source{"some": "source-1"}, atc.VersionedResourceTypes{})
-					Expect(err).NotTo(HaveOccurred())
+			_, cached, err := build.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cached).To(BeFalse())
+		})
 
-					err = resourceConfig1.SaveVersions([]atc.Version{{"version": "v1"}})
-					Expect(err).NotTo(HaveOccurred())
+		It("recomputes the preparation after a pinned input's version is removed", func() {
+			_, err := dbConn.Exec(`UPDATE jobs SET config = $1 WHERE id = $2`, `{"name":"some-job","plan":[{"get":"some-input","resource":"some-resource","version":{"version":"v1"}}]}`, job.ID())
+			Expect(err).NotTo(HaveOccurred())
 
-					versions, _, found, err := resource1.Versions(db.Page{Limit: 1})
-					Expect(err).NotTo(HaveOccurred())
-					Expect(found).To(BeTrue())
-					Expect(versions).To(HaveLen(1))
+			_, _, err = build.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
 
-					err = job.SaveIndependentInputMapping(algorithm.InputMapping{
-						"input1": {VersionID: versions[0].ID, ResourceID: resource1.ID(), FirstOccurrence: true},
-					})
-					Expect(err).NotTo(HaveOccurred())
+			_, err = dbConn.Exec("DELETE FROM resource_config_versions WHERE id = $1", rcv1.ID())
+			Expect(err).NotTo(HaveOccurred())
 
-					expectedBuildPrep.Inputs = map[string]db.BuildPreparationStatus{
-						"input1": db.BuildPreparationStatusNotBlocking,
-						"input2": db.BuildPreparationStatusBlocking,
-						"input3": db.BuildPreparationStatusBlocking,
-						"input4": db.BuildPreparationStatusBlocking,
-						"input5": db.BuildPreparationStatusBlocking,
-						"input6": db.BuildPreparationStatusBlocking,
-					}
-					expectedBuildPrep.InputsSatisfied = db.BuildPreparationStatusBlocking
-					expectedBuildPrep.MissingInputReasons = db.MissingInputReasons{
-						"input2": db.NoVersionsAvailable,
-						"input3": db.NoVersionsSatisfiedPassedConstraints,
-						"input4": fmt.Sprintf(db.PinnedVersionUnavailable, `{"version":"v4"}`),
-						"input5": fmt.Sprintf(db.PinnedVersionUnavailable, `{"version":"v5"}`),
-						"input6": db.NoVersionsSatisfiedPassedConstraints,
-					}
-				})
+			prep, cached, err := build.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cached).To(BeFalse())
+			Expect(prep.MissingInputReasons).To(Equal(db.MissingInputReasons{
+				"some-input": fmt.Sprintf(db.PinnedVersionGone, `{"version":"v1"}`),
+			}))
+		})
 
-				It("returns blocking inputs satisfied", func() {
-					buildPrep, found, err := build.Preparation()
-					Expect(err).NotTo(HaveOccurred())
-					Expect(found).To(BeTrue())
-					Expect(buildPrep).To(Equal(expectedBuildPrep))
-				})
-			})
+		It("recomputes whether the build awaits a manual trigger after an input's trigger changes", func() {
+			_, err := dbConn.Exec(`UPDATE jobs SET config = $1 WHERE id = $2`, `{"name":"some-job","plan":[{"get":"some-input","resource":"some-resource"}]}`, job.ID())
+			Expect(err).NotTo(HaveOccurred())
+
+			prep, _, err := build.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(prep.AwaitingManualTrigger).To(Equal(db.BuildPreparationStatusBlocking))
+
+			_, err = dbConn.Exec(`UPDATE jobs SET config = $1 WHERE id = $2`, `{"name":"some-job","plan":[{"get":"some-input","resource":"some-resource","trigger":true}]}`, job.ID())
+			Expect(err).NotTo(HaveOccurred())
+
+			prep, cached, err := build.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cached).To(BeFalse())
+			Expect(prep.AwaitingManualTrigger).To(Equal(db.BuildPreparationStatusNotBlocking))
 		})
 
-		Describe("Schedule", func() {
-			var (
-				build db.Build
-				found bool
-				f     bool
-			)
+		It("never caches the preparation of a manually triggered build", func() {
+			manualBuild, err := job.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
 
-			BeforeEach(func() {
-				pipeline, _, err := team.SavePipeline("some-pipeline", atc.Config{
-					Jobs: atc.JobConfigs{
-						{
-							Name: "some-job",
-						},
-					},
-				}, db.ConfigVersion(1), false)
-				Expect(err).ToNot(HaveOccurred())
+			prep, cached, err := manualBuild.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cached).To(BeFalse())
+			Expect(prep.AwaitingManualTrigger).To(Equal(db.BuildPreparationStatusNotBlocking))
 
-				job, found, err := pipeline.Job("some-job")
-				Expect(err).ToNot(HaveOccurred())
-				Expect(found).To(BeTrue())
+			_, cached, err = manualBuild.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cached).To(BeFalse())
+		})
 
-				build, err = job.CreateBuild()
-				Expect(err).ToNot(HaveOccurred())
-				Expect(build.IsScheduled()).To(BeFalse())
-			})
+		It("never caches the preparation of a one-off build", func() {
+			oneOffBuild, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
 
-			JustBeforeEach(func() {
-				found, err = build.Schedule()
-				Expect(err).ToNot(HaveOccurred())
+			_, cached, err := oneOffBuild.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cached).To(BeFalse())
 
-				f, err = build.Reload()
-				Expect(err).ToNot(HaveOccurred())
-			})
+			_, cached, err = oneOffBuild.PreparationCached()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cached).To(BeFalse())
+		})
+	})
 
-			Context("when build exists", func() {
-				It("sets the build to scheduled", func() {
-					Expect(f).To(BeTrue())
-					Expect(found).To(BeTrue())
-					Expect(build.IsScheduled()).To(BeTrue())
-				})
-			})
+	Describe("RegisterResourceCacheUse", func() {
+		It("returns the registered resource caches", func() {
+			build, err := defaultJob.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
 
-			Context("when the build does not exist", func() {
-				var found2 bool
-				BeforeEach(func() {
-					var err error
-					found2, err = build.Delete()
-					Expect(err).ToNot(HaveOccurred())
-				})
+			resourceCache, err := resourceCacheFactory.FindOrCreateResourceCache(
+				db.ForBuild(build.ID()),
+				"some-base-resource-type",
+				atc.Version{"some": "version"},
+				atc.Source{"some": "source"},
+				atc.Params{},
+				atc.VersionedResourceTypes{},
+			)
+			Expect(err).ToNot(HaveOccurred())
 
-				It("returns false", func() {
-					Expect(f).To(BeFalse())
-					Expect(found2).To(BeTrue())
-					Expect(found).To(BeFalse())
-				})
-			})
+			err = build.RegisterResourceCacheUse(resourceCache)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.RegisterResourceCacheUse(resourceCache)
+			Expect(err).ToNot(HaveOccurred())
+
+			uses, err := build.ResourceCacheUses()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(uses).To(HaveLen(1))
+			Expect(uses[0].ID()).To(Equal(resourceCache.ID()))
 		})
 	})
 
@@ -1268,11 +4371,89 @@ var _ = Describe("Build", func() {
 			Expect(actualBuildInput[0].Version).To(Equal(atc.Version{"some": "weird-version"}))
 			Expect(actualBuildInput[1].Name).To(Equal("some-weird-input"))
 			Expect(actualBuildInput[1].Version).To(Equal(atc.Version{"weird": "version"}))
+
+			inputVersions, err := build.InputVersions()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inputVersions).To(Equal(map[string]atc.Version{
+				"some-other-input": atc.Version{"some": "weird-version"},
+				"some-weird-input": atc.Version{"weird": "version"},
+			}))
+		})
+
+		It("records when the inputs were determined", func() {
+			determinedAt, determined := build.InputsDeterminedAt()
+			Expect(determined).To(BeTrue())
+			Expect(determinedAt).ToNot(BeZero())
+
+			found, err := build.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			reloadedAt, determined := build.InputsDeterminedAt()
+			Expect(determined).To(BeTrue())
+			Expect(reloadedAt).To(BeTemporally("~", determinedAt, time.Second))
+		})
+
+		It("does not record inputs as determined for builds without inputs", func() {
+			job, found, err := pipeline.Job("some-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			otherBuild, err := job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, determined := otherBuild.InputsDeterminedAt()
+			Expect(determined).To(BeFalse())
 		})
 	})
 
 })
 
+// oldEvent is an event whose saved payloads are migrated to a newer version
+// when they are read.
+type oldEvent struct {
+	Hello string `json:"hello"`
+}
+
+func (oldEvent) EventType() atc.EventType  { return "old-event" }
+func (oldEvent) Version() atc.EventVersion { return "1.0" }
+
+// matchEnvelope matches the envelope of the given event, ignoring when it was
+// saved.
+func matchEnvelope(ev atc.Event) types.GomegaMatcher {
+	return WithTransform(func(e event.Envelope) event.Envelope {
+		e.InsertedAt = nil
+		return e
+	}, Equal(envelope(ev)))
+}
+
+func withoutInsertTimes(envelopes []event.Envelope) []event.Envelope {
+	stripped := []event.Envelope{}
+	for _, e := range envelopes {
+		e.InsertedAt = nil
+		stripped = append(stripped, e)
+	}
+
+	return stripped
+}
+
+// buildSummary returns the summary event saved after the final status event
+// of the given finished build.
+func buildSummary(build db.Build) event.BuildSummary {
+	var duration int64
+	if !build.StartTime().IsZero() {
+		duration = int64(build.EndTime().Sub(build.StartTime()) / time.Second)
+	}
+
+	return event.BuildSummary{
+		Status:   atc.BuildStatus(build.Status()),
+		Time:     build.EndTime().Unix(),
+		Duration: duration,
+		Inputs:   build.InputCount(),
+		Outputs:  build.OutputCount(),
+	}
+}
+
 func envelope(ev atc.Event) event.Envelope {
 	payload, err := json.Marshal(ev)
 	Expect(err).ToNot(HaveOccurred())
@@ -3,6 +3,7 @@ package dbfakes
 
 import (
 	"encoding/json"
+	"io"
 	"sync"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/concourse/concourse/atc/event"
 )
 
 type FakeBuild struct {
@@ -25,6 +27,16 @@ type FakeBuild struct {
 		result1 db.Notifier
 		result2 error
 	}
+	AbortReasonStub        func() string
+	abortReasonMutex       sync.RWMutex
+	abortReasonArgsForCall []struct {
+	}
+	abortReasonReturns struct {
+		result1 string
+	}
+	abortReasonReturnsOnCall map[int]struct {
+		result1 string
+	}
 	AcquireTrackingLockStub        func(lager.Logger, time.Duration) (lock.Lock, bool, error)
 	acquireTrackingLockMutex       sync.RWMutex
 	acquireTrackingLockArgsForCall []struct {
@@ -54,6 +66,21 @@ type FakeBuild struct {
 		result1 db.WorkerArtifact
 		result2 error
 	}
+	ArtifactByNameStub        func(string) (db.WorkerArtifact, bool, error)
+	artifactByNameMutex       sync.RWMutex
+	artifactByNameArgsForCall []struct {
+		arg1 string
+	}
+	artifactByNameReturns struct {
+		result1 db.WorkerArtifact
+		result2 bool
+		result3 error
+	}
+	artifactByNameReturnsOnCall map[int]struct {
+		result1 db.WorkerArtifact
+		result2 bool
+		result3 error
+	}
 	ArtifactsStub        func() ([]db.WorkerArtifact, error)
 	artifactsMutex       sync.RWMutex
 	artifactsArgsForCall []struct {
@@ -66,6 +93,18 @@ type FakeBuild struct {
 		result1 []db.WorkerArtifact
 		result2 error
 	}
+	ContainerHandlesStub        func() ([]string, error)
+	containerHandlesMutex       sync.RWMutex
+	containerHandlesArgsForCall []struct {
+	}
+	containerHandlesReturns struct {
+		result1 []string
+		result2 error
+	}
+	containerHandlesReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	CreateTimeStub        func() time.Time
 	createTimeMutex       sync.RWMutex
 	createTimeArgsForCall []struct {
@@ -88,6 +127,18 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	DownstreamJobsStub        func() ([]db.Job, error)
+	downstreamJobsMutex       sync.RWMutex
+	downstreamJobsArgsForCall []struct {
+	}
+	downstreamJobsReturns struct {
+		result1 []db.Job
+		result2 error
+	}
+	downstreamJobsReturnsOnCall map[int]struct {
+		result1 []db.Job
+		result2 error
+	}
 	EndTimeStub        func() time.Time
 	endTimeMutex       sync.RWMutex
 	endTimeArgsForCall []struct {
@@ -98,6 +149,42 @@ type FakeBuild struct {
 	endTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	ErrorUnresolvedInputsStub        func(string) error
+	errorUnresolvedInputsMutex       sync.RWMutex
+	errorUnresolvedInputsArgsForCall []struct {
+		arg1 string
+	}
+	errorUnresolvedInputsReturns struct {
+		result1 error
+	}
+	errorUnresolvedInputsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EventCountStub        func() (int, error)
+	eventCountMutex       sync.RWMutex
+	eventCountArgsForCall []struct {
+	}
+	eventCountReturns struct {
+		result1 int
+		result2 error
+	}
+	eventCountReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	EventOffsetAtFractionStub        func(float64) (uint, error)
+	eventOffsetAtFractionMutex       sync.RWMutex
+	eventOffsetAtFractionArgsForCall []struct {
+		arg1 float64
+	}
+	eventOffsetAtFractionReturns struct {
+		result1 uint
+		result2 error
+	}
+	eventOffsetAtFractionReturnsOnCall map[int]struct {
+		result1 uint
+		result2 error
+	}
 	EventsStub        func(uint) (db.EventSource, error)
 	eventsMutex       sync.RWMutex
 	eventsArgsForCall []struct {
@@ -111,6 +198,59 @@ type FakeBuild struct {
 		result1 db.EventSource
 		result2 error
 	}
+	EventsBetweenStub        func(time.Time, time.Time) (db.EventSource, error)
+	eventsBetweenMutex       sync.RWMutex
+	eventsBetweenArgsForCall []struct {
+		arg1 time.Time
+		arg2 time.Time
+	}
+	eventsBetweenReturns struct {
+		result1 db.EventSource
+		result2 error
+	}
+	eventsBetweenReturnsOnCall map[int]struct {
+		result1 db.EventSource
+		result2 error
+	}
+	EventsCompressedStub        func(uint) (io.ReadCloser, error)
+	eventsCompressedMutex       sync.RWMutex
+	eventsCompressedArgsForCall []struct {
+		arg1 uint
+	}
+	eventsCompressedReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	eventsCompressedReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	EventsFromIDStub        func(int) (db.EventSource, error)
+	eventsFromIDMutex       sync.RWMutex
+	eventsFromIDArgsForCall []struct {
+		arg1 int
+	}
+	eventsFromIDReturns struct {
+		result1 db.EventSource
+		result2 error
+	}
+	eventsFromIDReturnsOnCall map[int]struct {
+		result1 db.EventSource
+		result2 error
+	}
+	EventsFromTokenStub        func(string) (db.EventSource, error)
+	eventsFromTokenMutex       sync.RWMutex
+	eventsFromTokenArgsForCall []struct {
+		arg1 string
+	}
+	eventsFromTokenReturns struct {
+		result1 db.EventSource
+		result2 error
+	}
+	eventsFromTokenReturnsOnCall map[int]struct {
+		result1 db.EventSource
+		result2 error
+	}
 	FinishStub        func(db.BuildStatus) error
 	finishMutex       sync.RWMutex
 	finishArgsForCall []struct {
@@ -122,6 +262,30 @@ type FakeBuild struct {
 	finishReturnsOnCall map[int]struct {
 		result1 error
 	}
+	FinishWithEventsStub        func(db.BuildStatus, []atc.Event) error
+	finishWithEventsMutex       sync.RWMutex
+	finishWithEventsArgsForCall []struct {
+		arg1 db.BuildStatus
+		arg2 []atc.Event
+	}
+	finishWithEventsReturns struct {
+		result1 error
+	}
+	finishWithEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	FinishWithOptionsStub        func(db.BuildStatus, db.FinishOptions) error
+	finishWithOptionsMutex       sync.RWMutex
+	finishWithOptionsArgsForCall []struct {
+		arg1 db.BuildStatus
+		arg2 db.FinishOptions
+	}
+	finishWithOptionsReturns struct {
+		result1 error
+	}
+	finishWithOptionsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	HasPlanStub        func() bool
 	hasPlanMutex       sync.RWMutex
 	hasPlanArgsForCall []struct {
@@ -132,6 +296,26 @@ type FakeBuild struct {
 	hasPlanReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	HasPrivatePlanStub        func() bool
+	hasPrivatePlanMutex       sync.RWMutex
+	hasPrivatePlanArgsForCall []struct {
+	}
+	hasPrivatePlanReturns struct {
+		result1 bool
+	}
+	hasPrivatePlanReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	HoldStub        func() error
+	holdMutex       sync.RWMutex
+	holdArgsForCall []struct {
+	}
+	holdReturns struct {
+		result1 error
+	}
+	holdReturnsOnCall map[int]struct {
+		result1 error
+	}
 	IDStub        func() int
 	iDMutex       sync.RWMutex
 	iDArgsForCall []struct {
@@ -142,6 +326,40 @@ type FakeBuild struct {
 	iDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	InputCountStub        func() int
+	inputCountMutex       sync.RWMutex
+	inputCountArgsForCall []struct {
+	}
+	inputCountReturns struct {
+		result1 int
+	}
+	inputCountReturnsOnCall map[int]struct {
+		result1 int
+	}
+	InputVersionsStub        func() (map[string]atc.Version, error)
+	inputVersionsMutex       sync.RWMutex
+	inputVersionsArgsForCall []struct {
+	}
+	inputVersionsReturns struct {
+		result1 map[string]atc.Version
+		result2 error
+	}
+	inputVersionsReturnsOnCall map[int]struct {
+		result1 map[string]atc.Version
+		result2 error
+	}
+	InputsDeterminedAtStub        func() (time.Time, bool)
+	inputsDeterminedAtMutex       sync.RWMutex
+	inputsDeterminedAtArgsForCall []struct {
+	}
+	inputsDeterminedAtReturns struct {
+		result1 time.Time
+		result2 bool
+	}
+	inputsDeterminedAtReturnsOnCall map[int]struct {
+		result1 time.Time
+		result2 bool
+	}
 	InterceptibleStub        func() (bool, error)
 	interceptibleMutex       sync.RWMutex
 	interceptibleArgsForCall []struct {
@@ -184,6 +402,26 @@ type FakeBuild struct {
 	isDrainedReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	IsHeldStub        func() bool
+	isHeldMutex       sync.RWMutex
+	isHeldArgsForCall []struct {
+	}
+	isHeldReturns struct {
+		result1 bool
+	}
+	isHeldReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	IsKeptStub        func() bool
+	isKeptMutex       sync.RWMutex
+	isKeptArgsForCall []struct {
+	}
+	isKeptReturns struct {
+		result1 bool
+	}
+	isKeptReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	IsManuallyTriggeredStub        func() bool
 	isManuallyTriggeredMutex       sync.RWMutex
 	isManuallyTriggeredArgsForCall []struct {
@@ -214,6 +452,20 @@ type FakeBuild struct {
 	isScheduledReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	JobStub        func() (db.Job, bool, error)
+	jobMutex       sync.RWMutex
+	jobArgsForCall []struct {
+	}
+	jobReturns struct {
+		result1 db.Job
+		result2 bool
+		result3 error
+	}
+	jobReturnsOnCall map[int]struct {
+		result1 db.Job
+		result2 bool
+		result3 error
+	}
 	JobIDStub        func() int
 	jobIDMutex       sync.RWMutex
 	jobIDArgsForCall []struct {
@@ -234,6 +486,20 @@ type FakeBuild struct {
 	jobNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	LatestStatusEventStub        func() (event.Envelope, bool, error)
+	latestStatusEventMutex       sync.RWMutex
+	latestStatusEventArgsForCall []struct {
+	}
+	latestStatusEventReturns struct {
+		result1 event.Envelope
+		result2 bool
+		result3 error
+	}
+	latestStatusEventReturnsOnCall map[int]struct {
+		result1 event.Envelope
+		result2 bool
+		result3 error
+	}
 	MarkAsAbortedStub        func() error
 	markAsAbortedMutex       sync.RWMutex
 	markAsAbortedArgsForCall []struct {
@@ -244,6 +510,28 @@ type FakeBuild struct {
 	markAsAbortedReturnsOnCall map[int]struct {
 		result1 error
 	}
+	MarkAsAbortedWithReasonStub        func(string) error
+	markAsAbortedWithReasonMutex       sync.RWMutex
+	markAsAbortedWithReasonArgsForCall []struct {
+		arg1 string
+	}
+	markAsAbortedWithReasonReturns struct {
+		result1 error
+	}
+	markAsAbortedWithReasonReturnsOnCall map[int]struct {
+		result1 error
+	}
+	MoveToTeamStub        func(db.Team) error
+	moveToTeamMutex       sync.RWMutex
+	moveToTeamArgsForCall []struct {
+		arg1 db.Team
+	}
+	moveToTeamReturns struct {
+		result1 error
+	}
+	moveToTeamReturnsOnCall map[int]struct {
+		result1 error
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -254,6 +542,51 @@ type FakeBuild struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	NotifyOnCompletionStub        func() (db.Notifier, error)
+	notifyOnCompletionMutex       sync.RWMutex
+	notifyOnCompletionArgsForCall []struct {
+	}
+	notifyOnCompletionReturns struct {
+		result1 db.Notifier
+		result2 error
+	}
+	notifyOnCompletionReturnsOnCall map[int]struct {
+		result1 db.Notifier
+		result2 error
+	}
+	OriginStub        func() db.BuildOrigin
+	originMutex       sync.RWMutex
+	originArgsForCall []struct {
+	}
+	originReturns struct {
+		result1 db.BuildOrigin
+	}
+	originReturnsOnCall map[int]struct {
+		result1 db.BuildOrigin
+	}
+	OutputCountStub        func() int
+	outputCountMutex       sync.RWMutex
+	outputCountArgsForCall []struct {
+	}
+	outputCountReturns struct {
+		result1 int
+	}
+	outputCountReturnsOnCall map[int]struct {
+		result1 int
+	}
+	OutputsSinceStub        func(int) ([]db.BuildOutput, error)
+	outputsSinceMutex       sync.RWMutex
+	outputsSinceArgsForCall []struct {
+		arg1 int
+	}
+	outputsSinceReturns struct {
+		result1 []db.BuildOutput
+		result2 error
+	}
+	outputsSinceReturnsOnCall map[int]struct {
+		result1 []db.BuildOutput
+		result2 error
+	}
 	PipelineStub        func() (db.Pipeline, bool, error)
 	pipelineMutex       sync.RWMutex
 	pipelineArgsForCall []struct {
@@ -302,6 +635,20 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	PreparationCachedStub        func() (db.BuildPreparation, bool, error)
+	preparationCachedMutex       sync.RWMutex
+	preparationCachedArgsForCall []struct {
+	}
+	preparationCachedReturns struct {
+		result1 db.BuildPreparation
+		result2 bool
+		result3 error
+	}
+	preparationCachedReturnsOnCall map[int]struct {
+		result1 db.BuildPreparation
+		result2 bool
+		result3 error
+	}
 	PrivatePlanStub        func() atc.Plan
 	privatePlanMutex       sync.RWMutex
 	privatePlanArgsForCall []struct {
@@ -312,6 +659,20 @@ type FakeBuild struct {
 	privatePlanReturnsOnCall map[int]struct {
 		result1 atc.Plan
 	}
+	PrivatePlanForResumeStub        func() (atc.Plan, bool, error)
+	privatePlanForResumeMutex       sync.RWMutex
+	privatePlanForResumeArgsForCall []struct {
+	}
+	privatePlanForResumeReturns struct {
+		result1 atc.Plan
+		result2 bool
+		result3 error
+	}
+	privatePlanForResumeReturnsOnCall map[int]struct {
+		result1 atc.Plan
+		result2 bool
+		result3 error
+	}
 	PublicPlanStub        func() *json.RawMessage
 	publicPlanMutex       sync.RWMutex
 	publicPlanArgsForCall []struct {
@@ -332,6 +693,52 @@ type FakeBuild struct {
 	reapTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	ReapableStub        func(db.ReapPolicy) bool
+	reapableMutex       sync.RWMutex
+	reapableArgsForCall []struct {
+		arg1 db.ReapPolicy
+	}
+	reapableReturns struct {
+		result1 bool
+	}
+	reapableReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	RedactedEventsStub        func(uint, db.SecretRedactor) (db.EventSource, error)
+	redactedEventsMutex       sync.RWMutex
+	redactedEventsArgsForCall []struct {
+		arg1 uint
+		arg2 db.SecretRedactor
+	}
+	redactedEventsReturns struct {
+		result1 db.EventSource
+		result2 error
+	}
+	redactedEventsReturnsOnCall map[int]struct {
+		result1 db.EventSource
+		result2 error
+	}
+	RegisterResourceCacheUseStub        func(db.UsedResourceCache) error
+	registerResourceCacheUseMutex       sync.RWMutex
+	registerResourceCacheUseArgsForCall []struct {
+		arg1 db.UsedResourceCache
+	}
+	registerResourceCacheUseReturns struct {
+		result1 error
+	}
+	registerResourceCacheUseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReleaseStub        func() error
+	releaseMutex       sync.RWMutex
+	releaseArgsForCall []struct {
+	}
+	releaseReturns struct {
+		result1 error
+	}
+	releaseReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ReloadStub        func() (bool, error)
 	reloadMutex       sync.RWMutex
 	reloadArgsForCall []struct {
@@ -344,6 +751,44 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	ReloadChangedStub        func() (bool, bool, error)
+	reloadChangedMutex       sync.RWMutex
+	reloadChangedArgsForCall []struct {
+	}
+	reloadChangedReturns struct {
+		result1 bool
+		result2 bool
+		result3 error
+	}
+	reloadChangedReturnsOnCall map[int]struct {
+		result1 bool
+		result2 bool
+		result3 error
+	}
+	RerunsStub        func() ([]db.Build, error)
+	rerunsMutex       sync.RWMutex
+	rerunsArgsForCall []struct {
+	}
+	rerunsReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	rerunsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
+	ResourceCacheUsesStub        func() ([]db.UsedResourceCache, error)
+	resourceCacheUsesMutex       sync.RWMutex
+	resourceCacheUsesArgsForCall []struct {
+	}
+	resourceCacheUsesReturns struct {
+		result1 []db.UsedResourceCache
+		result2 error
+	}
+	resourceCacheUsesReturnsOnCall map[int]struct {
+		result1 []db.UsedResourceCache
+		result2 error
+	}
 	ResourcesStub        func() ([]db.BuildInput, []db.BuildOutput, error)
 	resourcesMutex       sync.RWMutex
 	resourcesArgsForCall []struct {
@@ -358,6 +803,30 @@ type FakeBuild struct {
 		result2 []db.BuildOutput
 		result3 error
 	}
+	ResourcesWithMetadataStub        func() ([]db.BuildInput, []db.BuildOutput, error)
+	resourcesWithMetadataMutex       sync.RWMutex
+	resourcesWithMetadataArgsForCall []struct {
+	}
+	resourcesWithMetadataReturns struct {
+		result1 []db.BuildInput
+		result2 []db.BuildOutput
+		result3 error
+	}
+	resourcesWithMetadataReturnsOnCall map[int]struct {
+		result1 []db.BuildInput
+		result2 []db.BuildOutput
+		result3 error
+	}
+	RetryCountStub        func() int
+	retryCountMutex       sync.RWMutex
+	retryCountArgsForCall []struct {
+	}
+	retryCountReturns struct {
+		result1 int
+	}
+	retryCountReturnsOnCall map[int]struct {
+		result1 int
+	}
 	SaveEventStub        func(atc.Event) error
 	saveEventMutex       sync.RWMutex
 	saveEventArgsForCall []struct {
@@ -369,6 +838,29 @@ type FakeBuild struct {
 	saveEventReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SaveEventWithSeqStub        func(int64, atc.Event) error
+	saveEventWithSeqMutex       sync.RWMutex
+	saveEventWithSeqArgsForCall []struct {
+		arg1 int64
+		arg2 atc.Event
+	}
+	saveEventWithSeqReturns struct {
+		result1 error
+	}
+	saveEventWithSeqReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveEventsStub        func([]atc.Event) error
+	saveEventsMutex       sync.RWMutex
+	saveEventsArgsForCall []struct {
+		arg1 []atc.Event
+	}
+	saveEventsReturns struct {
+		result1 error
+	}
+	saveEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveImageResourceVersionStub        func(db.UsedResourceCache) error
 	saveImageResourceVersionMutex       sync.RWMutex
 	saveImageResourceVersionArgsForCall []struct {
@@ -397,9 +889,32 @@ type FakeBuild struct {
 	saveOutputReturnsOnCall map[int]struct {
 		result1 error
 	}
-	ScheduleStub        func() (bool, error)
-	scheduleMutex       sync.RWMutex
-	scheduleArgsForCall []struct {
+	SaveOutputDeletedStub        func(string, atc.Version) error
+	saveOutputDeletedMutex       sync.RWMutex
+	saveOutputDeletedArgsForCall []struct {
+		arg1 string
+		arg2 atc.Version
+	}
+	saveOutputDeletedReturns struct {
+		result1 error
+	}
+	saveOutputDeletedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveWorkerNameStub        func(string) error
+	saveWorkerNameMutex       sync.RWMutex
+	saveWorkerNameArgsForCall []struct {
+		arg1 string
+	}
+	saveWorkerNameReturns struct {
+		result1 error
+	}
+	saveWorkerNameReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ScheduleStub        func() (bool, error)
+	scheduleMutex       sync.RWMutex
+	scheduleArgsForCall []struct {
 	}
 	scheduleReturns struct {
 		result1 bool
@@ -430,6 +945,17 @@ type FakeBuild struct {
 	setDrainedReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetExpiryStub        func(time.Duration) error
+	setExpiryMutex       sync.RWMutex
+	setExpiryArgsForCall []struct {
+		arg1 time.Duration
+	}
+	setExpiryReturns struct {
+		result1 error
+	}
+	setExpiryReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetInterceptibleStub        func(bool) error
 	setInterceptibleMutex       sync.RWMutex
 	setInterceptibleArgsForCall []struct {
@@ -441,6 +967,50 @@ type FakeBuild struct {
 	setInterceptibleReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetKeepStub        func(bool) error
+	setKeepMutex       sync.RWMutex
+	setKeepArgsForCall []struct {
+		arg1 bool
+	}
+	setKeepReturns struct {
+		result1 error
+	}
+	setKeepReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetNameStub        func(string) error
+	setNameMutex       sync.RWMutex
+	setNameArgsForCall []struct {
+		arg1 string
+	}
+	setNameReturns struct {
+		result1 error
+	}
+	setNameReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetPlanStub        func(atc.Plan) error
+	setPlanMutex       sync.RWMutex
+	setPlanArgsForCall []struct {
+		arg1 atc.Plan
+	}
+	setPlanReturns struct {
+		result1 error
+	}
+	setPlanReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetWaitingForWorkerStub        func([]string) error
+	setWaitingForWorkerMutex       sync.RWMutex
+	setWaitingForWorkerArgsForCall []struct {
+		arg1 []string
+	}
+	setWaitingForWorkerReturns struct {
+		result1 error
+	}
+	setWaitingForWorkerReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StartStub        func(atc.Plan) (bool, error)
 	startMutex       sync.RWMutex
 	startArgsForCall []struct {
@@ -474,6 +1044,16 @@ type FakeBuild struct {
 	statusReturnsOnCall map[int]struct {
 		result1 db.BuildStatus
 	}
+	TagsStub        func() []string
+	tagsMutex       sync.RWMutex
+	tagsArgsForCall []struct {
+	}
+	tagsReturns struct {
+		result1 []string
+	}
+	tagsReturnsOnCall map[int]struct {
+		result1 []string
+	}
 	TeamIDStub        func() int
 	teamIDMutex       sync.RWMutex
 	teamIDArgsForCall []struct {
@@ -494,6 +1074,31 @@ type FakeBuild struct {
 	teamNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	TrimEventsStub        func(time.Time) (int, error)
+	trimEventsMutex       sync.RWMutex
+	trimEventsArgsForCall []struct {
+		arg1 time.Time
+	}
+	trimEventsReturns struct {
+		result1 int
+		result2 error
+	}
+	trimEventsReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	UpdateOutputMetadataStub        func(string, []db.ResourceConfigMetadataField) error
+	updateOutputMetadataMutex       sync.RWMutex
+	updateOutputMetadataArgsForCall []struct {
+		arg1 string
+		arg2 []db.ResourceConfigMetadataField
+	}
+	updateOutputMetadataReturns struct {
+		result1 error
+	}
+	updateOutputMetadataReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UseInputsStub        func([]db.BuildInput) error
 	useInputsMutex       sync.RWMutex
 	useInputsArgsForCall []struct {
@@ -505,6 +1110,18 @@ type FakeBuild struct {
 	useInputsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WorkerNamesStub        func() ([]string, error)
+	workerNamesMutex       sync.RWMutex
+	workerNamesArgsForCall []struct {
+	}
+	workerNamesReturns struct {
+		result1 []string
+		result2 error
+	}
+	workerNamesReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -564,6 +1181,58 @@ func (fake *FakeBuild) AbortNotifierReturnsOnCall(i int, result1 db.Notifier, re
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) AbortReason() string {
+	fake.abortReasonMutex.Lock()
+	ret, specificReturn := fake.abortReasonReturnsOnCall[len(fake.abortReasonArgsForCall)]
+	fake.abortReasonArgsForCall = append(fake.abortReasonArgsForCall, struct {
+	}{})
+	fake.recordInvocation("AbortReason", []interface{}{})
+	fake.abortReasonMutex.Unlock()
+	if fake.AbortReasonStub != nil {
+		return fake.AbortReasonStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.abortReasonReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) AbortReasonCallCount() int {
+	fake.abortReasonMutex.RLock()
+	defer fake.abortReasonMutex.RUnlock()
+	return len(fake.abortReasonArgsForCall)
+}
+
+func (fake *FakeBuild) AbortReasonCalls(stub func() string) {
+	fake.abortReasonMutex.Lock()
+	defer fake.abortReasonMutex.Unlock()
+	fake.AbortReasonStub = stub
+}
+
+func (fake *FakeBuild) AbortReasonReturns(result1 string) {
+	fake.abortReasonMutex.Lock()
+	defer fake.abortReasonMutex.Unlock()
+	fake.AbortReasonStub = nil
+	fake.abortReasonReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) AbortReasonReturnsOnCall(i int, result1 string) {
+	fake.abortReasonMutex.Lock()
+	defer fake.abortReasonMutex.Unlock()
+	fake.AbortReasonStub = nil
+	if fake.abortReasonReturnsOnCall == nil {
+		fake.abortReasonReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.abortReasonReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeBuild) AcquireTrackingLock(arg1 lager.Logger, arg2 time.Duration) (lock.Lock, bool, error) {
 	fake.acquireTrackingLockMutex.Lock()
 	ret, specificReturn := fake.acquireTrackingLockReturnsOnCall[len(fake.acquireTrackingLockArgsForCall)]
@@ -694,6 +1363,72 @@ func (fake *FakeBuild) ArtifactReturnsOnCall(i int, result1 db.WorkerArtifact, r
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) ArtifactByName(arg1 string) (db.WorkerArtifact, bool, error) {
+	fake.artifactByNameMutex.Lock()
+	ret, specificReturn := fake.artifactByNameReturnsOnCall[len(fake.artifactByNameArgsForCall)]
+	fake.artifactByNameArgsForCall = append(fake.artifactByNameArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ArtifactByName", []interface{}{arg1})
+	fake.artifactByNameMutex.Unlock()
+	if fake.ArtifactByNameStub != nil {
+		return fake.ArtifactByNameStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.artifactByNameReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) ArtifactByNameCallCount() int {
+	fake.artifactByNameMutex.RLock()
+	defer fake.artifactByNameMutex.RUnlock()
+	return len(fake.artifactByNameArgsForCall)
+}
+
+func (fake *FakeBuild) ArtifactByNameCalls(stub func(string) (db.WorkerArtifact, bool, error)) {
+	fake.artifactByNameMutex.Lock()
+	defer fake.artifactByNameMutex.Unlock()
+	fake.ArtifactByNameStub = stub
+}
+
+func (fake *FakeBuild) ArtifactByNameArgsForCall(i int) string {
+	fake.artifactByNameMutex.RLock()
+	defer fake.artifactByNameMutex.RUnlock()
+	argsForCall := fake.artifactByNameArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) ArtifactByNameReturns(result1 db.WorkerArtifact, result2 bool, result3 error) {
+	fake.artifactByNameMutex.Lock()
+	defer fake.artifactByNameMutex.Unlock()
+	fake.ArtifactByNameStub = nil
+	fake.artifactByNameReturns = struct {
+		result1 db.WorkerArtifact
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) ArtifactByNameReturnsOnCall(i int, result1 db.WorkerArtifact, result2 bool, result3 error) {
+	fake.artifactByNameMutex.Lock()
+	defer fake.artifactByNameMutex.Unlock()
+	fake.ArtifactByNameStub = nil
+	if fake.artifactByNameReturnsOnCall == nil {
+		fake.artifactByNameReturnsOnCall = make(map[int]struct {
+			result1 db.WorkerArtifact
+			result2 bool
+			result3 error
+		})
+	}
+	fake.artifactByNameReturnsOnCall[i] = struct {
+		result1 db.WorkerArtifact
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuild) Artifacts() ([]db.WorkerArtifact, error) {
 	fake.artifactsMutex.Lock()
 	ret, specificReturn := fake.artifactsReturnsOnCall[len(fake.artifactsArgsForCall)]
@@ -749,6 +1484,61 @@ func (fake *FakeBuild) ArtifactsReturnsOnCall(i int, result1 []db.WorkerArtifact
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) ContainerHandles() ([]string, error) {
+	fake.containerHandlesMutex.Lock()
+	ret, specificReturn := fake.containerHandlesReturnsOnCall[len(fake.containerHandlesArgsForCall)]
+	fake.containerHandlesArgsForCall = append(fake.containerHandlesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ContainerHandles", []interface{}{})
+	fake.containerHandlesMutex.Unlock()
+	if fake.ContainerHandlesStub != nil {
+		return fake.ContainerHandlesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.containerHandlesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) ContainerHandlesCallCount() int {
+	fake.containerHandlesMutex.RLock()
+	defer fake.containerHandlesMutex.RUnlock()
+	return len(fake.containerHandlesArgsForCall)
+}
+
+func (fake *FakeBuild) ContainerHandlesCalls(stub func() ([]string, error)) {
+	fake.containerHandlesMutex.Lock()
+	defer fake.containerHandlesMutex.Unlock()
+	fake.ContainerHandlesStub = stub
+}
+
+func (fake *FakeBuild) ContainerHandlesReturns(result1 []string, result2 error) {
+	fake.containerHandlesMutex.Lock()
+	defer fake.containerHandlesMutex.Unlock()
+	fake.ContainerHandlesStub = nil
+	fake.containerHandlesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ContainerHandlesReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.containerHandlesMutex.Lock()
+	defer fake.containerHandlesMutex.Unlock()
+	fake.ContainerHandlesStub = nil
+	if fake.containerHandlesReturnsOnCall == nil {
+		fake.containerHandlesReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.containerHandlesReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) CreateTime() time.Time {
 	fake.createTimeMutex.Lock()
 	ret, specificReturn := fake.createTimeReturnsOnCall[len(fake.createTimeArgsForCall)]
@@ -856,6 +1646,61 @@ func (fake *FakeBuild) DeleteReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) DownstreamJobs() ([]db.Job, error) {
+	fake.downstreamJobsMutex.Lock()
+	ret, specificReturn := fake.downstreamJobsReturnsOnCall[len(fake.downstreamJobsArgsForCall)]
+	fake.downstreamJobsArgsForCall = append(fake.downstreamJobsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("DownstreamJobs", []interface{}{})
+	fake.downstreamJobsMutex.Unlock()
+	if fake.DownstreamJobsStub != nil {
+		return fake.DownstreamJobsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.downstreamJobsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) DownstreamJobsCallCount() int {
+	fake.downstreamJobsMutex.RLock()
+	defer fake.downstreamJobsMutex.RUnlock()
+	return len(fake.downstreamJobsArgsForCall)
+}
+
+func (fake *FakeBuild) DownstreamJobsCalls(stub func() ([]db.Job, error)) {
+	fake.downstreamJobsMutex.Lock()
+	defer fake.downstreamJobsMutex.Unlock()
+	fake.DownstreamJobsStub = stub
+}
+
+func (fake *FakeBuild) DownstreamJobsReturns(result1 []db.Job, result2 error) {
+	fake.downstreamJobsMutex.Lock()
+	defer fake.downstreamJobsMutex.Unlock()
+	fake.DownstreamJobsStub = nil
+	fake.downstreamJobsReturns = struct {
+		result1 []db.Job
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) DownstreamJobsReturnsOnCall(i int, result1 []db.Job, result2 error) {
+	fake.downstreamJobsMutex.Lock()
+	defer fake.downstreamJobsMutex.Unlock()
+	fake.DownstreamJobsStub = nil
+	if fake.downstreamJobsReturnsOnCall == nil {
+		fake.downstreamJobsReturnsOnCall = make(map[int]struct {
+			result1 []db.Job
+			result2 error
+		})
+	}
+	fake.downstreamJobsReturnsOnCall[i] = struct {
+		result1 []db.Job
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) EndTime() time.Time {
 	fake.endTimeMutex.Lock()
 	ret, specificReturn := fake.endTimeReturnsOnCall[len(fake.endTimeArgsForCall)]
@@ -908,6 +1753,184 @@ func (fake *FakeBuild) EndTimeReturnsOnCall(i int, result1 time.Time) {
 	}{result1}
 }
 
+func (fake *FakeBuild) ErrorUnresolvedInputs(arg1 string) error {
+	fake.errorUnresolvedInputsMutex.Lock()
+	ret, specificReturn := fake.errorUnresolvedInputsReturnsOnCall[len(fake.errorUnresolvedInputsArgsForCall)]
+	fake.errorUnresolvedInputsArgsForCall = append(fake.errorUnresolvedInputsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ErrorUnresolvedInputs", []interface{}{arg1})
+	fake.errorUnresolvedInputsMutex.Unlock()
+	if fake.ErrorUnresolvedInputsStub != nil {
+		return fake.ErrorUnresolvedInputsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.errorUnresolvedInputsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ErrorUnresolvedInputsCallCount() int {
+	fake.errorUnresolvedInputsMutex.RLock()
+	defer fake.errorUnresolvedInputsMutex.RUnlock()
+	return len(fake.errorUnresolvedInputsArgsForCall)
+}
+
+func (fake *FakeBuild) ErrorUnresolvedInputsCalls(stub func(string) error) {
+	fake.errorUnresolvedInputsMutex.Lock()
+	defer fake.errorUnresolvedInputsMutex.Unlock()
+	fake.ErrorUnresolvedInputsStub = stub
+}
+
+func (fake *FakeBuild) ErrorUnresolvedInputsArgsForCall(i int) string {
+	fake.errorUnresolvedInputsMutex.RLock()
+	defer fake.errorUnresolvedInputsMutex.RUnlock()
+	argsForCall := fake.errorUnresolvedInputsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) ErrorUnresolvedInputsReturns(result1 error) {
+	fake.errorUnresolvedInputsMutex.Lock()
+	defer fake.errorUnresolvedInputsMutex.Unlock()
+	fake.ErrorUnresolvedInputsStub = nil
+	fake.errorUnresolvedInputsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) ErrorUnresolvedInputsReturnsOnCall(i int, result1 error) {
+	fake.errorUnresolvedInputsMutex.Lock()
+	defer fake.errorUnresolvedInputsMutex.Unlock()
+	fake.ErrorUnresolvedInputsStub = nil
+	if fake.errorUnresolvedInputsReturnsOnCall == nil {
+		fake.errorUnresolvedInputsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.errorUnresolvedInputsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) EventCount() (int, error) {
+	fake.eventCountMutex.Lock()
+	ret, specificReturn := fake.eventCountReturnsOnCall[len(fake.eventCountArgsForCall)]
+	fake.eventCountArgsForCall = append(fake.eventCountArgsForCall, struct {
+	}{})
+	fake.recordInvocation("EventCount", []interface{}{})
+	fake.eventCountMutex.Unlock()
+	if fake.EventCountStub != nil {
+		return fake.EventCountStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.eventCountReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) EventCountCallCount() int {
+	fake.eventCountMutex.RLock()
+	defer fake.eventCountMutex.RUnlock()
+	return len(fake.eventCountArgsForCall)
+}
+
+func (fake *FakeBuild) EventCountCalls(stub func() (int, error)) {
+	fake.eventCountMutex.Lock()
+	defer fake.eventCountMutex.Unlock()
+	fake.EventCountStub = stub
+}
+
+func (fake *FakeBuild) EventCountReturns(result1 int, result2 error) {
+	fake.eventCountMutex.Lock()
+	defer fake.eventCountMutex.Unlock()
+	fake.EventCountStub = nil
+	fake.eventCountReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) EventCountReturnsOnCall(i int, result1 int, result2 error) {
+	fake.eventCountMutex.Lock()
+	defer fake.eventCountMutex.Unlock()
+	fake.EventCountStub = nil
+	if fake.eventCountReturnsOnCall == nil {
+		fake.eventCountReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.eventCountReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) EventOffsetAtFraction(arg1 float64) (uint, error) {
+	fake.eventOffsetAtFractionMutex.Lock()
+	ret, specificReturn := fake.eventOffsetAtFractionReturnsOnCall[len(fake.eventOffsetAtFractionArgsForCall)]
+	fake.eventOffsetAtFractionArgsForCall = append(fake.eventOffsetAtFractionArgsForCall, struct {
+		arg1 float64
+	}{arg1})
+	fake.recordInvocation("EventOffsetAtFraction", []interface{}{arg1})
+	fake.eventOffsetAtFractionMutex.Unlock()
+	if fake.EventOffsetAtFractionStub != nil {
+		return fake.EventOffsetAtFractionStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.eventOffsetAtFractionReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) EventOffsetAtFractionCallCount() int {
+	fake.eventOffsetAtFractionMutex.RLock()
+	defer fake.eventOffsetAtFractionMutex.RUnlock()
+	return len(fake.eventOffsetAtFractionArgsForCall)
+}
+
+func (fake *FakeBuild) EventOffsetAtFractionCalls(stub func(float64) (uint, error)) {
+	fake.eventOffsetAtFractionMutex.Lock()
+	defer fake.eventOffsetAtFractionMutex.Unlock()
+	fake.EventOffsetAtFractionStub = stub
+}
+
+func (fake *FakeBuild) EventOffsetAtFractionArgsForCall(i int) float64 {
+	fake.eventOffsetAtFractionMutex.RLock()
+	defer fake.eventOffsetAtFractionMutex.RUnlock()
+	argsForCall := fake.eventOffsetAtFractionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) EventOffsetAtFractionReturns(result1 uint, result2 error) {
+	fake.eventOffsetAtFractionMutex.Lock()
+	defer fake.eventOffsetAtFractionMutex.Unlock()
+	fake.EventOffsetAtFractionStub = nil
+	fake.eventOffsetAtFractionReturns = struct {
+		result1 uint
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) EventOffsetAtFractionReturnsOnCall(i int, result1 uint, result2 error) {
+	fake.eventOffsetAtFractionMutex.Lock()
+	defer fake.eventOffsetAtFractionMutex.Unlock()
+	fake.EventOffsetAtFractionStub = nil
+	if fake.eventOffsetAtFractionReturnsOnCall == nil {
+		fake.eventOffsetAtFractionReturnsOnCall = make(map[int]struct {
+			result1 uint
+			result2 error
+		})
+	}
+	fake.eventOffsetAtFractionReturnsOnCall[i] = struct {
+		result1 uint
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Events(arg1 uint) (db.EventSource, error) {
 	fake.eventsMutex.Lock()
 	ret, specificReturn := fake.eventsReturnsOnCall[len(fake.eventsArgsForCall)]
@@ -971,19 +1994,272 @@ func (fake *FakeBuild) EventsReturnsOnCall(i int, result1 db.EventSource, result
 	}{result1, result2}
 }
 
-func (fake *FakeBuild) Finish(arg1 db.BuildStatus) error {
-	fake.finishMutex.Lock()
-	ret, specificReturn := fake.finishReturnsOnCall[len(fake.finishArgsForCall)]
-	fake.finishArgsForCall = append(fake.finishArgsForCall, struct {
-		arg1 db.BuildStatus
-	}{arg1})
-	fake.recordInvocation("Finish", []interface{}{arg1})
-	fake.finishMutex.Unlock()
-	if fake.FinishStub != nil {
-		return fake.FinishStub(arg1)
+func (fake *FakeBuild) EventsBetween(arg1 time.Time, arg2 time.Time) (db.EventSource, error) {
+	fake.eventsBetweenMutex.Lock()
+	ret, specificReturn := fake.eventsBetweenReturnsOnCall[len(fake.eventsBetweenArgsForCall)]
+	fake.eventsBetweenArgsForCall = append(fake.eventsBetweenArgsForCall, struct {
+		arg1 time.Time
+		arg2 time.Time
+	}{arg1, arg2})
+	fake.recordInvocation("EventsBetween", []interface{}{arg1, arg2})
+	fake.eventsBetweenMutex.Unlock()
+	if fake.EventsBetweenStub != nil {
+		return fake.EventsBetweenStub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.eventsBetweenReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) EventsBetweenCallCount() int {
+	fake.eventsBetweenMutex.RLock()
+	defer fake.eventsBetweenMutex.RUnlock()
+	return len(fake.eventsBetweenArgsForCall)
+}
+
+func (fake *FakeBuild) EventsBetweenCalls(stub func(time.Time, time.Time) (db.EventSource, error)) {
+	fake.eventsBetweenMutex.Lock()
+	defer fake.eventsBetweenMutex.Unlock()
+	fake.EventsBetweenStub = stub
+}
+
+func (fake *FakeBuild) EventsBetweenArgsForCall(i int) (time.Time, time.Time) {
+	fake.eventsBetweenMutex.RLock()
+	defer fake.eventsBetweenMutex.RUnlock()
+	argsForCall := fake.eventsBetweenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) EventsBetweenReturns(result1 db.EventSource, result2 error) {
+	fake.eventsBetweenMutex.Lock()
+	defer fake.eventsBetweenMutex.Unlock()
+	fake.EventsBetweenStub = nil
+	fake.eventsBetweenReturns = struct {
+		result1 db.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) EventsBetweenReturnsOnCall(i int, result1 db.EventSource, result2 error) {
+	fake.eventsBetweenMutex.Lock()
+	defer fake.eventsBetweenMutex.Unlock()
+	fake.EventsBetweenStub = nil
+	if fake.eventsBetweenReturnsOnCall == nil {
+		fake.eventsBetweenReturnsOnCall = make(map[int]struct {
+			result1 db.EventSource
+			result2 error
+		})
+	}
+	fake.eventsBetweenReturnsOnCall[i] = struct {
+		result1 db.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) EventsCompressed(arg1 uint) (io.ReadCloser, error) {
+	fake.eventsCompressedMutex.Lock()
+	ret, specificReturn := fake.eventsCompressedReturnsOnCall[len(fake.eventsCompressedArgsForCall)]
+	fake.eventsCompressedArgsForCall = append(fake.eventsCompressedArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	fake.recordInvocation("EventsCompressed", []interface{}{arg1})
+	fake.eventsCompressedMutex.Unlock()
+	if fake.EventsCompressedStub != nil {
+		return fake.EventsCompressedStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.eventsCompressedReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) EventsCompressedCallCount() int {
+	fake.eventsCompressedMutex.RLock()
+	defer fake.eventsCompressedMutex.RUnlock()
+	return len(fake.eventsCompressedArgsForCall)
+}
+
+func (fake *FakeBuild) EventsCompressedCalls(stub func(uint) (io.ReadCloser, error)) {
+	fake.eventsCompressedMutex.Lock()
+	defer fake.eventsCompressedMutex.Unlock()
+	fake.EventsCompressedStub = stub
+}
+
+func (fake *FakeBuild) EventsCompressedArgsForCall(i int) uint {
+	fake.eventsCompressedMutex.RLock()
+	defer fake.eventsCompressedMutex.RUnlock()
+	argsForCall := fake.eventsCompressedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) EventsCompressedReturns(result1 io.ReadCloser, result2 error) {
+	fake.eventsCompressedMutex.Lock()
+	defer fake.eventsCompressedMutex.Unlock()
+	fake.EventsCompressedStub = nil
+	fake.eventsCompressedReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) EventsCompressedReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.eventsCompressedMutex.Lock()
+	defer fake.eventsCompressedMutex.Unlock()
+	fake.EventsCompressedStub = nil
+	if fake.eventsCompressedReturnsOnCall == nil {
+		fake.eventsCompressedReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.eventsCompressedReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) EventsFromID(arg1 int) (db.EventSource, error) {
+	fake.eventsFromIDMutex.Lock()
+	ret, specificReturn := fake.eventsFromIDReturnsOnCall[len(fake.eventsFromIDArgsForCall)]
+	fake.eventsFromIDArgsForCall = append(fake.eventsFromIDArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("EventsFromID", []interface{}{arg1})
+	fake.eventsFromIDMutex.Unlock()
+	if fake.EventsFromIDStub != nil {
+		return fake.EventsFromIDStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.eventsFromIDReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) EventsFromIDCallCount() int {
+	fake.eventsFromIDMutex.RLock()
+	defer fake.eventsFromIDMutex.RUnlock()
+	return len(fake.eventsFromIDArgsForCall)
+}
+
+func (fake *FakeBuild) EventsFromIDCalls(stub func(int) (db.EventSource, error)) {
+	fake.eventsFromIDMutex.Lock()
+	defer fake.eventsFromIDMutex.Unlock()
+	fake.EventsFromIDStub = stub
+}
+
+func (fake *FakeBuild) EventsFromIDArgsForCall(i int) int {
+	fake.eventsFromIDMutex.RLock()
+	defer fake.eventsFromIDMutex.RUnlock()
+	argsForCall := fake.eventsFromIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) EventsFromIDReturns(result1 db.EventSource, result2 error) {
+	fake.eventsFromIDMutex.Lock()
+	defer fake.eventsFromIDMutex.Unlock()
+	fake.EventsFromIDStub = nil
+	fake.eventsFromIDReturns = struct {
+		result1 db.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) EventsFromIDReturnsOnCall(i int, result1 db.EventSource, result2 error) {
+	fake.eventsFromIDMutex.Lock()
+	defer fake.eventsFromIDMutex.Unlock()
+	fake.EventsFromIDStub = nil
+	if fake.eventsFromIDReturnsOnCall == nil {
+		fake.eventsFromIDReturnsOnCall = make(map[int]struct {
+			result1 db.EventSource
+			result2 error
+		})
+	}
+	fake.eventsFromIDReturnsOnCall[i] = struct {
+		result1 db.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) EventsFromToken(arg1 string) (db.EventSource, error) {
+	fake.eventsFromTokenMutex.Lock()
+	ret, specificReturn := fake.eventsFromTokenReturnsOnCall[len(fake.eventsFromTokenArgsForCall)]
+	fake.eventsFromTokenArgsForCall = append(fake.eventsFromTokenArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("EventsFromToken", []interface{}{arg1})
+	fake.eventsFromTokenMutex.Unlock()
+	if fake.EventsFromTokenStub != nil {
+		return fake.EventsFromTokenStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.eventsFromTokenReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) EventsFromTokenCallCount() int {
+	fake.eventsFromTokenMutex.RLock()
+	defer fake.eventsFromTokenMutex.RUnlock()
+	return len(fake.eventsFromTokenArgsForCall)
+}
+
+func (fake *FakeBuild) EventsFromTokenCalls(stub func(string) (db.EventSource, error)) {
+	fake.eventsFromTokenMutex.Lock()
+	defer fake.eventsFromTokenMutex.Unlock()
+	fake.EventsFromTokenStub = stub
+}
+
+func (fake *FakeBuild) EventsFromTokenArgsForCall(i int) string {
+	fake.eventsFromTokenMutex.RLock()
+	defer fake.eventsFromTokenMutex.RUnlock()
+	argsForCall := fake.eventsFromTokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) EventsFromTokenReturns(result1 db.EventSource, result2 error) {
+	fake.eventsFromTokenMutex.Lock()
+	defer fake.eventsFromTokenMutex.Unlock()
+	fake.EventsFromTokenStub = nil
+	fake.eventsFromTokenReturns = struct {
+		result1 db.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) EventsFromTokenReturnsOnCall(i int, result1 db.EventSource, result2 error) {
+	fake.eventsFromTokenMutex.Lock()
+	defer fake.eventsFromTokenMutex.Unlock()
+	fake.EventsFromTokenStub = nil
+	if fake.eventsFromTokenReturnsOnCall == nil {
+		fake.eventsFromTokenReturnsOnCall = make(map[int]struct {
+			result1 db.EventSource
+			result2 error
+		})
+	}
+	fake.eventsFromTokenReturnsOnCall[i] = struct {
+		result1 db.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) Finish(arg1 db.BuildStatus) error {
+	fake.finishMutex.Lock()
+	ret, specificReturn := fake.finishReturnsOnCall[len(fake.finishArgsForCall)]
+	fake.finishArgsForCall = append(fake.finishArgsForCall, struct {
+		arg1 db.BuildStatus
+	}{arg1})
+	fake.recordInvocation("Finish", []interface{}{arg1})
+	fake.finishMutex.Unlock()
+	if fake.FinishStub != nil {
+		return fake.FinishStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
 	}
 	fakeReturns := fake.finishReturns
 	return fakeReturns.result1
@@ -1031,6 +2307,133 @@ func (fake *FakeBuild) FinishReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) FinishWithEvents(arg1 db.BuildStatus, arg2 []atc.Event) error {
+	var arg2Copy []atc.Event
+	if arg2 != nil {
+		arg2Copy = make([]atc.Event, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.finishWithEventsMutex.Lock()
+	ret, specificReturn := fake.finishWithEventsReturnsOnCall[len(fake.finishWithEventsArgsForCall)]
+	fake.finishWithEventsArgsForCall = append(fake.finishWithEventsArgsForCall, struct {
+		arg1 db.BuildStatus
+		arg2 []atc.Event
+	}{arg1, arg2Copy})
+	fake.recordInvocation("FinishWithEvents", []interface{}{arg1, arg2Copy})
+	fake.finishWithEventsMutex.Unlock()
+	if fake.FinishWithEventsStub != nil {
+		return fake.FinishWithEventsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.finishWithEventsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) FinishWithEventsCallCount() int {
+	fake.finishWithEventsMutex.RLock()
+	defer fake.finishWithEventsMutex.RUnlock()
+	return len(fake.finishWithEventsArgsForCall)
+}
+
+func (fake *FakeBuild) FinishWithEventsCalls(stub func(db.BuildStatus, []atc.Event) error) {
+	fake.finishWithEventsMutex.Lock()
+	defer fake.finishWithEventsMutex.Unlock()
+	fake.FinishWithEventsStub = stub
+}
+
+func (fake *FakeBuild) FinishWithEventsArgsForCall(i int) (db.BuildStatus, []atc.Event) {
+	fake.finishWithEventsMutex.RLock()
+	defer fake.finishWithEventsMutex.RUnlock()
+	argsForCall := fake.finishWithEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) FinishWithEventsReturns(result1 error) {
+	fake.finishWithEventsMutex.Lock()
+	defer fake.finishWithEventsMutex.Unlock()
+	fake.FinishWithEventsStub = nil
+	fake.finishWithEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) FinishWithEventsReturnsOnCall(i int, result1 error) {
+	fake.finishWithEventsMutex.Lock()
+	defer fake.finishWithEventsMutex.Unlock()
+	fake.FinishWithEventsStub = nil
+	if fake.finishWithEventsReturnsOnCall == nil {
+		fake.finishWithEventsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.finishWithEventsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) FinishWithOptions(arg1 db.BuildStatus, arg2 db.FinishOptions) error {
+	fake.finishWithOptionsMutex.Lock()
+	ret, specificReturn := fake.finishWithOptionsReturnsOnCall[len(fake.finishWithOptionsArgsForCall)]
+	fake.finishWithOptionsArgsForCall = append(fake.finishWithOptionsArgsForCall, struct {
+		arg1 db.BuildStatus
+		arg2 db.FinishOptions
+	}{arg1, arg2})
+	fake.recordInvocation("FinishWithOptions", []interface{}{arg1, arg2})
+	fake.finishWithOptionsMutex.Unlock()
+	if fake.FinishWithOptionsStub != nil {
+		return fake.FinishWithOptionsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.finishWithOptionsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) FinishWithOptionsCallCount() int {
+	fake.finishWithOptionsMutex.RLock()
+	defer fake.finishWithOptionsMutex.RUnlock()
+	return len(fake.finishWithOptionsArgsForCall)
+}
+
+func (fake *FakeBuild) FinishWithOptionsCalls(stub func(db.BuildStatus, db.FinishOptions) error) {
+	fake.finishWithOptionsMutex.Lock()
+	defer fake.finishWithOptionsMutex.Unlock()
+	fake.FinishWithOptionsStub = stub
+}
+
+func (fake *FakeBuild) FinishWithOptionsArgsForCall(i int) (db.BuildStatus, db.FinishOptions) {
+	fake.finishWithOptionsMutex.RLock()
+	defer fake.finishWithOptionsMutex.RUnlock()
+	argsForCall := fake.finishWithOptionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) FinishWithOptionsReturns(result1 error) {
+	fake.finishWithOptionsMutex.Lock()
+	defer fake.finishWithOptionsMutex.Unlock()
+	fake.FinishWithOptionsStub = nil
+	fake.finishWithOptionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) FinishWithOptionsReturnsOnCall(i int, result1 error) {
+	fake.finishWithOptionsMutex.Lock()
+	defer fake.finishWithOptionsMutex.Unlock()
+	fake.FinishWithOptionsStub = nil
+	if fake.finishWithOptionsReturnsOnCall == nil {
+		fake.finishWithOptionsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.finishWithOptionsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) HasPlan() bool {
 	fake.hasPlanMutex.Lock()
 	ret, specificReturn := fake.hasPlanReturnsOnCall[len(fake.hasPlanArgsForCall)]
@@ -1083,6 +2486,110 @@ func (fake *FakeBuild) HasPlanReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeBuild) HasPrivatePlan() bool {
+	fake.hasPrivatePlanMutex.Lock()
+	ret, specificReturn := fake.hasPrivatePlanReturnsOnCall[len(fake.hasPrivatePlanArgsForCall)]
+	fake.hasPrivatePlanArgsForCall = append(fake.hasPrivatePlanArgsForCall, struct {
+	}{})
+	fake.recordInvocation("HasPrivatePlan", []interface{}{})
+	fake.hasPrivatePlanMutex.Unlock()
+	if fake.HasPrivatePlanStub != nil {
+		return fake.HasPrivatePlanStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.hasPrivatePlanReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) HasPrivatePlanCallCount() int {
+	fake.hasPrivatePlanMutex.RLock()
+	defer fake.hasPrivatePlanMutex.RUnlock()
+	return len(fake.hasPrivatePlanArgsForCall)
+}
+
+func (fake *FakeBuild) HasPrivatePlanCalls(stub func() bool) {
+	fake.hasPrivatePlanMutex.Lock()
+	defer fake.hasPrivatePlanMutex.Unlock()
+	fake.HasPrivatePlanStub = stub
+}
+
+func (fake *FakeBuild) HasPrivatePlanReturns(result1 bool) {
+	fake.hasPrivatePlanMutex.Lock()
+	defer fake.hasPrivatePlanMutex.Unlock()
+	fake.HasPrivatePlanStub = nil
+	fake.hasPrivatePlanReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) HasPrivatePlanReturnsOnCall(i int, result1 bool) {
+	fake.hasPrivatePlanMutex.Lock()
+	defer fake.hasPrivatePlanMutex.Unlock()
+	fake.HasPrivatePlanStub = nil
+	if fake.hasPrivatePlanReturnsOnCall == nil {
+		fake.hasPrivatePlanReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.hasPrivatePlanReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) Hold() error {
+	fake.holdMutex.Lock()
+	ret, specificReturn := fake.holdReturnsOnCall[len(fake.holdArgsForCall)]
+	fake.holdArgsForCall = append(fake.holdArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Hold", []interface{}{})
+	fake.holdMutex.Unlock()
+	if fake.HoldStub != nil {
+		return fake.HoldStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.holdReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) HoldCallCount() int {
+	fake.holdMutex.RLock()
+	defer fake.holdMutex.RUnlock()
+	return len(fake.holdArgsForCall)
+}
+
+func (fake *FakeBuild) HoldCalls(stub func() error) {
+	fake.holdMutex.Lock()
+	defer fake.holdMutex.Unlock()
+	fake.HoldStub = stub
+}
+
+func (fake *FakeBuild) HoldReturns(result1 error) {
+	fake.holdMutex.Lock()
+	defer fake.holdMutex.Unlock()
+	fake.HoldStub = nil
+	fake.holdReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) HoldReturnsOnCall(i int, result1 error) {
+	fake.holdMutex.Lock()
+	defer fake.holdMutex.Unlock()
+	fake.HoldStub = nil
+	if fake.holdReturnsOnCall == nil {
+		fake.holdReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.holdReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) ID() int {
 	fake.iDMutex.Lock()
 	ret, specificReturn := fake.iDReturnsOnCall[len(fake.iDArgsForCall)]
@@ -1135,44 +2642,206 @@ func (fake *FakeBuild) IDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
-func (fake *FakeBuild) Interceptible() (bool, error) {
-	fake.interceptibleMutex.Lock()
-	ret, specificReturn := fake.interceptibleReturnsOnCall[len(fake.interceptibleArgsForCall)]
-	fake.interceptibleArgsForCall = append(fake.interceptibleArgsForCall, struct {
+func (fake *FakeBuild) InputCount() int {
+	fake.inputCountMutex.Lock()
+	ret, specificReturn := fake.inputCountReturnsOnCall[len(fake.inputCountArgsForCall)]
+	fake.inputCountArgsForCall = append(fake.inputCountArgsForCall, struct {
 	}{})
-	fake.recordInvocation("Interceptible", []interface{}{})
-	fake.interceptibleMutex.Unlock()
-	if fake.InterceptibleStub != nil {
-		return fake.InterceptibleStub()
+	fake.recordInvocation("InputCount", []interface{}{})
+	fake.inputCountMutex.Unlock()
+	if fake.InputCountStub != nil {
+		return fake.InputCountStub()
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	fakeReturns := fake.interceptibleReturns
-	return fakeReturns.result1, fakeReturns.result2
+	fakeReturns := fake.inputCountReturns
+	return fakeReturns.result1
 }
 
-func (fake *FakeBuild) InterceptibleCallCount() int {
-	fake.interceptibleMutex.RLock()
-	defer fake.interceptibleMutex.RUnlock()
-	return len(fake.interceptibleArgsForCall)
+func (fake *FakeBuild) InputCountCallCount() int {
+	fake.inputCountMutex.RLock()
+	defer fake.inputCountMutex.RUnlock()
+	return len(fake.inputCountArgsForCall)
 }
 
-func (fake *FakeBuild) InterceptibleCalls(stub func() (bool, error)) {
-	fake.interceptibleMutex.Lock()
-	defer fake.interceptibleMutex.Unlock()
-	fake.InterceptibleStub = stub
+func (fake *FakeBuild) InputCountCalls(stub func() int) {
+	fake.inputCountMutex.Lock()
+	defer fake.inputCountMutex.Unlock()
+	fake.InputCountStub = stub
 }
 
-func (fake *FakeBuild) InterceptibleReturns(result1 bool, result2 error) {
-	fake.interceptibleMutex.Lock()
-	defer fake.interceptibleMutex.Unlock()
-	fake.InterceptibleStub = nil
-	fake.interceptibleReturns = struct {
-		result1 bool
-		result2 error
-	}{result1, result2}
-}
+func (fake *FakeBuild) InputCountReturns(result1 int) {
+	fake.inputCountMutex.Lock()
+	defer fake.inputCountMutex.Unlock()
+	fake.InputCountStub = nil
+	fake.inputCountReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) InputCountReturnsOnCall(i int, result1 int) {
+	fake.inputCountMutex.Lock()
+	defer fake.inputCountMutex.Unlock()
+	fake.InputCountStub = nil
+	if fake.inputCountReturnsOnCall == nil {
+		fake.inputCountReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.inputCountReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) InputVersions() (map[string]atc.Version, error) {
+	fake.inputVersionsMutex.Lock()
+	ret, specificReturn := fake.inputVersionsReturnsOnCall[len(fake.inputVersionsArgsForCall)]
+	fake.inputVersionsArgsForCall = append(fake.inputVersionsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("InputVersions", []interface{}{})
+	fake.inputVersionsMutex.Unlock()
+	if fake.InputVersionsStub != nil {
+		return fake.InputVersionsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.inputVersionsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) InputVersionsCallCount() int {
+	fake.inputVersionsMutex.RLock()
+	defer fake.inputVersionsMutex.RUnlock()
+	return len(fake.inputVersionsArgsForCall)
+}
+
+func (fake *FakeBuild) InputVersionsCalls(stub func() (map[string]atc.Version, error)) {
+	fake.inputVersionsMutex.Lock()
+	defer fake.inputVersionsMutex.Unlock()
+	fake.InputVersionsStub = stub
+}
+
+func (fake *FakeBuild) InputVersionsReturns(result1 map[string]atc.Version, result2 error) {
+	fake.inputVersionsMutex.Lock()
+	defer fake.inputVersionsMutex.Unlock()
+	fake.InputVersionsStub = nil
+	fake.inputVersionsReturns = struct {
+		result1 map[string]atc.Version
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) InputVersionsReturnsOnCall(i int, result1 map[string]atc.Version, result2 error) {
+	fake.inputVersionsMutex.Lock()
+	defer fake.inputVersionsMutex.Unlock()
+	fake.InputVersionsStub = nil
+	if fake.inputVersionsReturnsOnCall == nil {
+		fake.inputVersionsReturnsOnCall = make(map[int]struct {
+			result1 map[string]atc.Version
+			result2 error
+		})
+	}
+	fake.inputVersionsReturnsOnCall[i] = struct {
+		result1 map[string]atc.Version
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) InputsDeterminedAt() (time.Time, bool) {
+	fake.inputsDeterminedAtMutex.Lock()
+	ret, specificReturn := fake.inputsDeterminedAtReturnsOnCall[len(fake.inputsDeterminedAtArgsForCall)]
+	fake.inputsDeterminedAtArgsForCall = append(fake.inputsDeterminedAtArgsForCall, struct {
+	}{})
+	fake.recordInvocation("InputsDeterminedAt", []interface{}{})
+	fake.inputsDeterminedAtMutex.Unlock()
+	if fake.InputsDeterminedAtStub != nil {
+		return fake.InputsDeterminedAtStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.inputsDeterminedAtReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) InputsDeterminedAtCallCount() int {
+	fake.inputsDeterminedAtMutex.RLock()
+	defer fake.inputsDeterminedAtMutex.RUnlock()
+	return len(fake.inputsDeterminedAtArgsForCall)
+}
+
+func (fake *FakeBuild) InputsDeterminedAtCalls(stub func() (time.Time, bool)) {
+	fake.inputsDeterminedAtMutex.Lock()
+	defer fake.inputsDeterminedAtMutex.Unlock()
+	fake.InputsDeterminedAtStub = stub
+}
+
+func (fake *FakeBuild) InputsDeterminedAtReturns(result1 time.Time, result2 bool) {
+	fake.inputsDeterminedAtMutex.Lock()
+	defer fake.inputsDeterminedAtMutex.Unlock()
+	fake.InputsDeterminedAtStub = nil
+	fake.inputsDeterminedAtReturns = struct {
+		result1 time.Time
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) InputsDeterminedAtReturnsOnCall(i int, result1 time.Time, result2 bool) {
+	fake.inputsDeterminedAtMutex.Lock()
+	defer fake.inputsDeterminedAtMutex.Unlock()
+	fake.InputsDeterminedAtStub = nil
+	if fake.inputsDeterminedAtReturnsOnCall == nil {
+		fake.inputsDeterminedAtReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+			result2 bool
+		})
+	}
+	fake.inputsDeterminedAtReturnsOnCall[i] = struct {
+		result1 time.Time
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) Interceptible() (bool, error) {
+	fake.interceptibleMutex.Lock()
+	ret, specificReturn := fake.interceptibleReturnsOnCall[len(fake.interceptibleArgsForCall)]
+	fake.interceptibleArgsForCall = append(fake.interceptibleArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Interceptible", []interface{}{})
+	fake.interceptibleMutex.Unlock()
+	if fake.InterceptibleStub != nil {
+		return fake.InterceptibleStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.interceptibleReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) InterceptibleCallCount() int {
+	fake.interceptibleMutex.RLock()
+	defer fake.interceptibleMutex.RUnlock()
+	return len(fake.interceptibleArgsForCall)
+}
+
+func (fake *FakeBuild) InterceptibleCalls(stub func() (bool, error)) {
+	fake.interceptibleMutex.Lock()
+	defer fake.interceptibleMutex.Unlock()
+	fake.InterceptibleStub = stub
+}
+
+func (fake *FakeBuild) InterceptibleReturns(result1 bool, result2 error) {
+	fake.interceptibleMutex.Lock()
+	defer fake.interceptibleMutex.Unlock()
+	fake.InterceptibleStub = nil
+	fake.interceptibleReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
 
 func (fake *FakeBuild) InterceptibleReturnsOnCall(i int, result1 bool, result2 error) {
 	fake.interceptibleMutex.Lock()
@@ -1346,6 +3015,110 @@ func (fake *FakeBuild) IsDrainedReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeBuild) IsHeld() bool {
+	fake.isHeldMutex.Lock()
+	ret, specificReturn := fake.isHeldReturnsOnCall[len(fake.isHeldArgsForCall)]
+	fake.isHeldArgsForCall = append(fake.isHeldArgsForCall, struct {
+	}{})
+	fake.recordInvocation("IsHeld", []interface{}{})
+	fake.isHeldMutex.Unlock()
+	if fake.IsHeldStub != nil {
+		return fake.IsHeldStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.isHeldReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) IsHeldCallCount() int {
+	fake.isHeldMutex.RLock()
+	defer fake.isHeldMutex.RUnlock()
+	return len(fake.isHeldArgsForCall)
+}
+
+func (fake *FakeBuild) IsHeldCalls(stub func() bool) {
+	fake.isHeldMutex.Lock()
+	defer fake.isHeldMutex.Unlock()
+	fake.IsHeldStub = stub
+}
+
+func (fake *FakeBuild) IsHeldReturns(result1 bool) {
+	fake.isHeldMutex.Lock()
+	defer fake.isHeldMutex.Unlock()
+	fake.IsHeldStub = nil
+	fake.isHeldReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) IsHeldReturnsOnCall(i int, result1 bool) {
+	fake.isHeldMutex.Lock()
+	defer fake.isHeldMutex.Unlock()
+	fake.IsHeldStub = nil
+	if fake.isHeldReturnsOnCall == nil {
+		fake.isHeldReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isHeldReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) IsKept() bool {
+	fake.isKeptMutex.Lock()
+	ret, specificReturn := fake.isKeptReturnsOnCall[len(fake.isKeptArgsForCall)]
+	fake.isKeptArgsForCall = append(fake.isKeptArgsForCall, struct {
+	}{})
+	fake.recordInvocation("IsKept", []interface{}{})
+	fake.isKeptMutex.Unlock()
+	if fake.IsKeptStub != nil {
+		return fake.IsKeptStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.isKeptReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) IsKeptCallCount() int {
+	fake.isKeptMutex.RLock()
+	defer fake.isKeptMutex.RUnlock()
+	return len(fake.isKeptArgsForCall)
+}
+
+func (fake *FakeBuild) IsKeptCalls(stub func() bool) {
+	fake.isKeptMutex.Lock()
+	defer fake.isKeptMutex.Unlock()
+	fake.IsKeptStub = stub
+}
+
+func (fake *FakeBuild) IsKeptReturns(result1 bool) {
+	fake.isKeptMutex.Lock()
+	defer fake.isKeptMutex.Unlock()
+	fake.IsKeptStub = nil
+	fake.isKeptReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) IsKeptReturnsOnCall(i int, result1 bool) {
+	fake.isKeptMutex.Lock()
+	defer fake.isKeptMutex.Unlock()
+	fake.IsKeptStub = nil
+	if fake.isKeptReturnsOnCall == nil {
+		fake.isKeptReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isKeptReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeBuild) IsManuallyTriggered() bool {
 	fake.isManuallyTriggeredMutex.Lock()
 	ret, specificReturn := fake.isManuallyTriggeredReturnsOnCall[len(fake.isManuallyTriggeredArgsForCall)]
@@ -1502,6 +3275,64 @@ func (fake *FakeBuild) IsScheduledReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeBuild) Job() (db.Job, bool, error) {
+	fake.jobMutex.Lock()
+	ret, specificReturn := fake.jobReturnsOnCall[len(fake.jobArgsForCall)]
+	fake.jobArgsForCall = append(fake.jobArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Job", []interface{}{})
+	fake.jobMutex.Unlock()
+	if fake.JobStub != nil {
+		return fake.JobStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.jobReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) JobCallCount() int {
+	fake.jobMutex.RLock()
+	defer fake.jobMutex.RUnlock()
+	return len(fake.jobArgsForCall)
+}
+
+func (fake *FakeBuild) JobCalls(stub func() (db.Job, bool, error)) {
+	fake.jobMutex.Lock()
+	defer fake.jobMutex.Unlock()
+	fake.JobStub = stub
+}
+
+func (fake *FakeBuild) JobReturns(result1 db.Job, result2 bool, result3 error) {
+	fake.jobMutex.Lock()
+	defer fake.jobMutex.Unlock()
+	fake.JobStub = nil
+	fake.jobReturns = struct {
+		result1 db.Job
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) JobReturnsOnCall(i int, result1 db.Job, result2 bool, result3 error) {
+	fake.jobMutex.Lock()
+	defer fake.jobMutex.Unlock()
+	fake.JobStub = nil
+	if fake.jobReturnsOnCall == nil {
+		fake.jobReturnsOnCall = make(map[int]struct {
+			result1 db.Job
+			result2 bool
+			result3 error
+		})
+	}
+	fake.jobReturnsOnCall[i] = struct {
+		result1 db.Job
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuild) JobID() int {
 	fake.jobIDMutex.Lock()
 	ret, specificReturn := fake.jobIDReturnsOnCall[len(fake.jobIDArgsForCall)]
@@ -1592,122 +3423,522 @@ func (fake *FakeBuild) JobNameReturns(result1 string) {
 	}{result1}
 }
 
-func (fake *FakeBuild) JobNameReturnsOnCall(i int, result1 string) {
-	fake.jobNameMutex.Lock()
-	defer fake.jobNameMutex.Unlock()
-	fake.JobNameStub = nil
-	if fake.jobNameReturnsOnCall == nil {
-		fake.jobNameReturnsOnCall = make(map[int]struct {
-			result1 string
+func (fake *FakeBuild) JobNameReturnsOnCall(i int, result1 string) {
+	fake.jobNameMutex.Lock()
+	defer fake.jobNameMutex.Unlock()
+	fake.JobNameStub = nil
+	if fake.jobNameReturnsOnCall == nil {
+		fake.jobNameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.jobNameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) LatestStatusEvent() (event.Envelope, bool, error) {
+	fake.latestStatusEventMutex.Lock()
+	ret, specificReturn := fake.latestStatusEventReturnsOnCall[len(fake.latestStatusEventArgsForCall)]
+	fake.latestStatusEventArgsForCall = append(fake.latestStatusEventArgsForCall, struct {
+	}{})
+	fake.recordInvocation("LatestStatusEvent", []interface{}{})
+	fake.latestStatusEventMutex.Unlock()
+	if fake.LatestStatusEventStub != nil {
+		return fake.LatestStatusEventStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.latestStatusEventReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) LatestStatusEventCallCount() int {
+	fake.latestStatusEventMutex.RLock()
+	defer fake.latestStatusEventMutex.RUnlock()
+	return len(fake.latestStatusEventArgsForCall)
+}
+
+func (fake *FakeBuild) LatestStatusEventCalls(stub func() (event.Envelope, bool, error)) {
+	fake.latestStatusEventMutex.Lock()
+	defer fake.latestStatusEventMutex.Unlock()
+	fake.LatestStatusEventStub = stub
+}
+
+func (fake *FakeBuild) LatestStatusEventReturns(result1 event.Envelope, result2 bool, result3 error) {
+	fake.latestStatusEventMutex.Lock()
+	defer fake.latestStatusEventMutex.Unlock()
+	fake.LatestStatusEventStub = nil
+	fake.latestStatusEventReturns = struct {
+		result1 event.Envelope
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) LatestStatusEventReturnsOnCall(i int, result1 event.Envelope, result2 bool, result3 error) {
+	fake.latestStatusEventMutex.Lock()
+	defer fake.latestStatusEventMutex.Unlock()
+	fake.LatestStatusEventStub = nil
+	if fake.latestStatusEventReturnsOnCall == nil {
+		fake.latestStatusEventReturnsOnCall = make(map[int]struct {
+			result1 event.Envelope
+			result2 bool
+			result3 error
+		})
+	}
+	fake.latestStatusEventReturnsOnCall[i] = struct {
+		result1 event.Envelope
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) MarkAsAborted() error {
+	fake.markAsAbortedMutex.Lock()
+	ret, specificReturn := fake.markAsAbortedReturnsOnCall[len(fake.markAsAbortedArgsForCall)]
+	fake.markAsAbortedArgsForCall = append(fake.markAsAbortedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("MarkAsAborted", []interface{}{})
+	fake.markAsAbortedMutex.Unlock()
+	if fake.MarkAsAbortedStub != nil {
+		return fake.MarkAsAbortedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.markAsAbortedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) MarkAsAbortedCallCount() int {
+	fake.markAsAbortedMutex.RLock()
+	defer fake.markAsAbortedMutex.RUnlock()
+	return len(fake.markAsAbortedArgsForCall)
+}
+
+func (fake *FakeBuild) MarkAsAbortedCalls(stub func() error) {
+	fake.markAsAbortedMutex.Lock()
+	defer fake.markAsAbortedMutex.Unlock()
+	fake.MarkAsAbortedStub = stub
+}
+
+func (fake *FakeBuild) MarkAsAbortedReturns(result1 error) {
+	fake.markAsAbortedMutex.Lock()
+	defer fake.markAsAbortedMutex.Unlock()
+	fake.MarkAsAbortedStub = nil
+	fake.markAsAbortedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) MarkAsAbortedReturnsOnCall(i int, result1 error) {
+	fake.markAsAbortedMutex.Lock()
+	defer fake.markAsAbortedMutex.Unlock()
+	fake.MarkAsAbortedStub = nil
+	if fake.markAsAbortedReturnsOnCall == nil {
+		fake.markAsAbortedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markAsAbortedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) MarkAsAbortedWithReason(arg1 string) error {
+	fake.markAsAbortedWithReasonMutex.Lock()
+	ret, specificReturn := fake.markAsAbortedWithReasonReturnsOnCall[len(fake.markAsAbortedWithReasonArgsForCall)]
+	fake.markAsAbortedWithReasonArgsForCall = append(fake.markAsAbortedWithReasonArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("MarkAsAbortedWithReason", []interface{}{arg1})
+	fake.markAsAbortedWithReasonMutex.Unlock()
+	if fake.MarkAsAbortedWithReasonStub != nil {
+		return fake.MarkAsAbortedWithReasonStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.markAsAbortedWithReasonReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) MarkAsAbortedWithReasonCallCount() int {
+	fake.markAsAbortedWithReasonMutex.RLock()
+	defer fake.markAsAbortedWithReasonMutex.RUnlock()
+	return len(fake.markAsAbortedWithReasonArgsForCall)
+}
+
+func (fake *FakeBuild) MarkAsAbortedWithReasonCalls(stub func(string) error) {
+	fake.markAsAbortedWithReasonMutex.Lock()
+	defer fake.markAsAbortedWithReasonMutex.Unlock()
+	fake.MarkAsAbortedWithReasonStub = stub
+}
+
+func (fake *FakeBuild) MarkAsAbortedWithReasonArgsForCall(i int) string {
+	fake.markAsAbortedWithReasonMutex.RLock()
+	defer fake.markAsAbortedWithReasonMutex.RUnlock()
+	argsForCall := fake.markAsAbortedWithReasonArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) MarkAsAbortedWithReasonReturns(result1 error) {
+	fake.markAsAbortedWithReasonMutex.Lock()
+	defer fake.markAsAbortedWithReasonMutex.Unlock()
+	fake.MarkAsAbortedWithReasonStub = nil
+	fake.markAsAbortedWithReasonReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) MarkAsAbortedWithReasonReturnsOnCall(i int, result1 error) {
+	fake.markAsAbortedWithReasonMutex.Lock()
+	defer fake.markAsAbortedWithReasonMutex.Unlock()
+	fake.MarkAsAbortedWithReasonStub = nil
+	if fake.markAsAbortedWithReasonReturnsOnCall == nil {
+		fake.markAsAbortedWithReasonReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markAsAbortedWithReasonReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) MoveToTeam(arg1 db.Team) error {
+	fake.moveToTeamMutex.Lock()
+	ret, specificReturn := fake.moveToTeamReturnsOnCall[len(fake.moveToTeamArgsForCall)]
+	fake.moveToTeamArgsForCall = append(fake.moveToTeamArgsForCall, struct {
+		arg1 db.Team
+	}{arg1})
+	fake.recordInvocation("MoveToTeam", []interface{}{arg1})
+	fake.moveToTeamMutex.Unlock()
+	if fake.MoveToTeamStub != nil {
+		return fake.MoveToTeamStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.moveToTeamReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) MoveToTeamCallCount() int {
+	fake.moveToTeamMutex.RLock()
+	defer fake.moveToTeamMutex.RUnlock()
+	return len(fake.moveToTeamArgsForCall)
+}
+
+func (fake *FakeBuild) MoveToTeamCalls(stub func(db.Team) error) {
+	fake.moveToTeamMutex.Lock()
+	defer fake.moveToTeamMutex.Unlock()
+	fake.MoveToTeamStub = stub
+}
+
+func (fake *FakeBuild) MoveToTeamArgsForCall(i int) db.Team {
+	fake.moveToTeamMutex.RLock()
+	defer fake.moveToTeamMutex.RUnlock()
+	argsForCall := fake.moveToTeamArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) MoveToTeamReturns(result1 error) {
+	fake.moveToTeamMutex.Lock()
+	defer fake.moveToTeamMutex.Unlock()
+	fake.MoveToTeamStub = nil
+	fake.moveToTeamReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) MoveToTeamReturnsOnCall(i int, result1 error) {
+	fake.moveToTeamMutex.Lock()
+	defer fake.moveToTeamMutex.Unlock()
+	fake.MoveToTeamStub = nil
+	if fake.moveToTeamReturnsOnCall == nil {
+		fake.moveToTeamReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.moveToTeamReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) Name() string {
+	fake.nameMutex.Lock()
+	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
+	fake.nameArgsForCall = append(fake.nameArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Name", []interface{}{})
+	fake.nameMutex.Unlock()
+	if fake.NameStub != nil {
+		return fake.NameStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.nameReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) NameCallCount() int {
+	fake.nameMutex.RLock()
+	defer fake.nameMutex.RUnlock()
+	return len(fake.nameArgsForCall)
+}
+
+func (fake *FakeBuild) NameCalls(stub func() string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = stub
+}
+
+func (fake *FakeBuild) NameReturns(result1 string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = nil
+	fake.nameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) NameReturnsOnCall(i int, result1 string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = nil
+	if fake.nameReturnsOnCall == nil {
+		fake.nameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.nameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) NotifyOnCompletion() (db.Notifier, error) {
+	fake.notifyOnCompletionMutex.Lock()
+	ret, specificReturn := fake.notifyOnCompletionReturnsOnCall[len(fake.notifyOnCompletionArgsForCall)]
+	fake.notifyOnCompletionArgsForCall = append(fake.notifyOnCompletionArgsForCall, struct {
+	}{})
+	fake.recordInvocation("NotifyOnCompletion", []interface{}{})
+	fake.notifyOnCompletionMutex.Unlock()
+	if fake.NotifyOnCompletionStub != nil {
+		return fake.NotifyOnCompletionStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.notifyOnCompletionReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) NotifyOnCompletionCallCount() int {
+	fake.notifyOnCompletionMutex.RLock()
+	defer fake.notifyOnCompletionMutex.RUnlock()
+	return len(fake.notifyOnCompletionArgsForCall)
+}
+
+func (fake *FakeBuild) NotifyOnCompletionCalls(stub func() (db.Notifier, error)) {
+	fake.notifyOnCompletionMutex.Lock()
+	defer fake.notifyOnCompletionMutex.Unlock()
+	fake.NotifyOnCompletionStub = stub
+}
+
+func (fake *FakeBuild) NotifyOnCompletionReturns(result1 db.Notifier, result2 error) {
+	fake.notifyOnCompletionMutex.Lock()
+	defer fake.notifyOnCompletionMutex.Unlock()
+	fake.NotifyOnCompletionStub = nil
+	fake.notifyOnCompletionReturns = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) NotifyOnCompletionReturnsOnCall(i int, result1 db.Notifier, result2 error) {
+	fake.notifyOnCompletionMutex.Lock()
+	defer fake.notifyOnCompletionMutex.Unlock()
+	fake.NotifyOnCompletionStub = nil
+	if fake.notifyOnCompletionReturnsOnCall == nil {
+		fake.notifyOnCompletionReturnsOnCall = make(map[int]struct {
+			result1 db.Notifier
+			result2 error
+		})
+	}
+	fake.notifyOnCompletionReturnsOnCall[i] = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) Origin() db.BuildOrigin {
+	fake.originMutex.Lock()
+	ret, specificReturn := fake.originReturnsOnCall[len(fake.originArgsForCall)]
+	fake.originArgsForCall = append(fake.originArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Origin", []interface{}{})
+	fake.originMutex.Unlock()
+	if fake.OriginStub != nil {
+		return fake.OriginStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.originReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) OriginCallCount() int {
+	fake.originMutex.RLock()
+	defer fake.originMutex.RUnlock()
+	return len(fake.originArgsForCall)
+}
+
+func (fake *FakeBuild) OriginCalls(stub func() db.BuildOrigin) {
+	fake.originMutex.Lock()
+	defer fake.originMutex.Unlock()
+	fake.OriginStub = stub
+}
+
+func (fake *FakeBuild) OriginReturns(result1 db.BuildOrigin) {
+	fake.originMutex.Lock()
+	defer fake.originMutex.Unlock()
+	fake.OriginStub = nil
+	fake.originReturns = struct {
+		result1 db.BuildOrigin
+	}{result1}
+}
+
+func (fake *FakeBuild) OriginReturnsOnCall(i int, result1 db.BuildOrigin) {
+	fake.originMutex.Lock()
+	defer fake.originMutex.Unlock()
+	fake.OriginStub = nil
+	if fake.originReturnsOnCall == nil {
+		fake.originReturnsOnCall = make(map[int]struct {
+			result1 db.BuildOrigin
 		})
 	}
-	fake.jobNameReturnsOnCall[i] = struct {
-		result1 string
+	fake.originReturnsOnCall[i] = struct {
+		result1 db.BuildOrigin
 	}{result1}
 }
 
-func (fake *FakeBuild) MarkAsAborted() error {
-	fake.markAsAbortedMutex.Lock()
-	ret, specificReturn := fake.markAsAbortedReturnsOnCall[len(fake.markAsAbortedArgsForCall)]
-	fake.markAsAbortedArgsForCall = append(fake.markAsAbortedArgsForCall, struct {
+func (fake *FakeBuild) OutputCount() int {
+	fake.outputCountMutex.Lock()
+	ret, specificReturn := fake.outputCountReturnsOnCall[len(fake.outputCountArgsForCall)]
+	fake.outputCountArgsForCall = append(fake.outputCountArgsForCall, struct {
 	}{})
-	fake.recordInvocation("MarkAsAborted", []interface{}{})
-	fake.markAsAbortedMutex.Unlock()
-	if fake.MarkAsAbortedStub != nil {
-		return fake.MarkAsAbortedStub()
+	fake.recordInvocation("OutputCount", []interface{}{})
+	fake.outputCountMutex.Unlock()
+	if fake.OutputCountStub != nil {
+		return fake.OutputCountStub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.markAsAbortedReturns
+	fakeReturns := fake.outputCountReturns
 	return fakeReturns.result1
 }
 
-func (fake *FakeBuild) MarkAsAbortedCallCount() int {
-	fake.markAsAbortedMutex.RLock()
-	defer fake.markAsAbortedMutex.RUnlock()
-	return len(fake.markAsAbortedArgsForCall)
+func (fake *FakeBuild) OutputCountCallCount() int {
+	fake.outputCountMutex.RLock()
+	defer fake.outputCountMutex.RUnlock()
+	return len(fake.outputCountArgsForCall)
 }
 
-func (fake *FakeBuild) MarkAsAbortedCalls(stub func() error) {
-	fake.markAsAbortedMutex.Lock()
-	defer fake.markAsAbortedMutex.Unlock()
-	fake.MarkAsAbortedStub = stub
+func (fake *FakeBuild) OutputCountCalls(stub func() int) {
+	fake.outputCountMutex.Lock()
+	defer fake.outputCountMutex.Unlock()
+	fake.OutputCountStub = stub
 }
 
-func (fake *FakeBuild) MarkAsAbortedReturns(result1 error) {
-	fake.markAsAbortedMutex.Lock()
-	defer fake.markAsAbortedMutex.Unlock()
-	fake.MarkAsAbortedStub = nil
-	fake.markAsAbortedReturns = struct {
-		result1 error
+func (fake *FakeBuild) OutputCountReturns(result1 int) {
+	fake.outputCountMutex.Lock()
+	defer fake.outputCountMutex.Unlock()
+	fake.OutputCountStub = nil
+	fake.outputCountReturns = struct {
+		result1 int
 	}{result1}
 }
 
-func (fake *FakeBuild) MarkAsAbortedReturnsOnCall(i int, result1 error) {
-	fake.markAsAbortedMutex.Lock()
-	defer fake.markAsAbortedMutex.Unlock()
-	fake.MarkAsAbortedStub = nil
-	if fake.markAsAbortedReturnsOnCall == nil {
-		fake.markAsAbortedReturnsOnCall = make(map[int]struct {
-			result1 error
+func (fake *FakeBuild) OutputCountReturnsOnCall(i int, result1 int) {
+	fake.outputCountMutex.Lock()
+	defer fake.outputCountMutex.Unlock()
+	fake.OutputCountStub = nil
+	if fake.outputCountReturnsOnCall == nil {
+		fake.outputCountReturnsOnCall = make(map[int]struct {
+			result1 int
 		})
 	}
-	fake.markAsAbortedReturnsOnCall[i] = struct {
-		result1 error
+	fake.outputCountReturnsOnCall[i] = struct {
+		result1 int
 	}{result1}
 }
 
-func (fake *FakeBuild) Name() string {
-	fake.nameMutex.Lock()
-	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
-	fake.nameArgsForCall = append(fake.nameArgsForCall, struct {
-	}{})
-	fake.recordInvocation("Name", []interface{}{})
-	fake.nameMutex.Unlock()
-	if fake.NameStub != nil {
-		return fake.NameStub()
+func (fake *FakeBuild) OutputsSince(arg1 int) ([]db.BuildOutput, error) {
+	fake.outputsSinceMutex.Lock()
+	ret, specificReturn := fake.outputsSinceReturnsOnCall[len(fake.outputsSinceArgsForCall)]
+	fake.outputsSinceArgsForCall = append(fake.outputsSinceArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("OutputsSince", []interface{}{arg1})
+	fake.outputsSinceMutex.Unlock()
+	if fake.OutputsSinceStub != nil {
+		return fake.OutputsSinceStub(arg1)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.nameReturns
-	return fakeReturns.result1
+	fakeReturns := fake.outputsSinceReturns
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeBuild) NameCallCount() int {
-	fake.nameMutex.RLock()
-	defer fake.nameMutex.RUnlock()
-	return len(fake.nameArgsForCall)
+func (fake *FakeBuild) OutputsSinceCallCount() int {
+	fake.outputsSinceMutex.RLock()
+	defer fake.outputsSinceMutex.RUnlock()
+	return len(fake.outputsSinceArgsForCall)
 }
 
-func (fake *FakeBuild) NameCalls(stub func() string) {
-	fake.nameMutex.Lock()
-	defer fake.nameMutex.Unlock()
-	fake.NameStub = stub
+func (fake *FakeBuild) OutputsSinceCalls(stub func(int) ([]db.BuildOutput, error)) {
+	fake.outputsSinceMutex.Lock()
+	defer fake.outputsSinceMutex.Unlock()
+	fake.OutputsSinceStub = stub
 }
 
-func (fake *FakeBuild) NameReturns(result1 string) {
-	fake.nameMutex.Lock()
-	defer fake.nameMutex.Unlock()
-	fake.NameStub = nil
-	fake.nameReturns = struct {
-		result1 string
-	}{result1}
+func (fake *FakeBuild) OutputsSinceArgsForCall(i int) int {
+	fake.outputsSinceMutex.RLock()
+	defer fake.outputsSinceMutex.RUnlock()
+	argsForCall := fake.outputsSinceArgsForCall[i]
+	return argsForCall.arg1
 }
 
-func (fake *FakeBuild) NameReturnsOnCall(i int, result1 string) {
-	fake.nameMutex.Lock()
-	defer fake.nameMutex.Unlock()
-	fake.NameStub = nil
-	if fake.nameReturnsOnCall == nil {
-		fake.nameReturnsOnCall = make(map[int]struct {
-			result1 string
+func (fake *FakeBuild) OutputsSinceReturns(result1 []db.BuildOutput, result2 error) {
+	fake.outputsSinceMutex.Lock()
+	defer fake.outputsSinceMutex.Unlock()
+	fake.OutputsSinceStub = nil
+	fake.outputsSinceReturns = struct {
+		result1 []db.BuildOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) OutputsSinceReturnsOnCall(i int, result1 []db.BuildOutput, result2 error) {
+	fake.outputsSinceMutex.Lock()
+	defer fake.outputsSinceMutex.Unlock()
+	fake.OutputsSinceStub = nil
+	if fake.outputsSinceReturnsOnCall == nil {
+		fake.outputsSinceReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildOutput
+			result2 error
 		})
 	}
-	fake.nameReturnsOnCall[i] = struct {
-		result1 string
-	}{result1}
+	fake.outputsSinceReturnsOnCall[i] = struct {
+		result1 []db.BuildOutput
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakeBuild) Pipeline() (db.Pipeline, bool, error) {
@@ -1930,6 +4161,64 @@ func (fake *FakeBuild) PreparationReturnsOnCall(i int, result1 db.BuildPreparati
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) PreparationCached() (db.BuildPreparation, bool, error) {
+	fake.preparationCachedMutex.Lock()
+	ret, specificReturn := fake.preparationCachedReturnsOnCall[len(fake.preparationCachedArgsForCall)]
+	fake.preparationCachedArgsForCall = append(fake.preparationCachedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PreparationCached", []interface{}{})
+	fake.preparationCachedMutex.Unlock()
+	if fake.PreparationCachedStub != nil {
+		return fake.PreparationCachedStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.preparationCachedReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) PreparationCachedCallCount() int {
+	fake.preparationCachedMutex.RLock()
+	defer fake.preparationCachedMutex.RUnlock()
+	return len(fake.preparationCachedArgsForCall)
+}
+
+func (fake *FakeBuild) PreparationCachedCalls(stub func() (db.BuildPreparation, bool, error)) {
+	fake.preparationCachedMutex.Lock()
+	defer fake.preparationCachedMutex.Unlock()
+	fake.PreparationCachedStub = stub
+}
+
+func (fake *FakeBuild) PreparationCachedReturns(result1 db.BuildPreparation, result2 bool, result3 error) {
+	fake.preparationCachedMutex.Lock()
+	defer fake.preparationCachedMutex.Unlock()
+	fake.PreparationCachedStub = nil
+	fake.preparationCachedReturns = struct {
+		result1 db.BuildPreparation
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) PreparationCachedReturnsOnCall(i int, result1 db.BuildPreparation, result2 bool, result3 error) {
+	fake.preparationCachedMutex.Lock()
+	defer fake.preparationCachedMutex.Unlock()
+	fake.PreparationCachedStub = nil
+	if fake.preparationCachedReturnsOnCall == nil {
+		fake.preparationCachedReturnsOnCall = make(map[int]struct {
+			result1 db.BuildPreparation
+			result2 bool
+			result3 error
+		})
+	}
+	fake.preparationCachedReturnsOnCall[i] = struct {
+		result1 db.BuildPreparation
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuild) PrivatePlan() atc.Plan {
 	fake.privatePlanMutex.Lock()
 	ret, specificReturn := fake.privatePlanReturnsOnCall[len(fake.privatePlanArgsForCall)]
@@ -1982,6 +4271,64 @@ func (fake *FakeBuild) PrivatePlanReturnsOnCall(i int, result1 atc.Plan) {
 	}{result1}
 }
 
+func (fake *FakeBuild) PrivatePlanForResume() (atc.Plan, bool, error) {
+	fake.privatePlanForResumeMutex.Lock()
+	ret, specificReturn := fake.privatePlanForResumeReturnsOnCall[len(fake.privatePlanForResumeArgsForCall)]
+	fake.privatePlanForResumeArgsForCall = append(fake.privatePlanForResumeArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PrivatePlanForResume", []interface{}{})
+	fake.privatePlanForResumeMutex.Unlock()
+	if fake.PrivatePlanForResumeStub != nil {
+		return fake.PrivatePlanForResumeStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.privatePlanForResumeReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) PrivatePlanForResumeCallCount() int {
+	fake.privatePlanForResumeMutex.RLock()
+	defer fake.privatePlanForResumeMutex.RUnlock()
+	return len(fake.privatePlanForResumeArgsForCall)
+}
+
+func (fake *FakeBuild) PrivatePlanForResumeCalls(stub func() (atc.Plan, bool, error)) {
+	fake.privatePlanForResumeMutex.Lock()
+	defer fake.privatePlanForResumeMutex.Unlock()
+	fake.PrivatePlanForResumeStub = stub
+}
+
+func (fake *FakeBuild) PrivatePlanForResumeReturns(result1 atc.Plan, result2 bool, result3 error) {
+	fake.privatePlanForResumeMutex.Lock()
+	defer fake.privatePlanForResumeMutex.Unlock()
+	fake.PrivatePlanForResumeStub = nil
+	fake.privatePlanForResumeReturns = struct {
+		result1 atc.Plan
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) PrivatePlanForResumeReturnsOnCall(i int, result1 atc.Plan, result2 bool, result3 error) {
+	fake.privatePlanForResumeMutex.Lock()
+	defer fake.privatePlanForResumeMutex.Unlock()
+	fake.PrivatePlanForResumeStub = nil
+	if fake.privatePlanForResumeReturnsOnCall == nil {
+		fake.privatePlanForResumeReturnsOnCall = make(map[int]struct {
+			result1 atc.Plan
+			result2 bool
+			result3 error
+		})
+	}
+	fake.privatePlanForResumeReturnsOnCall[i] = struct {
+		result1 atc.Plan
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuild) PublicPlan() *json.RawMessage {
 	fake.publicPlanMutex.Lock()
 	ret, specificReturn := fake.publicPlanReturnsOnCall[len(fake.publicPlanArgsForCall)]
@@ -2081,8 +4428,244 @@ func (fake *FakeBuild) ReapTimeReturnsOnCall(i int, result1 time.Time) {
 			result1 time.Time
 		})
 	}
-	fake.reapTimeReturnsOnCall[i] = struct {
-		result1 time.Time
+	fake.reapTimeReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeBuild) Reapable(arg1 db.ReapPolicy) bool {
+	fake.reapableMutex.Lock()
+	ret, specificReturn := fake.reapableReturnsOnCall[len(fake.reapableArgsForCall)]
+	fake.reapableArgsForCall = append(fake.reapableArgsForCall, struct {
+		arg1 db.ReapPolicy
+	}{arg1})
+	fake.recordInvocation("Reapable", []interface{}{arg1})
+	fake.reapableMutex.Unlock()
+	if fake.ReapableStub != nil {
+		return fake.ReapableStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.reapableReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ReapableCallCount() int {
+	fake.reapableMutex.RLock()
+	defer fake.reapableMutex.RUnlock()
+	return len(fake.reapableArgsForCall)
+}
+
+func (fake *FakeBuild) ReapableCalls(stub func(db.ReapPolicy) bool) {
+	fake.reapableMutex.Lock()
+	defer fake.reapableMutex.Unlock()
+	fake.ReapableStub = stub
+}
+
+func (fake *FakeBuild) ReapableArgsForCall(i int) db.ReapPolicy {
+	fake.reapableMutex.RLock()
+	defer fake.reapableMutex.RUnlock()
+	argsForCall := fake.reapableArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) ReapableReturns(result1 bool) {
+	fake.reapableMutex.Lock()
+	defer fake.reapableMutex.Unlock()
+	fake.ReapableStub = nil
+	fake.reapableReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) ReapableReturnsOnCall(i int, result1 bool) {
+	fake.reapableMutex.Lock()
+	defer fake.reapableMutex.Unlock()
+	fake.ReapableStub = nil
+	if fake.reapableReturnsOnCall == nil {
+		fake.reapableReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.reapableReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) RedactedEvents(arg1 uint, arg2 db.SecretRedactor) (db.EventSource, error) {
+	fake.redactedEventsMutex.Lock()
+	ret, specificReturn := fake.redactedEventsReturnsOnCall[len(fake.redactedEventsArgsForCall)]
+	fake.redactedEventsArgsForCall = append(fake.redactedEventsArgsForCall, struct {
+		arg1 uint
+		arg2 db.SecretRedactor
+	}{arg1, arg2})
+	fake.recordInvocation("RedactedEvents", []interface{}{arg1, arg2})
+	fake.redactedEventsMutex.Unlock()
+	if fake.RedactedEventsStub != nil {
+		return fake.RedactedEventsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.redactedEventsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) RedactedEventsCallCount() int {
+	fake.redactedEventsMutex.RLock()
+	defer fake.redactedEventsMutex.RUnlock()
+	return len(fake.redactedEventsArgsForCall)
+}
+
+func (fake *FakeBuild) RedactedEventsCalls(stub func(uint, db.SecretRedactor) (db.EventSource, error)) {
+	fake.redactedEventsMutex.Lock()
+	defer fake.redactedEventsMutex.Unlock()
+	fake.RedactedEventsStub = stub
+}
+
+func (fake *FakeBuild) RedactedEventsArgsForCall(i int) (uint, db.SecretRedactor) {
+	fake.redactedEventsMutex.RLock()
+	defer fake.redactedEventsMutex.RUnlock()
+	argsForCall := fake.redactedEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) RedactedEventsReturns(result1 db.EventSource, result2 error) {
+	fake.redactedEventsMutex.Lock()
+	defer fake.redactedEventsMutex.Unlock()
+	fake.RedactedEventsStub = nil
+	fake.redactedEventsReturns = struct {
+		result1 db.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) RedactedEventsReturnsOnCall(i int, result1 db.EventSource, result2 error) {
+	fake.redactedEventsMutex.Lock()
+	defer fake.redactedEventsMutex.Unlock()
+	fake.RedactedEventsStub = nil
+	if fake.redactedEventsReturnsOnCall == nil {
+		fake.redactedEventsReturnsOnCall = make(map[int]struct {
+			result1 db.EventSource
+			result2 error
+		})
+	}
+	fake.redactedEventsReturnsOnCall[i] = struct {
+		result1 db.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) RegisterResourceCacheUse(arg1 db.UsedResourceCache) error {
+	fake.registerResourceCacheUseMutex.Lock()
+	ret, specificReturn := fake.registerResourceCacheUseReturnsOnCall[len(fake.registerResourceCacheUseArgsForCall)]
+	fake.registerResourceCacheUseArgsForCall = append(fake.registerResourceCacheUseArgsForCall, struct {
+		arg1 db.UsedResourceCache
+	}{arg1})
+	fake.recordInvocation("RegisterResourceCacheUse", []interface{}{arg1})
+	fake.registerResourceCacheUseMutex.Unlock()
+	if fake.RegisterResourceCacheUseStub != nil {
+		return fake.RegisterResourceCacheUseStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.registerResourceCacheUseReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) RegisterResourceCacheUseCallCount() int {
+	fake.registerResourceCacheUseMutex.RLock()
+	defer fake.registerResourceCacheUseMutex.RUnlock()
+	return len(fake.registerResourceCacheUseArgsForCall)
+}
+
+func (fake *FakeBuild) RegisterResourceCacheUseCalls(stub func(db.UsedResourceCache) error) {
+	fake.registerResourceCacheUseMutex.Lock()
+	defer fake.registerResourceCacheUseMutex.Unlock()
+	fake.RegisterResourceCacheUseStub = stub
+}
+
+func (fake *FakeBuild) RegisterResourceCacheUseArgsForCall(i int) db.UsedResourceCache {
+	fake.registerResourceCacheUseMutex.RLock()
+	defer fake.registerResourceCacheUseMutex.RUnlock()
+	argsForCall := fake.registerResourceCacheUseArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) RegisterResourceCacheUseReturns(result1 error) {
+	fake.registerResourceCacheUseMutex.Lock()
+	defer fake.registerResourceCacheUseMutex.Unlock()
+	fake.RegisterResourceCacheUseStub = nil
+	fake.registerResourceCacheUseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) RegisterResourceCacheUseReturnsOnCall(i int, result1 error) {
+	fake.registerResourceCacheUseMutex.Lock()
+	defer fake.registerResourceCacheUseMutex.Unlock()
+	fake.RegisterResourceCacheUseStub = nil
+	if fake.registerResourceCacheUseReturnsOnCall == nil {
+		fake.registerResourceCacheUseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.registerResourceCacheUseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) Release() error {
+	fake.releaseMutex.Lock()
+	ret, specificReturn := fake.releaseReturnsOnCall[len(fake.releaseArgsForCall)]
+	fake.releaseArgsForCall = append(fake.releaseArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Release", []interface{}{})
+	fake.releaseMutex.Unlock()
+	if fake.ReleaseStub != nil {
+		return fake.ReleaseStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.releaseReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ReleaseCallCount() int {
+	fake.releaseMutex.RLock()
+	defer fake.releaseMutex.RUnlock()
+	return len(fake.releaseArgsForCall)
+}
+
+func (fake *FakeBuild) ReleaseCalls(stub func() error) {
+	fake.releaseMutex.Lock()
+	defer fake.releaseMutex.Unlock()
+	fake.ReleaseStub = stub
+}
+
+func (fake *FakeBuild) ReleaseReturns(result1 error) {
+	fake.releaseMutex.Lock()
+	defer fake.releaseMutex.Unlock()
+	fake.ReleaseStub = nil
+	fake.releaseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) ReleaseReturnsOnCall(i int, result1 error) {
+	fake.releaseMutex.Lock()
+	defer fake.releaseMutex.Unlock()
+	fake.ReleaseStub = nil
+	if fake.releaseReturnsOnCall == nil {
+		fake.releaseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releaseReturnsOnCall[i] = struct {
+		result1 error
 	}{result1}
 }
 
@@ -2141,6 +4724,174 @@ func (fake *FakeBuild) ReloadReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) ReloadChanged() (bool, bool, error) {
+	fake.reloadChangedMutex.Lock()
+	ret, specificReturn := fake.reloadChangedReturnsOnCall[len(fake.reloadChangedArgsForCall)]
+	fake.reloadChangedArgsForCall = append(fake.reloadChangedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ReloadChanged", []interface{}{})
+	fake.reloadChangedMutex.Unlock()
+	if fake.ReloadChangedStub != nil {
+		return fake.ReloadChangedStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.reloadChangedReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) ReloadChangedCallCount() int {
+	fake.reloadChangedMutex.RLock()
+	defer fake.reloadChangedMutex.RUnlock()
+	return len(fake.reloadChangedArgsForCall)
+}
+
+func (fake *FakeBuild) ReloadChangedCalls(stub func() (bool, bool, error)) {
+	fake.reloadChangedMutex.Lock()
+	defer fake.reloadChangedMutex.Unlock()
+	fake.ReloadChangedStub = stub
+}
+
+func (fake *FakeBuild) ReloadChangedReturns(result1 bool, result2 bool, result3 error) {
+	fake.reloadChangedMutex.Lock()
+	defer fake.reloadChangedMutex.Unlock()
+	fake.ReloadChangedStub = nil
+	fake.reloadChangedReturns = struct {
+		result1 bool
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) ReloadChangedReturnsOnCall(i int, result1 bool, result2 bool, result3 error) {
+	fake.reloadChangedMutex.Lock()
+	defer fake.reloadChangedMutex.Unlock()
+	fake.ReloadChangedStub = nil
+	if fake.reloadChangedReturnsOnCall == nil {
+		fake.reloadChangedReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 bool
+			result3 error
+		})
+	}
+	fake.reloadChangedReturnsOnCall[i] = struct {
+		result1 bool
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) Reruns() ([]db.Build, error) {
+	fake.rerunsMutex.Lock()
+	ret, specificReturn := fake.rerunsReturnsOnCall[len(fake.rerunsArgsForCall)]
+	fake.rerunsArgsForCall = append(fake.rerunsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Reruns", []interface{}{})
+	fake.rerunsMutex.Unlock()
+	if fake.RerunsStub != nil {
+		return fake.RerunsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.rerunsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) RerunsCallCount() int {
+	fake.rerunsMutex.RLock()
+	defer fake.rerunsMutex.RUnlock()
+	return len(fake.rerunsArgsForCall)
+}
+
+func (fake *FakeBuild) RerunsCalls(stub func() ([]db.Build, error)) {
+	fake.rerunsMutex.Lock()
+	defer fake.rerunsMutex.Unlock()
+	fake.RerunsStub = stub
+}
+
+func (fake *FakeBuild) RerunsReturns(result1 []db.Build, result2 error) {
+	fake.rerunsMutex.Lock()
+	defer fake.rerunsMutex.Unlock()
+	fake.RerunsStub = nil
+	fake.rerunsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) RerunsReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.rerunsMutex.Lock()
+	defer fake.rerunsMutex.Unlock()
+	fake.RerunsStub = nil
+	if fake.rerunsReturnsOnCall == nil {
+		fake.rerunsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.rerunsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ResourceCacheUses() ([]db.UsedResourceCache, error) {
+	fake.resourceCacheUsesMutex.Lock()
+	ret, specificReturn := fake.resourceCacheUsesReturnsOnCall[len(fake.resourceCacheUsesArgsForCall)]
+	fake.resourceCacheUsesArgsForCall = append(fake.resourceCacheUsesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ResourceCacheUses", []interface{}{})
+	fake.resourceCacheUsesMutex.Unlock()
+	if fake.ResourceCacheUsesStub != nil {
+		return fake.ResourceCacheUsesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.resourceCacheUsesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) ResourceCacheUsesCallCount() int {
+	fake.resourceCacheUsesMutex.RLock()
+	defer fake.resourceCacheUsesMutex.RUnlock()
+	return len(fake.resourceCacheUsesArgsForCall)
+}
+
+func (fake *FakeBuild) ResourceCacheUsesCalls(stub func() ([]db.UsedResourceCache, error)) {
+	fake.resourceCacheUsesMutex.Lock()
+	defer fake.resourceCacheUsesMutex.Unlock()
+	fake.ResourceCacheUsesStub = stub
+}
+
+func (fake *FakeBuild) ResourceCacheUsesReturns(result1 []db.UsedResourceCache, result2 error) {
+	fake.resourceCacheUsesMutex.Lock()
+	defer fake.resourceCacheUsesMutex.Unlock()
+	fake.ResourceCacheUsesStub = nil
+	fake.resourceCacheUsesReturns = struct {
+		result1 []db.UsedResourceCache
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ResourceCacheUsesReturnsOnCall(i int, result1 []db.UsedResourceCache, result2 error) {
+	fake.resourceCacheUsesMutex.Lock()
+	defer fake.resourceCacheUsesMutex.Unlock()
+	fake.ResourceCacheUsesStub = nil
+	if fake.resourceCacheUsesReturnsOnCall == nil {
+		fake.resourceCacheUsesReturnsOnCall = make(map[int]struct {
+			result1 []db.UsedResourceCache
+			result2 error
+		})
+	}
+	fake.resourceCacheUsesReturnsOnCall[i] = struct {
+		result1 []db.UsedResourceCache
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Resources() ([]db.BuildInput, []db.BuildOutput, error) {
 	fake.resourcesMutex.Lock()
 	ret, specificReturn := fake.resourcesReturnsOnCall[len(fake.resourcesArgsForCall)]
@@ -2199,6 +4950,116 @@ func (fake *FakeBuild) ResourcesReturnsOnCall(i int, result1 []db.BuildInput, re
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) ResourcesWithMetadata() ([]db.BuildInput, []db.BuildOutput, error) {
+	fake.resourcesWithMetadataMutex.Lock()
+	ret, specificReturn := fake.resourcesWithMetadataReturnsOnCall[len(fake.resourcesWithMetadataArgsForCall)]
+	fake.resourcesWithMetadataArgsForCall = append(fake.resourcesWithMetadataArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ResourcesWithMetadata", []interface{}{})
+	fake.resourcesWithMetadataMutex.Unlock()
+	if fake.ResourcesWithMetadataStub != nil {
+		return fake.ResourcesWithMetadataStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.resourcesWithMetadataReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) ResourcesWithMetadataCallCount() int {
+	fake.resourcesWithMetadataMutex.RLock()
+	defer fake.resourcesWithMetadataMutex.RUnlock()
+	return len(fake.resourcesWithMetadataArgsForCall)
+}
+
+func (fake *FakeBuild) ResourcesWithMetadataCalls(stub func() ([]db.BuildInput, []db.BuildOutput, error)) {
+	fake.resourcesWithMetadataMutex.Lock()
+	defer fake.resourcesWithMetadataMutex.Unlock()
+	fake.ResourcesWithMetadataStub = stub
+}
+
+func (fake *FakeBuild) ResourcesWithMetadataReturns(result1 []db.BuildInput, result2 []db.BuildOutput, result3 error) {
+	fake.resourcesWithMetadataMutex.Lock()
+	defer fake.resourcesWithMetadataMutex.Unlock()
+	fake.ResourcesWithMetadataStub = nil
+	fake.resourcesWithMetadataReturns = struct {
+		result1 []db.BuildInput
+		result2 []db.BuildOutput
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) ResourcesWithMetadataReturnsOnCall(i int, result1 []db.BuildInput, result2 []db.BuildOutput, result3 error) {
+	fake.resourcesWithMetadataMutex.Lock()
+	defer fake.resourcesWithMetadataMutex.Unlock()
+	fake.ResourcesWithMetadataStub = nil
+	if fake.resourcesWithMetadataReturnsOnCall == nil {
+		fake.resourcesWithMetadataReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildInput
+			result2 []db.BuildOutput
+			result3 error
+		})
+	}
+	fake.resourcesWithMetadataReturnsOnCall[i] = struct {
+		result1 []db.BuildInput
+		result2 []db.BuildOutput
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) RetryCount() int {
+	fake.retryCountMutex.Lock()
+	ret, specificReturn := fake.retryCountReturnsOnCall[len(fake.retryCountArgsForCall)]
+	fake.retryCountArgsForCall = append(fake.retryCountArgsForCall, struct {
+	}{})
+	fake.recordInvocation("RetryCount", []interface{}{})
+	fake.retryCountMutex.Unlock()
+	if fake.RetryCountStub != nil {
+		return fake.RetryCountStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.retryCountReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) RetryCountCallCount() int {
+	fake.retryCountMutex.RLock()
+	defer fake.retryCountMutex.RUnlock()
+	return len(fake.retryCountArgsForCall)
+}
+
+func (fake *FakeBuild) RetryCountCalls(stub func() int) {
+	fake.retryCountMutex.Lock()
+	defer fake.retryCountMutex.Unlock()
+	fake.RetryCountStub = stub
+}
+
+func (fake *FakeBuild) RetryCountReturns(result1 int) {
+	fake.retryCountMutex.Lock()
+	defer fake.retryCountMutex.Unlock()
+	fake.RetryCountStub = nil
+	fake.retryCountReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) RetryCountReturnsOnCall(i int, result1 int) {
+	fake.retryCountMutex.Lock()
+	defer fake.retryCountMutex.Unlock()
+	fake.RetryCountStub = nil
+	if fake.retryCountReturnsOnCall == nil {
+		fake.retryCountReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.retryCountReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeBuild) SaveEvent(arg1 atc.Event) error {
 	fake.saveEventMutex.Lock()
 	ret, specificReturn := fake.saveEventReturnsOnCall[len(fake.saveEventArgsForCall)]
@@ -2259,6 +5120,132 @@ func (fake *FakeBuild) SaveEventReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) SaveEventWithSeq(arg1 int64, arg2 atc.Event) error {
+	fake.saveEventWithSeqMutex.Lock()
+	ret, specificReturn := fake.saveEventWithSeqReturnsOnCall[len(fake.saveEventWithSeqArgsForCall)]
+	fake.saveEventWithSeqArgsForCall = append(fake.saveEventWithSeqArgsForCall, struct {
+		arg1 int64
+		arg2 atc.Event
+	}{arg1, arg2})
+	fake.recordInvocation("SaveEventWithSeq", []interface{}{arg1, arg2})
+	fake.saveEventWithSeqMutex.Unlock()
+	if fake.SaveEventWithSeqStub != nil {
+		return fake.SaveEventWithSeqStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveEventWithSeqReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveEventWithSeqCallCount() int {
+	fake.saveEventWithSeqMutex.RLock()
+	defer fake.saveEventWithSeqMutex.RUnlock()
+	return len(fake.saveEventWithSeqArgsForCall)
+}
+
+func (fake *FakeBuild) SaveEventWithSeqCalls(stub func(int64, atc.Event) error) {
+	fake.saveEventWithSeqMutex.Lock()
+	defer fake.saveEventWithSeqMutex.Unlock()
+	fake.SaveEventWithSeqStub = stub
+}
+
+func (fake *FakeBuild) SaveEventWithSeqArgsForCall(i int) (int64, atc.Event) {
+	fake.saveEventWithSeqMutex.RLock()
+	defer fake.saveEventWithSeqMutex.RUnlock()
+	argsForCall := fake.saveEventWithSeqArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) SaveEventWithSeqReturns(result1 error) {
+	fake.saveEventWithSeqMutex.Lock()
+	defer fake.saveEventWithSeqMutex.Unlock()
+	fake.SaveEventWithSeqStub = nil
+	fake.saveEventWithSeqReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveEventWithSeqReturnsOnCall(i int, result1 error) {
+	fake.saveEventWithSeqMutex.Lock()
+	defer fake.saveEventWithSeqMutex.Unlock()
+	fake.SaveEventWithSeqStub = nil
+	if fake.saveEventWithSeqReturnsOnCall == nil {
+		fake.saveEventWithSeqReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveEventWithSeqReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveEvents(arg1 []atc.Event) error {
+	var arg1Copy []atc.Event
+	if arg1 != nil {
+		arg1Copy = make([]atc.Event, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.saveEventsMutex.Lock()
+	ret, specificReturn := fake.saveEventsReturnsOnCall[len(fake.saveEventsArgsForCall)]
+	fake.saveEventsArgsForCall = append(fake.saveEventsArgsForCall, struct {
+		arg1 []atc.Event
+	}{arg1Copy})
+	fake.recordInvocation("SaveEvents", []interface{}{arg1Copy})
+	fake.saveEventsMutex.Unlock()
+	if fake.SaveEventsStub != nil {
+		return fake.SaveEventsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveEventsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveEventsCallCount() int {
+	fake.saveEventsMutex.RLock()
+	defer fake.saveEventsMutex.RUnlock()
+	return len(fake.saveEventsArgsForCall)
+}
+
+func (fake *FakeBuild) SaveEventsCalls(stub func([]atc.Event) error) {
+	fake.saveEventsMutex.Lock()
+	defer fake.saveEventsMutex.Unlock()
+	fake.SaveEventsStub = stub
+}
+
+func (fake *FakeBuild) SaveEventsArgsForCall(i int) []atc.Event {
+	fake.saveEventsMutex.RLock()
+	defer fake.saveEventsMutex.RUnlock()
+	argsForCall := fake.saveEventsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SaveEventsReturns(result1 error) {
+	fake.saveEventsMutex.Lock()
+	defer fake.saveEventsMutex.Unlock()
+	fake.SaveEventsStub = nil
+	fake.saveEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveEventsReturnsOnCall(i int, result1 error) {
+	fake.saveEventsMutex.Lock()
+	defer fake.saveEventsMutex.Unlock()
+	fake.SaveEventsStub = nil
+	if fake.saveEventsReturnsOnCall == nil {
+		fake.saveEventsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveEventsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) SaveImageResourceVersion(arg1 db.UsedResourceCache) error {
 	fake.saveImageResourceVersionMutex.Lock()
 	ret, specificReturn := fake.saveImageResourceVersionReturnsOnCall[len(fake.saveImageResourceVersionArgsForCall)]
@@ -2355,32 +5342,153 @@ func (fake *FakeBuild) SaveOutputCalls(stub func(string, atc.Source, atc.Version
 	fake.SaveOutputStub = stub
 }
 
-func (fake *FakeBuild) SaveOutputArgsForCall(i int) (string, atc.Source, atc.VersionedResourceTypes, atc.Version, db.ResourceConfigMetadataFields, string, string) {
-	fake.saveOutputMutex.RLock()
-	defer fake.saveOutputMutex.RUnlock()
-	argsForCall := fake.saveOutputArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7
+func (fake *FakeBuild) SaveOutputArgsForCall(i int) (string, atc.Source, atc.VersionedResourceTypes, atc.Version, db.ResourceConfigMetadataFields, string, string) {
+	fake.saveOutputMutex.RLock()
+	defer fake.saveOutputMutex.RUnlock()
+	argsForCall := fake.saveOutputArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7
+}
+
+func (fake *FakeBuild) SaveOutputReturns(result1 error) {
+	fake.saveOutputMutex.Lock()
+	defer fake.saveOutputMutex.Unlock()
+	fake.SaveOutputStub = nil
+	fake.saveOutputReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveOutputReturnsOnCall(i int, result1 error) {
+	fake.saveOutputMutex.Lock()
+	defer fake.saveOutputMutex.Unlock()
+	fake.SaveOutputStub = nil
+	if fake.saveOutputReturnsOnCall == nil {
+		fake.saveOutputReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveOutputReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveOutputDeleted(arg1 string, arg2 atc.Version) error {
+	fake.saveOutputDeletedMutex.Lock()
+	ret, specificReturn := fake.saveOutputDeletedReturnsOnCall[len(fake.saveOutputDeletedArgsForCall)]
+	fake.saveOutputDeletedArgsForCall = append(fake.saveOutputDeletedArgsForCall, struct {
+		arg1 string
+		arg2 atc.Version
+	}{arg1, arg2})
+	fake.recordInvocation("SaveOutputDeleted", []interface{}{arg1, arg2})
+	fake.saveOutputDeletedMutex.Unlock()
+	if fake.SaveOutputDeletedStub != nil {
+		return fake.SaveOutputDeletedStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveOutputDeletedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveOutputDeletedCallCount() int {
+	fake.saveOutputDeletedMutex.RLock()
+	defer fake.saveOutputDeletedMutex.RUnlock()
+	return len(fake.saveOutputDeletedArgsForCall)
+}
+
+func (fake *FakeBuild) SaveOutputDeletedCalls(stub func(string, atc.Version) error) {
+	fake.saveOutputDeletedMutex.Lock()
+	defer fake.saveOutputDeletedMutex.Unlock()
+	fake.SaveOutputDeletedStub = stub
+}
+
+func (fake *FakeBuild) SaveOutputDeletedArgsForCall(i int) (string, atc.Version) {
+	fake.saveOutputDeletedMutex.RLock()
+	defer fake.saveOutputDeletedMutex.RUnlock()
+	argsForCall := fake.saveOutputDeletedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) SaveOutputDeletedReturns(result1 error) {
+	fake.saveOutputDeletedMutex.Lock()
+	defer fake.saveOutputDeletedMutex.Unlock()
+	fake.SaveOutputDeletedStub = nil
+	fake.saveOutputDeletedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveOutputDeletedReturnsOnCall(i int, result1 error) {
+	fake.saveOutputDeletedMutex.Lock()
+	defer fake.saveOutputDeletedMutex.Unlock()
+	fake.SaveOutputDeletedStub = nil
+	if fake.saveOutputDeletedReturnsOnCall == nil {
+		fake.saveOutputDeletedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveOutputDeletedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveWorkerName(arg1 string) error {
+	fake.saveWorkerNameMutex.Lock()
+	ret, specificReturn := fake.saveWorkerNameReturnsOnCall[len(fake.saveWorkerNameArgsForCall)]
+	fake.saveWorkerNameArgsForCall = append(fake.saveWorkerNameArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("SaveWorkerName", []interface{}{arg1})
+	fake.saveWorkerNameMutex.Unlock()
+	if fake.SaveWorkerNameStub != nil {
+		return fake.SaveWorkerNameStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveWorkerNameReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveWorkerNameCallCount() int {
+	fake.saveWorkerNameMutex.RLock()
+	defer fake.saveWorkerNameMutex.RUnlock()
+	return len(fake.saveWorkerNameArgsForCall)
+}
+
+func (fake *FakeBuild) SaveWorkerNameCalls(stub func(string) error) {
+	fake.saveWorkerNameMutex.Lock()
+	defer fake.saveWorkerNameMutex.Unlock()
+	fake.SaveWorkerNameStub = stub
 }
 
-func (fake *FakeBuild) SaveOutputReturns(result1 error) {
-	fake.saveOutputMutex.Lock()
-	defer fake.saveOutputMutex.Unlock()
-	fake.SaveOutputStub = nil
-	fake.saveOutputReturns = struct {
+func (fake *FakeBuild) SaveWorkerNameArgsForCall(i int) string {
+	fake.saveWorkerNameMutex.RLock()
+	defer fake.saveWorkerNameMutex.RUnlock()
+	argsForCall := fake.saveWorkerNameArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SaveWorkerNameReturns(result1 error) {
+	fake.saveWorkerNameMutex.Lock()
+	defer fake.saveWorkerNameMutex.Unlock()
+	fake.SaveWorkerNameStub = nil
+	fake.saveWorkerNameReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeBuild) SaveOutputReturnsOnCall(i int, result1 error) {
-	fake.saveOutputMutex.Lock()
-	defer fake.saveOutputMutex.Unlock()
-	fake.SaveOutputStub = nil
-	if fake.saveOutputReturnsOnCall == nil {
-		fake.saveOutputReturnsOnCall = make(map[int]struct {
+func (fake *FakeBuild) SaveWorkerNameReturnsOnCall(i int, result1 error) {
+	fake.saveWorkerNameMutex.Lock()
+	defer fake.saveWorkerNameMutex.Unlock()
+	fake.SaveWorkerNameStub = nil
+	if fake.saveWorkerNameReturnsOnCall == nil {
+		fake.saveWorkerNameReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.saveOutputReturnsOnCall[i] = struct {
+	fake.saveWorkerNameReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
@@ -2552,6 +5660,66 @@ func (fake *FakeBuild) SetDrainedReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) SetExpiry(arg1 time.Duration) error {
+	fake.setExpiryMutex.Lock()
+	ret, specificReturn := fake.setExpiryReturnsOnCall[len(fake.setExpiryArgsForCall)]
+	fake.setExpiryArgsForCall = append(fake.setExpiryArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	fake.recordInvocation("SetExpiry", []interface{}{arg1})
+	fake.setExpiryMutex.Unlock()
+	if fake.SetExpiryStub != nil {
+		return fake.SetExpiryStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setExpiryReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SetExpiryCallCount() int {
+	fake.setExpiryMutex.RLock()
+	defer fake.setExpiryMutex.RUnlock()
+	return len(fake.setExpiryArgsForCall)
+}
+
+func (fake *FakeBuild) SetExpiryCalls(stub func(time.Duration) error) {
+	fake.setExpiryMutex.Lock()
+	defer fake.setExpiryMutex.Unlock()
+	fake.SetExpiryStub = stub
+}
+
+func (fake *FakeBuild) SetExpiryArgsForCall(i int) time.Duration {
+	fake.setExpiryMutex.RLock()
+	defer fake.setExpiryMutex.RUnlock()
+	argsForCall := fake.setExpiryArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SetExpiryReturns(result1 error) {
+	fake.setExpiryMutex.Lock()
+	defer fake.setExpiryMutex.Unlock()
+	fake.SetExpiryStub = nil
+	fake.setExpiryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SetExpiryReturnsOnCall(i int, result1 error) {
+	fake.setExpiryMutex.Lock()
+	defer fake.setExpiryMutex.Unlock()
+	fake.SetExpiryStub = nil
+	if fake.setExpiryReturnsOnCall == nil {
+		fake.setExpiryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setExpiryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) SetInterceptible(arg1 bool) error {
 	fake.setInterceptibleMutex.Lock()
 	ret, specificReturn := fake.setInterceptibleReturnsOnCall[len(fake.setInterceptibleArgsForCall)]
@@ -2612,6 +5780,251 @@ func (fake *FakeBuild) SetInterceptibleReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) SetKeep(arg1 bool) error {
+	fake.setKeepMutex.Lock()
+	ret, specificReturn := fake.setKeepReturnsOnCall[len(fake.setKeepArgsForCall)]
+	fake.setKeepArgsForCall = append(fake.setKeepArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	fake.recordInvocation("SetKeep", []interface{}{arg1})
+	fake.setKeepMutex.Unlock()
+	if fake.SetKeepStub != nil {
+		return fake.SetKeepStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setKeepReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SetKeepCallCount() int {
+	fake.setKeepMutex.RLock()
+	defer fake.setKeepMutex.RUnlock()
+	return len(fake.setKeepArgsForCall)
+}
+
+func (fake *FakeBuild) SetKeepCalls(stub func(bool) error) {
+	fake.setKeepMutex.Lock()
+	defer fake.setKeepMutex.Unlock()
+	fake.SetKeepStub = stub
+}
+
+func (fake *FakeBuild) SetKeepArgsForCall(i int) bool {
+	fake.setKeepMutex.RLock()
+	defer fake.setKeepMutex.RUnlock()
+	argsForCall := fake.setKeepArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SetKeepReturns(result1 error) {
+	fake.setKeepMutex.Lock()
+	defer fake.setKeepMutex.Unlock()
+	fake.SetKeepStub = nil
+	fake.setKeepReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SetKeepReturnsOnCall(i int, result1 error) {
+	fake.setKeepMutex.Lock()
+	defer fake.setKeepMutex.Unlock()
+	fake.SetKeepStub = nil
+	if fake.setKeepReturnsOnCall == nil {
+		fake.setKeepReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setKeepReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SetName(arg1 string) error {
+	fake.setNameMutex.Lock()
+	ret, specificReturn := fake.setNameReturnsOnCall[len(fake.setNameArgsForCall)]
+	fake.setNameArgsForCall = append(fake.setNameArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("SetName", []interface{}{arg1})
+	fake.setNameMutex.Unlock()
+	if fake.SetNameStub != nil {
+		return fake.SetNameStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setNameReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SetNameCallCount() int {
+	fake.setNameMutex.RLock()
+	defer fake.setNameMutex.RUnlock()
+	return len(fake.setNameArgsForCall)
+}
+
+func (fake *FakeBuild) SetNameCalls(stub func(string) error) {
+	fake.setNameMutex.Lock()
+	defer fake.setNameMutex.Unlock()
+	fake.SetNameStub = stub
+}
+
+func (fake *FakeBuild) SetNameArgsForCall(i int) string {
+	fake.setNameMutex.RLock()
+	defer fake.setNameMutex.RUnlock()
+	argsForCall := fake.setNameArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SetNameReturns(result1 error) {
+	fake.setNameMutex.Lock()
+	defer fake.setNameMutex.Unlock()
+	fake.SetNameStub = nil
+	fake.setNameReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SetNameReturnsOnCall(i int, result1 error) {
+	fake.setNameMutex.Lock()
+	defer fake.setNameMutex.Unlock()
+	fake.SetNameStub = nil
+	if fake.setNameReturnsOnCall == nil {
+		fake.setNameReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setNameReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SetPlan(arg1 atc.Plan) error {
+	fake.setPlanMutex.Lock()
+	ret, specificReturn := fake.setPlanReturnsOnCall[len(fake.setPlanArgsForCall)]
+	fake.setPlanArgsForCall = append(fake.setPlanArgsForCall, struct {
+		arg1 atc.Plan
+	}{arg1})
+	fake.recordInvocation("SetPlan", []interface{}{arg1})
+	fake.setPlanMutex.Unlock()
+	if fake.SetPlanStub != nil {
+		return fake.SetPlanStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setPlanReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SetPlanCallCount() int {
+	fake.setPlanMutex.RLock()
+	defer fake.setPlanMutex.RUnlock()
+	return len(fake.setPlanArgsForCall)
+}
+
+func (fake *FakeBuild) SetPlanCalls(stub func(atc.Plan) error) {
+	fake.setPlanMutex.Lock()
+	defer fake.setPlanMutex.Unlock()
+	fake.SetPlanStub = stub
+}
+
+func (fake *FakeBuild) SetPlanArgsForCall(i int) atc.Plan {
+	fake.setPlanMutex.RLock()
+	defer fake.setPlanMutex.RUnlock()
+	argsForCall := fake.setPlanArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SetPlanReturns(result1 error) {
+	fake.setPlanMutex.Lock()
+	defer fake.setPlanMutex.Unlock()
+	fake.SetPlanStub = nil
+	fake.setPlanReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SetPlanReturnsOnCall(i int, result1 error) {
+	fake.setPlanMutex.Lock()
+	defer fake.setPlanMutex.Unlock()
+	fake.SetPlanStub = nil
+	if fake.setPlanReturnsOnCall == nil {
+		fake.setPlanReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setPlanReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SetWaitingForWorker(arg1 []string) error {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.setWaitingForWorkerMutex.Lock()
+	ret, specificReturn := fake.setWaitingForWorkerReturnsOnCall[len(fake.setWaitingForWorkerArgsForCall)]
+	fake.setWaitingForWorkerArgsForCall = append(fake.setWaitingForWorkerArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	fake.recordInvocation("SetWaitingForWorker", []interface{}{arg1Copy})
+	fake.setWaitingForWorkerMutex.Unlock()
+	if fake.SetWaitingForWorkerStub != nil {
+		return fake.SetWaitingForWorkerStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setWaitingForWorkerReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SetWaitingForWorkerCallCount() int {
+	fake.setWaitingForWorkerMutex.RLock()
+	defer fake.setWaitingForWorkerMutex.RUnlock()
+	return len(fake.setWaitingForWorkerArgsForCall)
+}
+
+func (fake *FakeBuild) SetWaitingForWorkerCalls(stub func([]string) error) {
+	fake.setWaitingForWorkerMutex.Lock()
+	defer fake.setWaitingForWorkerMutex.Unlock()
+	fake.SetWaitingForWorkerStub = stub
+}
+
+func (fake *FakeBuild) SetWaitingForWorkerArgsForCall(i int) []string {
+	fake.setWaitingForWorkerMutex.RLock()
+	defer fake.setWaitingForWorkerMutex.RUnlock()
+	argsForCall := fake.setWaitingForWorkerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SetWaitingForWorkerReturns(result1 error) {
+	fake.setWaitingForWorkerMutex.Lock()
+	defer fake.setWaitingForWorkerMutex.Unlock()
+	fake.SetWaitingForWorkerStub = nil
+	fake.setWaitingForWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SetWaitingForWorkerReturnsOnCall(i int, result1 error) {
+	fake.setWaitingForWorkerMutex.Lock()
+	defer fake.setWaitingForWorkerMutex.Unlock()
+	fake.SetWaitingForWorkerStub = nil
+	if fake.setWaitingForWorkerReturnsOnCall == nil {
+		fake.setWaitingForWorkerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setWaitingForWorkerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Start(arg1 atc.Plan) (bool, error) {
 	fake.startMutex.Lock()
 	ret, specificReturn := fake.startReturnsOnCall[len(fake.startArgsForCall)]
@@ -2779,6 +6192,58 @@ func (fake *FakeBuild) StatusReturnsOnCall(i int, result1 db.BuildStatus) {
 	}{result1}
 }
 
+func (fake *FakeBuild) Tags() []string {
+	fake.tagsMutex.Lock()
+	ret, specificReturn := fake.tagsReturnsOnCall[len(fake.tagsArgsForCall)]
+	fake.tagsArgsForCall = append(fake.tagsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Tags", []interface{}{})
+	fake.tagsMutex.Unlock()
+	if fake.TagsStub != nil {
+		return fake.TagsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.tagsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) TagsCallCount() int {
+	fake.tagsMutex.RLock()
+	defer fake.tagsMutex.RUnlock()
+	return len(fake.tagsArgsForCall)
+}
+
+func (fake *FakeBuild) TagsCalls(stub func() []string) {
+	fake.tagsMutex.Lock()
+	defer fake.tagsMutex.Unlock()
+	fake.TagsStub = stub
+}
+
+func (fake *FakeBuild) TagsReturns(result1 []string) {
+	fake.tagsMutex.Lock()
+	defer fake.tagsMutex.Unlock()
+	fake.TagsStub = nil
+	fake.tagsReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakeBuild) TagsReturnsOnCall(i int, result1 []string) {
+	fake.tagsMutex.Lock()
+	defer fake.tagsMutex.Unlock()
+	fake.TagsStub = nil
+	if fake.tagsReturnsOnCall == nil {
+		fake.tagsReturnsOnCall = make(map[int]struct {
+			result1 []string
+		})
+	}
+	fake.tagsReturnsOnCall[i] = struct {
+		result1 []string
+	}{result1}
+}
+
 func (fake *FakeBuild) TeamID() int {
 	fake.teamIDMutex.Lock()
 	ret, specificReturn := fake.teamIDReturnsOnCall[len(fake.teamIDArgsForCall)]
@@ -2883,6 +6348,135 @@ func (fake *FakeBuild) TeamNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) TrimEvents(arg1 time.Time) (int, error) {
+	fake.trimEventsMutex.Lock()
+	ret, specificReturn := fake.trimEventsReturnsOnCall[len(fake.trimEventsArgsForCall)]
+	fake.trimEventsArgsForCall = append(fake.trimEventsArgsForCall, struct {
+		arg1 time.Time
+	}{arg1})
+	fake.recordInvocation("TrimEvents", []interface{}{arg1})
+	fake.trimEventsMutex.Unlock()
+	if fake.TrimEventsStub != nil {
+		return fake.TrimEventsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.trimEventsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) TrimEventsCallCount() int {
+	fake.trimEventsMutex.RLock()
+	defer fake.trimEventsMutex.RUnlock()
+	return len(fake.trimEventsArgsForCall)
+}
+
+func (fake *FakeBuild) TrimEventsCalls(stub func(time.Time) (int, error)) {
+	fake.trimEventsMutex.Lock()
+	defer fake.trimEventsMutex.Unlock()
+	fake.TrimEventsStub = stub
+}
+
+func (fake *FakeBuild) TrimEventsArgsForCall(i int) time.Time {
+	fake.trimEventsMutex.RLock()
+	defer fake.trimEventsMutex.RUnlock()
+	argsForCall := fake.trimEventsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) TrimEventsReturns(result1 int, result2 error) {
+	fake.trimEventsMutex.Lock()
+	defer fake.trimEventsMutex.Unlock()
+	fake.TrimEventsStub = nil
+	fake.trimEventsReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) TrimEventsReturnsOnCall(i int, result1 int, result2 error) {
+	fake.trimEventsMutex.Lock()
+	defer fake.trimEventsMutex.Unlock()
+	fake.TrimEventsStub = nil
+	if fake.trimEventsReturnsOnCall == nil {
+		fake.trimEventsReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.trimEventsReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) UpdateOutputMetadata(arg1 string, arg2 []db.ResourceConfigMetadataField) error {
+	var arg2Copy []db.ResourceConfigMetadataField
+	if arg2 != nil {
+		arg2Copy = make([]db.ResourceConfigMetadataField, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.updateOutputMetadataMutex.Lock()
+	ret, specificReturn := fake.updateOutputMetadataReturnsOnCall[len(fake.updateOutputMetadataArgsForCall)]
+	fake.updateOutputMetadataArgsForCall = append(fake.updateOutputMetadataArgsForCall, struct {
+		arg1 string
+		arg2 []db.ResourceConfigMetadataField
+	}{arg1, arg2Copy})
+	fake.recordInvocation("UpdateOutputMetadata", []interface{}{arg1, arg2Copy})
+	fake.updateOutputMetadataMutex.Unlock()
+	if fake.UpdateOutputMetadataStub != nil {
+		return fake.UpdateOutputMetadataStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateOutputMetadataReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) UpdateOutputMetadataCallCount() int {
+	fake.updateOutputMetadataMutex.RLock()
+	defer fake.updateOutputMetadataMutex.RUnlock()
+	return len(fake.updateOutputMetadataArgsForCall)
+}
+
+func (fake *FakeBuild) UpdateOutputMetadataCalls(stub func(string, []db.ResourceConfigMetadataField) error) {
+	fake.updateOutputMetadataMutex.Lock()
+	defer fake.updateOutputMetadataMutex.Unlock()
+	fake.UpdateOutputMetadataStub = stub
+}
+
+func (fake *FakeBuild) UpdateOutputMetadataArgsForCall(i int) (string, []db.ResourceConfigMetadataField) {
+	fake.updateOutputMetadataMutex.RLock()
+	defer fake.updateOutputMetadataMutex.RUnlock()
+	argsForCall := fake.updateOutputMetadataArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) UpdateOutputMetadataReturns(result1 error) {
+	fake.updateOutputMetadataMutex.Lock()
+	defer fake.updateOutputMetadataMutex.Unlock()
+	fake.UpdateOutputMetadataStub = nil
+	fake.updateOutputMetadataReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) UpdateOutputMetadataReturnsOnCall(i int, result1 error) {
+	fake.updateOutputMetadataMutex.Lock()
+	defer fake.updateOutputMetadataMutex.Unlock()
+	fake.UpdateOutputMetadataStub = nil
+	if fake.updateOutputMetadataReturnsOnCall == nil {
+		fake.updateOutputMetadataReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateOutputMetadataReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) UseInputs(arg1 []db.BuildInput) error {
 	var arg1Copy []db.BuildInput
 	if arg1 != nil {
@@ -2948,31 +6542,122 @@ func (fake *FakeBuild) UseInputsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) WorkerNames() ([]string, error) {
+	fake.workerNamesMutex.Lock()
+	ret, specificReturn := fake.workerNamesReturnsOnCall[len(fake.workerNamesArgsForCall)]
+	fake.workerNamesArgsForCall = append(fake.workerNamesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("WorkerNames", []interface{}{})
+	fake.workerNamesMutex.Unlock()
+	if fake.WorkerNamesStub != nil {
+		return fake.WorkerNamesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.workerNamesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) WorkerNamesCallCount() int {
+	fake.workerNamesMutex.RLock()
+	defer fake.workerNamesMutex.RUnlock()
+	return len(fake.workerNamesArgsForCall)
+}
+
+func (fake *FakeBuild) WorkerNamesCalls(stub func() ([]string, error)) {
+	fake.workerNamesMutex.Lock()
+	defer fake.workerNamesMutex.Unlock()
+	fake.WorkerNamesStub = stub
+}
+
+func (fake *FakeBuild) WorkerNamesReturns(result1 []string, result2 error) {
+	fake.workerNamesMutex.Lock()
+	defer fake.workerNamesMutex.Unlock()
+	fake.WorkerNamesStub = nil
+	fake.workerNamesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) WorkerNamesReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.workerNamesMutex.Lock()
+	defer fake.workerNamesMutex.Unlock()
+	fake.WorkerNamesStub = nil
+	if fake.workerNamesReturnsOnCall == nil {
+		fake.workerNamesReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.workerNamesReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.abortNotifierMutex.RLock()
 	defer fake.abortNotifierMutex.RUnlock()
+	fake.abortReasonMutex.RLock()
+	defer fake.abortReasonMutex.RUnlock()
 	fake.acquireTrackingLockMutex.RLock()
 	defer fake.acquireTrackingLockMutex.RUnlock()
 	fake.artifactMutex.RLock()
 	defer fake.artifactMutex.RUnlock()
+	fake.artifactByNameMutex.RLock()
+	defer fake.artifactByNameMutex.RUnlock()
 	fake.artifactsMutex.RLock()
 	defer fake.artifactsMutex.RUnlock()
+	fake.containerHandlesMutex.RLock()
+	defer fake.containerHandlesMutex.RUnlock()
 	fake.createTimeMutex.RLock()
 	defer fake.createTimeMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
+	fake.downstreamJobsMutex.RLock()
+	defer fake.downstreamJobsMutex.RUnlock()
 	fake.endTimeMutex.RLock()
 	defer fake.endTimeMutex.RUnlock()
+	fake.errorUnresolvedInputsMutex.RLock()
+	defer fake.errorUnresolvedInputsMutex.RUnlock()
+	fake.eventCountMutex.RLock()
+	defer fake.eventCountMutex.RUnlock()
+	fake.eventOffsetAtFractionMutex.RLock()
+	defer fake.eventOffsetAtFractionMutex.RUnlock()
 	fake.eventsMutex.RLock()
 	defer fake.eventsMutex.RUnlock()
+	fake.eventsBetweenMutex.RLock()
+	defer fake.eventsBetweenMutex.RUnlock()
+	fake.eventsCompressedMutex.RLock()
+	defer fake.eventsCompressedMutex.RUnlock()
+	fake.eventsFromIDMutex.RLock()
+	defer fake.eventsFromIDMutex.RUnlock()
+	fake.eventsFromTokenMutex.RLock()
+	defer fake.eventsFromTokenMutex.RUnlock()
 	fake.finishMutex.RLock()
 	defer fake.finishMutex.RUnlock()
+	fake.finishWithEventsMutex.RLock()
+	defer fake.finishWithEventsMutex.RUnlock()
+	fake.finishWithOptionsMutex.RLock()
+	defer fake.finishWithOptionsMutex.RUnlock()
 	fake.hasPlanMutex.RLock()
 	defer fake.hasPlanMutex.RUnlock()
+	fake.hasPrivatePlanMutex.RLock()
+	defer fake.hasPrivatePlanMutex.RUnlock()
+	fake.holdMutex.RLock()
+	defer fake.holdMutex.RUnlock()
 	fake.iDMutex.RLock()
 	defer fake.iDMutex.RUnlock()
+	fake.inputCountMutex.RLock()
+	defer fake.inputCountMutex.RUnlock()
+	fake.inputVersionsMutex.RLock()
+	defer fake.inputVersionsMutex.RUnlock()
+	fake.inputsDeterminedAtMutex.RLock()
+	defer fake.inputsDeterminedAtMutex.RUnlock()
 	fake.interceptibleMutex.RLock()
 	defer fake.interceptibleMutex.RUnlock()
 	fake.isAbortedMutex.RLock()
@@ -2981,20 +6666,40 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.isCompletedMutex.RUnlock()
 	fake.isDrainedMutex.RLock()
 	defer fake.isDrainedMutex.RUnlock()
+	fake.isHeldMutex.RLock()
+	defer fake.isHeldMutex.RUnlock()
+	fake.isKeptMutex.RLock()
+	defer fake.isKeptMutex.RUnlock()
 	fake.isManuallyTriggeredMutex.RLock()
 	defer fake.isManuallyTriggeredMutex.RUnlock()
 	fake.isRunningMutex.RLock()
 	defer fake.isRunningMutex.RUnlock()
 	fake.isScheduledMutex.RLock()
 	defer fake.isScheduledMutex.RUnlock()
+	fake.jobMutex.RLock()
+	defer fake.jobMutex.RUnlock()
 	fake.jobIDMutex.RLock()
 	defer fake.jobIDMutex.RUnlock()
 	fake.jobNameMutex.RLock()
 	defer fake.jobNameMutex.RUnlock()
+	fake.latestStatusEventMutex.RLock()
+	defer fake.latestStatusEventMutex.RUnlock()
 	fake.markAsAbortedMutex.RLock()
 	defer fake.markAsAbortedMutex.RUnlock()
+	fake.markAsAbortedWithReasonMutex.RLock()
+	defer fake.markAsAbortedWithReasonMutex.RUnlock()
+	fake.moveToTeamMutex.RLock()
+	defer fake.moveToTeamMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.notifyOnCompletionMutex.RLock()
+	defer fake.notifyOnCompletionMutex.RUnlock()
+	fake.originMutex.RLock()
+	defer fake.originMutex.RUnlock()
+	fake.outputCountMutex.RLock()
+	defer fake.outputCountMutex.RUnlock()
+	fake.outputsSinceMutex.RLock()
+	defer fake.outputsSinceMutex.RUnlock()
 	fake.pipelineMutex.RLock()
 	defer fake.pipelineMutex.RUnlock()
 	fake.pipelineIDMutex.RLock()
@@ -3003,42 +6708,90 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.pipelineNameMutex.RUnlock()
 	fake.preparationMutex.RLock()
 	defer fake.preparationMutex.RUnlock()
+	fake.preparationCachedMutex.RLock()
+	defer fake.preparationCachedMutex.RUnlock()
 	fake.privatePlanMutex.RLock()
 	defer fake.privatePlanMutex.RUnlock()
+	fake.privatePlanForResumeMutex.RLock()
+	defer fake.privatePlanForResumeMutex.RUnlock()
 	fake.publicPlanMutex.RLock()
 	defer fake.publicPlanMutex.RUnlock()
 	fake.reapTimeMutex.RLock()
 	defer fake.reapTimeMutex.RUnlock()
+	fake.reapableMutex.RLock()
+	defer fake.reapableMutex.RUnlock()
+	fake.redactedEventsMutex.RLock()
+	defer fake.redactedEventsMutex.RUnlock()
+	fake.registerResourceCacheUseMutex.RLock()
+	defer fake.registerResourceCacheUseMutex.RUnlock()
+	fake.releaseMutex.RLock()
+	defer fake.releaseMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.reloadChangedMutex.RLock()
+	defer fake.reloadChangedMutex.RUnlock()
+	fake.rerunsMutex.RLock()
+	defer fake.rerunsMutex.RUnlock()
+	fake.resourceCacheUsesMutex.RLock()
+	defer fake.resourceCacheUsesMutex.RUnlock()
 	fake.resourcesMutex.RLock()
 	defer fake.resourcesMutex.RUnlock()
+	fake.resourcesWithMetadataMutex.RLock()
+	defer fake.resourcesWithMetadataMutex.RUnlock()
+	fake.retryCountMutex.RLock()
+	defer fake.retryCountMutex.RUnlock()
 	fake.saveEventMutex.RLock()
 	defer fake.saveEventMutex.RUnlock()
+	fake.saveEventWithSeqMutex.RLock()
+	defer fake.saveEventWithSeqMutex.RUnlock()
+	fake.saveEventsMutex.RLock()
+	defer fake.saveEventsMutex.RUnlock()
 	fake.saveImageResourceVersionMutex.RLock()
 	defer fake.saveImageResourceVersionMutex.RUnlock()
 	fake.saveOutputMutex.RLock()
 	defer fake.saveOutputMutex.RUnlock()
+	fake.saveOutputDeletedMutex.RLock()
+	defer fake.saveOutputDeletedMutex.RUnlock()
+	fake.saveWorkerNameMutex.RLock()
+	defer fake.saveWorkerNameMutex.RUnlock()
 	fake.scheduleMutex.RLock()
 	defer fake.scheduleMutex.RUnlock()
 	fake.schemaMutex.RLock()
 	defer fake.schemaMutex.RUnlock()
 	fake.setDrainedMutex.RLock()
 	defer fake.setDrainedMutex.RUnlock()
+	fake.setExpiryMutex.RLock()
+	defer fake.setExpiryMutex.RUnlock()
 	fake.setInterceptibleMutex.RLock()
 	defer fake.setInterceptibleMutex.RUnlock()
+	fake.setKeepMutex.RLock()
+	defer fake.setKeepMutex.RUnlock()
+	fake.setNameMutex.RLock()
+	defer fake.setNameMutex.RUnlock()
+	fake.setPlanMutex.RLock()
+	defer fake.setPlanMutex.RUnlock()
+	fake.setWaitingForWorkerMutex.RLock()
+	defer fake.setWaitingForWorkerMutex.RUnlock()
 	fake.startMutex.RLock()
 	defer fake.startMutex.RUnlock()
 	fake.startTimeMutex.RLock()
 	defer fake.startTimeMutex.RUnlock()
 	fake.statusMutex.RLock()
 	defer fake.statusMutex.RUnlock()
+	fake.tagsMutex.RLock()
+	defer fake.tagsMutex.RUnlock()
 	fake.teamIDMutex.RLock()
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
+	fake.trimEventsMutex.RLock()
+	defer fake.trimEventsMutex.RUnlock()
+	fake.updateOutputMetadataMutex.RLock()
+	defer fake.updateOutputMetadataMutex.RUnlock()
 	fake.useInputsMutex.RLock()
 	defer fake.useInputsMutex.RUnlock()
+	fake.workerNamesMutex.RLock()
+	defer fake.workerNamesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
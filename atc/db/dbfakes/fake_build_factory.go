@@ -23,6 +23,16 @@ type FakeBuildFactory struct {
 		result2 bool
 		result3 error
 	}
+	DeleteExpiredOneOffBuildsStub        func() error
+	deleteExpiredOneOffBuildsMutex       sync.RWMutex
+	deleteExpiredOneOffBuildsArgsForCall []struct {
+	}
+	deleteExpiredOneOffBuildsReturns struct {
+		result1 error
+	}
+	deleteExpiredOneOffBuildsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetAllStartedBuildsStub        func() ([]db.Build, error)
 	getAllStartedBuildsMutex       sync.RWMutex
 	getAllStartedBuildsArgsForCall []struct {
@@ -57,6 +67,20 @@ type FakeBuildFactory struct {
 	markNonInterceptibleBuildsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	NextBuildToScheduleFairStub        func() (db.Build, bool, error)
+	nextBuildToScheduleFairMutex       sync.RWMutex
+	nextBuildToScheduleFairArgsForCall []struct {
+	}
+	nextBuildToScheduleFairReturns struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	nextBuildToScheduleFairReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
 	PublicBuildsStub        func(db.Page) ([]db.Build, db.Pagination, error)
 	publicBuildsMutex       sync.RWMutex
 	publicBuildsArgsForCall []struct {
@@ -174,6 +198,58 @@ func (fake *FakeBuildFactory) BuildReturnsOnCall(i int, result1 db.Build, result
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuildFactory) DeleteExpiredOneOffBuilds() error {
+	fake.deleteExpiredOneOffBuildsMutex.Lock()
+	ret, specificReturn := fake.deleteExpiredOneOffBuildsReturnsOnCall[len(fake.deleteExpiredOneOffBuildsArgsForCall)]
+	fake.deleteExpiredOneOffBuildsArgsForCall = append(fake.deleteExpiredOneOffBuildsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("DeleteExpiredOneOffBuilds", []interface{}{})
+	fake.deleteExpiredOneOffBuildsMutex.Unlock()
+	if fake.DeleteExpiredOneOffBuildsStub != nil {
+		return fake.DeleteExpiredOneOffBuildsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteExpiredOneOffBuildsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildFactory) DeleteExpiredOneOffBuildsCallCount() int {
+	fake.deleteExpiredOneOffBuildsMutex.RLock()
+	defer fake.deleteExpiredOneOffBuildsMutex.RUnlock()
+	return len(fake.deleteExpiredOneOffBuildsArgsForCall)
+}
+
+func (fake *FakeBuildFactory) DeleteExpiredOneOffBuildsCalls(stub func() error) {
+	fake.deleteExpiredOneOffBuildsMutex.Lock()
+	defer fake.deleteExpiredOneOffBuildsMutex.Unlock()
+	fake.DeleteExpiredOneOffBuildsStub = stub
+}
+
+func (fake *FakeBuildFactory) DeleteExpiredOneOffBuildsReturns(result1 error) {
+	fake.deleteExpiredOneOffBuildsMutex.Lock()
+	defer fake.deleteExpiredOneOffBuildsMutex.Unlock()
+	fake.DeleteExpiredOneOffBuildsStub = nil
+	fake.deleteExpiredOneOffBuildsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildFactory) DeleteExpiredOneOffBuildsReturnsOnCall(i int, result1 error) {
+	fake.deleteExpiredOneOffBuildsMutex.Lock()
+	defer fake.deleteExpiredOneOffBuildsMutex.Unlock()
+	fake.DeleteExpiredOneOffBuildsStub = nil
+	if fake.deleteExpiredOneOffBuildsReturnsOnCall == nil {
+		fake.deleteExpiredOneOffBuildsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteExpiredOneOffBuildsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuildFactory) GetAllStartedBuilds() ([]db.Build, error) {
 	fake.getAllStartedBuildsMutex.Lock()
 	ret, specificReturn := fake.getAllStartedBuildsReturnsOnCall[len(fake.getAllStartedBuildsArgsForCall)]
@@ -336,6 +412,64 @@ func (fake *FakeBuildFactory) MarkNonInterceptibleBuildsReturnsOnCall(i int, res
 	}{result1}
 }
 
+func (fake *FakeBuildFactory) NextBuildToScheduleFair() (db.Build, bool, error) {
+	fake.nextBuildToScheduleFairMutex.Lock()
+	ret, specificReturn := fake.nextBuildToScheduleFairReturnsOnCall[len(fake.nextBuildToScheduleFairArgsForCall)]
+	fake.nextBuildToScheduleFairArgsForCall = append(fake.nextBuildToScheduleFairArgsForCall, struct {
+	}{})
+	fake.recordInvocation("NextBuildToScheduleFair", []interface{}{})
+	fake.nextBuildToScheduleFairMutex.Unlock()
+	if fake.NextBuildToScheduleFairStub != nil {
+		return fake.NextBuildToScheduleFairStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.nextBuildToScheduleFairReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuildFactory) NextBuildToScheduleFairCallCount() int {
+	fake.nextBuildToScheduleFairMutex.RLock()
+	defer fake.nextBuildToScheduleFairMutex.RUnlock()
+	return len(fake.nextBuildToScheduleFairArgsForCall)
+}
+
+func (fake *FakeBuildFactory) NextBuildToScheduleFairCalls(stub func() (db.Build, bool, error)) {
+	fake.nextBuildToScheduleFairMutex.Lock()
+	defer fake.nextBuildToScheduleFairMutex.Unlock()
+	fake.NextBuildToScheduleFairStub = stub
+}
+
+func (fake *FakeBuildFactory) NextBuildToScheduleFairReturns(result1 db.Build, result2 bool, result3 error) {
+	fake.nextBuildToScheduleFairMutex.Lock()
+	defer fake.nextBuildToScheduleFairMutex.Unlock()
+	fake.NextBuildToScheduleFairStub = nil
+	fake.nextBuildToScheduleFairReturns = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuildFactory) NextBuildToScheduleFairReturnsOnCall(i int, result1 db.Build, result2 bool, result3 error) {
+	fake.nextBuildToScheduleFairMutex.Lock()
+	defer fake.nextBuildToScheduleFairMutex.Unlock()
+	fake.NextBuildToScheduleFairStub = nil
+	if fake.nextBuildToScheduleFairReturnsOnCall == nil {
+		fake.nextBuildToScheduleFairReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 bool
+			result3 error
+		})
+	}
+	fake.nextBuildToScheduleFairReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuildFactory) PublicBuilds(arg1 db.Page) ([]db.Build, db.Pagination, error) {
 	fake.publicBuildsMutex.Lock()
 	ret, specificReturn := fake.publicBuildsReturnsOnCall[len(fake.publicBuildsArgsForCall)]
@@ -551,12 +685,16 @@ func (fake *FakeBuildFactory) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
+	fake.deleteExpiredOneOffBuildsMutex.RLock()
+	defer fake.deleteExpiredOneOffBuildsMutex.RUnlock()
 	fake.getAllStartedBuildsMutex.RLock()
 	defer fake.getAllStartedBuildsMutex.RUnlock()
 	fake.getDrainableBuildsMutex.RLock()
 	defer fake.getDrainableBuildsMutex.RUnlock()
 	fake.markNonInterceptibleBuildsMutex.RLock()
 	defer fake.markNonInterceptibleBuildsMutex.RUnlock()
+	fake.nextBuildToScheduleFairMutex.RLock()
+	defer fake.nextBuildToScheduleFairMutex.RUnlock()
 	fake.publicBuildsMutex.RLock()
 	defer fake.publicBuildsMutex.RUnlock()
 	fake.visibleBuildsMutex.RLock()
@@ -2,6 +2,7 @@
 package dbfakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/concourse/concourse/atc/db"
@@ -31,6 +32,56 @@ type FakeEventSource struct {
 		result1 event.Envelope
 		result2 error
 	}
+	NextBatchStub        func(int) ([]event.Envelope, error)
+	nextBatchMutex       sync.RWMutex
+	nextBatchArgsForCall []struct {
+		arg1 int
+	}
+	nextBatchReturns struct {
+		result1 []event.Envelope
+		result2 error
+	}
+	nextBatchReturnsOnCall map[int]struct {
+		result1 []event.Envelope
+		result2 error
+	}
+	NextBatchContextStub        func(context.Context, int) ([]event.Envelope, error)
+	nextBatchContextMutex       sync.RWMutex
+	nextBatchContextArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	nextBatchContextReturns struct {
+		result1 []event.Envelope
+		result2 error
+	}
+	nextBatchContextReturnsOnCall map[int]struct {
+		result1 []event.Envelope
+		result2 error
+	}
+	NextContextStub        func(context.Context) (event.Envelope, error)
+	nextContextMutex       sync.RWMutex
+	nextContextArgsForCall []struct {
+		arg1 context.Context
+	}
+	nextContextReturns struct {
+		result1 event.Envelope
+		result2 error
+	}
+	nextContextReturnsOnCall map[int]struct {
+		result1 event.Envelope
+		result2 error
+	}
+	ResumeTokenStub        func() string
+	resumeTokenMutex       sync.RWMutex
+	resumeTokenArgsForCall []struct {
+	}
+	resumeTokenReturns struct {
+		result1 string
+	}
+	resumeTokenReturnsOnCall map[int]struct {
+		result1 string
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -142,6 +193,248 @@ func (fake *FakeEventSource) NextReturnsOnCall(i int, result1 event.Envelope, re
 	}{result1, result2}
 }
 
+func (fake *FakeEventSource) NextBatch(arg1 int) ([]event.Envelope, error) {
+	fake.nextBatchMutex.Lock()
+	ret, specificReturn := fake.nextBatchReturnsOnCall[len(fake.nextBatchArgsForCall)]
+	fake.nextBatchArgsForCall = append(fake.nextBatchArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("NextBatch", []interface{}{arg1})
+	fake.nextBatchMutex.Unlock()
+	if fake.NextBatchStub != nil {
+		return fake.NextBatchStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.nextBatchReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeEventSource) NextBatchCallCount() int {
+	fake.nextBatchMutex.RLock()
+	defer fake.nextBatchMutex.RUnlock()
+	return len(fake.nextBatchArgsForCall)
+}
+
+func (fake *FakeEventSource) NextBatchCalls(stub func(int) ([]event.Envelope, error)) {
+	fake.nextBatchMutex.Lock()
+	defer fake.nextBatchMutex.Unlock()
+	fake.NextBatchStub = stub
+}
+
+func (fake *FakeEventSource) NextBatchArgsForCall(i int) int {
+	fake.nextBatchMutex.RLock()
+	defer fake.nextBatchMutex.RUnlock()
+	argsForCall := fake.nextBatchArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeEventSource) NextBatchReturns(result1 []event.Envelope, result2 error) {
+	fake.nextBatchMutex.Lock()
+	defer fake.nextBatchMutex.Unlock()
+	fake.NextBatchStub = nil
+	fake.nextBatchReturns = struct {
+		result1 []event.Envelope
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEventSource) NextBatchReturnsOnCall(i int, result1 []event.Envelope, result2 error) {
+	fake.nextBatchMutex.Lock()
+	defer fake.nextBatchMutex.Unlock()
+	fake.NextBatchStub = nil
+	if fake.nextBatchReturnsOnCall == nil {
+		fake.nextBatchReturnsOnCall = make(map[int]struct {
+			result1 []event.Envelope
+			result2 error
+		})
+	}
+	fake.nextBatchReturnsOnCall[i] = struct {
+		result1 []event.Envelope
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEventSource) NextBatchContext(arg1 context.Context, arg2 int) ([]event.Envelope, error) {
+	fake.nextBatchContextMutex.Lock()
+	ret, specificReturn := fake.nextBatchContextReturnsOnCall[len(fake.nextBatchContextArgsForCall)]
+	fake.nextBatchContextArgsForCall = append(fake.nextBatchContextArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("NextBatchContext", []interface{}{arg1, arg2})
+	fake.nextBatchContextMutex.Unlock()
+	if fake.NextBatchContextStub != nil {
+		return fake.NextBatchContextStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.nextBatchContextReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeEventSource) NextBatchContextCallCount() int {
+	fake.nextBatchContextMutex.RLock()
+	defer fake.nextBatchContextMutex.RUnlock()
+	return len(fake.nextBatchContextArgsForCall)
+}
+
+func (fake *FakeEventSource) NextBatchContextCalls(stub func(context.Context, int) ([]event.Envelope, error)) {
+	fake.nextBatchContextMutex.Lock()
+	defer fake.nextBatchContextMutex.Unlock()
+	fake.NextBatchContextStub = stub
+}
+
+func (fake *FakeEventSource) NextBatchContextArgsForCall(i int) (context.Context, int) {
+	fake.nextBatchContextMutex.RLock()
+	defer fake.nextBatchContextMutex.RUnlock()
+	argsForCall := fake.nextBatchContextArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeEventSource) NextBatchContextReturns(result1 []event.Envelope, result2 error) {
+	fake.nextBatchContextMutex.Lock()
+	defer fake.nextBatchContextMutex.Unlock()
+	fake.NextBatchContextStub = nil
+	fake.nextBatchContextReturns = struct {
+		result1 []event.Envelope
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEventSource) NextBatchContextReturnsOnCall(i int, result1 []event.Envelope, result2 error) {
+	fake.nextBatchContextMutex.Lock()
+	defer fake.nextBatchContextMutex.Unlock()
+	fake.NextBatchContextStub = nil
+	if fake.nextBatchContextReturnsOnCall == nil {
+		fake.nextBatchContextReturnsOnCall = make(map[int]struct {
+			result1 []event.Envelope
+			result2 error
+		})
+	}
+	fake.nextBatchContextReturnsOnCall[i] = struct {
+		result1 []event.Envelope
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEventSource) NextContext(arg1 context.Context) (event.Envelope, error) {
+	fake.nextContextMutex.Lock()
+	ret, specificReturn := fake.nextContextReturnsOnCall[len(fake.nextContextArgsForCall)]
+	fake.nextContextArgsForCall = append(fake.nextContextArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	fake.recordInvocation("NextContext", []interface{}{arg1})
+	fake.nextContextMutex.Unlock()
+	if fake.NextContextStub != nil {
+		return fake.NextContextStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.nextContextReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeEventSource) NextContextCallCount() int {
+	fake.nextContextMutex.RLock()
+	defer fake.nextContextMutex.RUnlock()
+	return len(fake.nextContextArgsForCall)
+}
+
+func (fake *FakeEventSource) NextContextCalls(stub func(context.Context) (event.Envelope, error)) {
+	fake.nextContextMutex.Lock()
+	defer fake.nextContextMutex.Unlock()
+	fake.NextContextStub = stub
+}
+
+func (fake *FakeEventSource) NextContextArgsForCall(i int) context.Context {
+	fake.nextContextMutex.RLock()
+	defer fake.nextContextMutex.RUnlock()
+	argsForCall := fake.nextContextArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeEventSource) NextContextReturns(result1 event.Envelope, result2 error) {
+	fake.nextContextMutex.Lock()
+	defer fake.nextContextMutex.Unlock()
+	fake.NextContextStub = nil
+	fake.nextContextReturns = struct {
+		result1 event.Envelope
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEventSource) NextContextReturnsOnCall(i int, result1 event.Envelope, result2 error) {
+	fake.nextContextMutex.Lock()
+	defer fake.nextContextMutex.Unlock()
+	fake.NextContextStub = nil
+	if fake.nextContextReturnsOnCall == nil {
+		fake.nextContextReturnsOnCall = make(map[int]struct {
+			result1 event.Envelope
+			result2 error
+		})
+	}
+	fake.nextContextReturnsOnCall[i] = struct {
+		result1 event.Envelope
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEventSource) ResumeToken() string {
+	fake.resumeTokenMutex.Lock()
+	ret, specificReturn := fake.resumeTokenReturnsOnCall[len(fake.resumeTokenArgsForCall)]
+	fake.resumeTokenArgsForCall = append(fake.resumeTokenArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ResumeToken", []interface{}{})
+	fake.resumeTokenMutex.Unlock()
+	if fake.ResumeTokenStub != nil {
+		return fake.ResumeTokenStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.resumeTokenReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeEventSource) ResumeTokenCallCount() int {
+	fake.resumeTokenMutex.RLock()
+	defer fake.resumeTokenMutex.RUnlock()
+	return len(fake.resumeTokenArgsForCall)
+}
+
+func (fake *FakeEventSource) ResumeTokenCalls(stub func() string) {
+	fake.resumeTokenMutex.Lock()
+	defer fake.resumeTokenMutex.Unlock()
+	fake.ResumeTokenStub = stub
+}
+
+func (fake *FakeEventSource) ResumeTokenReturns(result1 string) {
+	fake.resumeTokenMutex.Lock()
+	defer fake.resumeTokenMutex.Unlock()
+	fake.ResumeTokenStub = nil
+	fake.resumeTokenReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeEventSource) ResumeTokenReturnsOnCall(i int, result1 string) {
+	fake.resumeTokenMutex.Lock()
+	defer fake.resumeTokenMutex.Unlock()
+	fake.ResumeTokenStub = nil
+	if fake.resumeTokenReturnsOnCall == nil {
+		fake.resumeTokenReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.resumeTokenReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeEventSource) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -149,6 +442,14 @@ func (fake *FakeEventSource) Invocations() map[string][][]interface{} {
 	defer fake.closeMutex.RUnlock()
 	fake.nextMutex.RLock()
 	defer fake.nextMutex.RUnlock()
+	fake.nextBatchMutex.RLock()
+	defer fake.nextBatchMutex.RUnlock()
+	fake.nextBatchContextMutex.RLock()
+	defer fake.nextBatchContextMutex.RUnlock()
+	fake.nextContextMutex.RLock()
+	defer fake.nextContextMutex.RUnlock()
+	fake.resumeTokenMutex.RLock()
+	defer fake.resumeTokenMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
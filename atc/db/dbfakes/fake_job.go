@@ -3,6 +3,7 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -91,6 +92,47 @@ type FakeJob struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateBuildWithInputsStub        func(algorithm.InputMapping, bool) (db.Build, error)
+	createBuildWithInputsMutex       sync.RWMutex
+	createBuildWithInputsArgsForCall []struct {
+		arg1 algorithm.InputMapping
+		arg2 bool
+	}
+	createBuildWithInputsReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createBuildWithInputsReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
+	CreateBuildWithKeyStub        func(string, time.Duration) (db.Build, error)
+	createBuildWithKeyMutex       sync.RWMutex
+	createBuildWithKeyArgsForCall []struct {
+		arg1 string
+		arg2 time.Duration
+	}
+	createBuildWithKeyReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createBuildWithKeyReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
+	CreateRetryBuildStub        func(db.Build) (db.Build, error)
+	createRetryBuildMutex       sync.RWMutex
+	createRetryBuildArgsForCall []struct {
+		arg1 db.Build
+	}
+	createRetryBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createRetryBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	DeleteNextInputMappingStub        func() error
 	deleteNextInputMappingMutex       sync.RWMutex
 	deleteNextInputMappingArgsForCall []struct {
@@ -221,6 +263,20 @@ type FakeJob struct {
 	iDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	LatestSuccessfulBuildOutputsStub        func() ([]db.BuildOutput, bool, error)
+	latestSuccessfulBuildOutputsMutex       sync.RWMutex
+	latestSuccessfulBuildOutputsArgsForCall []struct {
+	}
+	latestSuccessfulBuildOutputsReturns struct {
+		result1 []db.BuildOutput
+		result2 bool
+		result3 error
+	}
+	latestSuccessfulBuildOutputsReturnsOnCall map[int]struct {
+		result1 []db.BuildOutput
+		result2 bool
+		result3 error
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -293,6 +349,18 @@ type FakeJob struct {
 		result1 bool
 		result2 error
 	}
+	RunningBuildsStub        func() ([]db.Build, error)
+	runningBuildsMutex       sync.RWMutex
+	runningBuildsArgsForCall []struct {
+	}
+	runningBuildsReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	runningBuildsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
 	SaveIndependentInputMappingStub        func(algorithm.InputMapping) error
 	saveIndependentInputMappingMutex       sync.RWMutex
 	saveIndependentInputMappingArgsForCall []struct {
@@ -761,6 +829,197 @@ func (fake *FakeJob) CreateBuildReturnsOnCall(i int, result1 db.Build, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeJob) CreateBuildWithInputs(arg1 algorithm.InputMapping, arg2 bool) (db.Build, error) {
+	fake.createBuildWithInputsMutex.Lock()
+	ret, specificReturn := fake.createBuildWithInputsReturnsOnCall[len(fake.createBuildWithInputsArgsForCall)]
+	fake.createBuildWithInputsArgsForCall = append(fake.createBuildWithInputsArgsForCall, struct {
+		arg1 algorithm.InputMapping
+		arg2 bool
+	}{arg1, arg2})
+	fake.recordInvocation("CreateBuildWithInputs", []interface{}{arg1, arg2})
+	fake.createBuildWithInputsMutex.Unlock()
+	if fake.CreateBuildWithInputsStub != nil {
+		return fake.CreateBuildWithInputsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createBuildWithInputsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) CreateBuildWithInputsCallCount() int {
+	fake.createBuildWithInputsMutex.RLock()
+	defer fake.createBuildWithInputsMutex.RUnlock()
+	return len(fake.createBuildWithInputsArgsForCall)
+}
+
+func (fake *FakeJob) CreateBuildWithInputsCalls(stub func(algorithm.InputMapping, bool) (db.Build, error)) {
+	fake.createBuildWithInputsMutex.Lock()
+	defer fake.createBuildWithInputsMutex.Unlock()
+	fake.CreateBuildWithInputsStub = stub
+}
+
+func (fake *FakeJob) CreateBuildWithInputsArgsForCall(i int) (algorithm.InputMapping, bool) {
+	fake.createBuildWithInputsMutex.RLock()
+	defer fake.createBuildWithInputsMutex.RUnlock()
+	argsForCall := fake.createBuildWithInputsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeJob) CreateBuildWithInputsReturns(result1 db.Build, result2 error) {
+	fake.createBuildWithInputsMutex.Lock()
+	defer fake.createBuildWithInputsMutex.Unlock()
+	fake.CreateBuildWithInputsStub = nil
+	fake.createBuildWithInputsReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateBuildWithInputsReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.createBuildWithInputsMutex.Lock()
+	defer fake.createBuildWithInputsMutex.Unlock()
+	fake.CreateBuildWithInputsStub = nil
+	if fake.createBuildWithInputsReturnsOnCall == nil {
+		fake.createBuildWithInputsReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createBuildWithInputsReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateBuildWithKey(arg1 string, arg2 time.Duration) (db.Build, error) {
+	fake.createBuildWithKeyMutex.Lock()
+	ret, specificReturn := fake.createBuildWithKeyReturnsOnCall[len(fake.createBuildWithKeyArgsForCall)]
+	fake.createBuildWithKeyArgsForCall = append(fake.createBuildWithKeyArgsForCall, struct {
+		arg1 string
+		arg2 time.Duration
+	}{arg1, arg2})
+	fake.recordInvocation("CreateBuildWithKey", []interface{}{arg1, arg2})
+	fake.createBuildWithKeyMutex.Unlock()
+	if fake.CreateBuildWithKeyStub != nil {
+		return fake.CreateBuildWithKeyStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createBuildWithKeyReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) CreateBuildWithKeyCallCount() int {
+	fake.createBuildWithKeyMutex.RLock()
+	defer fake.createBuildWithKeyMutex.RUnlock()
+	return len(fake.createBuildWithKeyArgsForCall)
+}
+
+func (fake *FakeJob) CreateBuildWithKeyCalls(stub func(string, time.Duration) (db.Build, error)) {
+	fake.createBuildWithKeyMutex.Lock()
+	defer fake.createBuildWithKeyMutex.Unlock()
+	fake.CreateBuildWithKeyStub = stub
+}
+
+func (fake *FakeJob) CreateBuildWithKeyArgsForCall(i int) (string, time.Duration) {
+	fake.createBuildWithKeyMutex.RLock()
+	defer fake.createBuildWithKeyMutex.RUnlock()
+	argsForCall := fake.createBuildWithKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeJob) CreateBuildWithKeyReturns(result1 db.Build, result2 error) {
+	fake.createBuildWithKeyMutex.Lock()
+	defer fake.createBuildWithKeyMutex.Unlock()
+	fake.CreateBuildWithKeyStub = nil
+	fake.createBuildWithKeyReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateBuildWithKeyReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.createBuildWithKeyMutex.Lock()
+	defer fake.createBuildWithKeyMutex.Unlock()
+	fake.CreateBuildWithKeyStub = nil
+	if fake.createBuildWithKeyReturnsOnCall == nil {
+		fake.createBuildWithKeyReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createBuildWithKeyReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateRetryBuild(arg1 db.Build) (db.Build, error) {
+	fake.createRetryBuildMutex.Lock()
+	ret, specificReturn := fake.createRetryBuildReturnsOnCall[len(fake.createRetryBuildArgsForCall)]
+	fake.createRetryBuildArgsForCall = append(fake.createRetryBuildArgsForCall, struct {
+		arg1 db.Build
+	}{arg1})
+	fake.recordInvocation("CreateRetryBuild", []interface{}{arg1})
+	fake.createRetryBuildMutex.Unlock()
+	if fake.CreateRetryBuildStub != nil {
+		return fake.CreateRetryBuildStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createRetryBuildReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) CreateRetryBuildCallCount() int {
+	fake.createRetryBuildMutex.RLock()
+	defer fake.createRetryBuildMutex.RUnlock()
+	return len(fake.createRetryBuildArgsForCall)
+}
+
+func (fake *FakeJob) CreateRetryBuildCalls(stub func(db.Build) (db.Build, error)) {
+	fake.createRetryBuildMutex.Lock()
+	defer fake.createRetryBuildMutex.Unlock()
+	fake.CreateRetryBuildStub = stub
+}
+
+func (fake *FakeJob) CreateRetryBuildArgsForCall(i int) db.Build {
+	fake.createRetryBuildMutex.RLock()
+	defer fake.createRetryBuildMutex.RUnlock()
+	argsForCall := fake.createRetryBuildArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) CreateRetryBuildReturns(result1 db.Build, result2 error) {
+	fake.createRetryBuildMutex.Lock()
+	defer fake.createRetryBuildMutex.Unlock()
+	fake.CreateRetryBuildStub = nil
+	fake.createRetryBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateRetryBuildReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.createRetryBuildMutex.Lock()
+	defer fake.createRetryBuildMutex.Unlock()
+	fake.CreateRetryBuildStub = nil
+	if fake.createRetryBuildReturnsOnCall == nil {
+		fake.createRetryBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createRetryBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) DeleteNextInputMapping() error {
 	fake.deleteNextInputMappingMutex.Lock()
 	ret, specificReturn := fake.deleteNextInputMappingReturnsOnCall[len(fake.deleteNextInputMappingArgsForCall)]
@@ -1386,6 +1645,64 @@ func (fake *FakeJob) IDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeJob) LatestSuccessfulBuildOutputs() ([]db.BuildOutput, bool, error) {
+	fake.latestSuccessfulBuildOutputsMutex.Lock()
+	ret, specificReturn := fake.latestSuccessfulBuildOutputsReturnsOnCall[len(fake.latestSuccessfulBuildOutputsArgsForCall)]
+	fake.latestSuccessfulBuildOutputsArgsForCall = append(fake.latestSuccessfulBuildOutputsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("LatestSuccessfulBuildOutputs", []interface{}{})
+	fake.latestSuccessfulBuildOutputsMutex.Unlock()
+	if fake.LatestSuccessfulBuildOutputsStub != nil {
+		return fake.LatestSuccessfulBuildOutputsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.latestSuccessfulBuildOutputsReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeJob) LatestSuccessfulBuildOutputsCallCount() int {
+	fake.latestSuccessfulBuildOutputsMutex.RLock()
+	defer fake.latestSuccessfulBuildOutputsMutex.RUnlock()
+	return len(fake.latestSuccessfulBuildOutputsArgsForCall)
+}
+
+func (fake *FakeJob) LatestSuccessfulBuildOutputsCalls(stub func() ([]db.BuildOutput, bool, error)) {
+	fake.latestSuccessfulBuildOutputsMutex.Lock()
+	defer fake.latestSuccessfulBuildOutputsMutex.Unlock()
+	fake.LatestSuccessfulBuildOutputsStub = stub
+}
+
+func (fake *FakeJob) LatestSuccessfulBuildOutputsReturns(result1 []db.BuildOutput, result2 bool, result3 error) {
+	fake.latestSuccessfulBuildOutputsMutex.Lock()
+	defer fake.latestSuccessfulBuildOutputsMutex.Unlock()
+	fake.LatestSuccessfulBuildOutputsStub = nil
+	fake.latestSuccessfulBuildOutputsReturns = struct {
+		result1 []db.BuildOutput
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeJob) LatestSuccessfulBuildOutputsReturnsOnCall(i int, result1 []db.BuildOutput, result2 bool, result3 error) {
+	fake.latestSuccessfulBuildOutputsMutex.Lock()
+	defer fake.latestSuccessfulBuildOutputsMutex.Unlock()
+	fake.LatestSuccessfulBuildOutputsStub = nil
+	if fake.latestSuccessfulBuildOutputsReturnsOnCall == nil {
+		fake.latestSuccessfulBuildOutputsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildOutput
+			result2 bool
+			result3 error
+		})
+	}
+	fake.latestSuccessfulBuildOutputsReturnsOnCall[i] = struct {
+		result1 []db.BuildOutput
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeJob) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -1753,6 +2070,61 @@ func (fake *FakeJob) ReloadReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeJob) RunningBuilds() ([]db.Build, error) {
+	fake.runningBuildsMutex.Lock()
+	ret, specificReturn := fake.runningBuildsReturnsOnCall[len(fake.runningBuildsArgsForCall)]
+	fake.runningBuildsArgsForCall = append(fake.runningBuildsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("RunningBuilds", []interface{}{})
+	fake.runningBuildsMutex.Unlock()
+	if fake.RunningBuildsStub != nil {
+		return fake.RunningBuildsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.runningBuildsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) RunningBuildsCallCount() int {
+	fake.runningBuildsMutex.RLock()
+	defer fake.runningBuildsMutex.RUnlock()
+	return len(fake.runningBuildsArgsForCall)
+}
+
+func (fake *FakeJob) RunningBuildsCalls(stub func() ([]db.Build, error)) {
+	fake.runningBuildsMutex.Lock()
+	defer fake.runningBuildsMutex.Unlock()
+	fake.RunningBuildsStub = stub
+}
+
+func (fake *FakeJob) RunningBuildsReturns(result1 []db.Build, result2 error) {
+	fake.runningBuildsMutex.Lock()
+	defer fake.runningBuildsMutex.Unlock()
+	fake.RunningBuildsStub = nil
+	fake.runningBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) RunningBuildsReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.runningBuildsMutex.Lock()
+	defer fake.runningBuildsMutex.Unlock()
+	fake.RunningBuildsStub = nil
+	if fake.runningBuildsReturnsOnCall == nil {
+		fake.runningBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.runningBuildsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) SaveIndependentInputMapping(arg1 algorithm.InputMapping) error {
 	fake.saveIndependentInputMappingMutex.Lock()
 	ret, specificReturn := fake.saveIndependentInputMappingReturnsOnCall[len(fake.saveIndependentInputMappingArgsForCall)]
@@ -2276,6 +2648,12 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.configMutex.RUnlock()
 	fake.createBuildMutex.RLock()
 	defer fake.createBuildMutex.RUnlock()
+	fake.createBuildWithInputsMutex.RLock()
+	defer fake.createBuildWithInputsMutex.RUnlock()
+	fake.createBuildWithKeyMutex.RLock()
+	defer fake.createBuildWithKeyMutex.RUnlock()
+	fake.createRetryBuildMutex.RLock()
+	defer fake.createRetryBuildMutex.RUnlock()
 	fake.deleteNextInputMappingMutex.RLock()
 	defer fake.deleteNextInputMappingMutex.RUnlock()
 	fake.ensurePendingBuildExistsMutex.RLock()
@@ -2298,6 +2676,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.hasNewInputsMutex.RUnlock()
 	fake.iDMutex.RLock()
 	defer fake.iDMutex.RUnlock()
+	fake.latestSuccessfulBuildOutputsMutex.RLock()
+	defer fake.latestSuccessfulBuildOutputsMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.pauseMutex.RLock()
@@ -2312,6 +2692,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.publicMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.runningBuildsMutex.RLock()
+	defer fake.runningBuildsMutex.RUnlock()
 	fake.saveIndependentInputMappingMutex.RLock()
 	defer fake.saveIndependentInputMappingMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
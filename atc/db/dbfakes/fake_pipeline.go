@@ -152,6 +152,16 @@ type FakePipeline struct {
 	destroyReturnsOnCall map[int]struct {
 		result1 error
 	}
+	EventRetentionStub        func() time.Duration
+	eventRetentionMutex       sync.RWMutex
+	eventRetentionArgsForCall []struct {
+	}
+	eventRetentionReturns struct {
+		result1 time.Duration
+	}
+	eventRetentionReturnsOnCall map[int]struct {
+		result1 time.Duration
+	}
 	ExposeStub        func() error
 	exposeMutex       sync.RWMutex
 	exposeArgsForCall []struct {
@@ -433,6 +443,44 @@ type FakePipeline struct {
 		result1 db.Resources
 		result2 error
 	}
+	SetEventRetentionStub        func(time.Duration) error
+	setEventRetentionMutex       sync.RWMutex
+	setEventRetentionArgsForCall []struct {
+		arg1 time.Duration
+	}
+	setEventRetentionReturns struct {
+		result1 error
+	}
+	setEventRetentionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StreamSuccessfulBuildOutputsStub        func(int, func(db.BuildOutput) error) error
+	streamSuccessfulBuildOutputsMutex       sync.RWMutex
+	streamSuccessfulBuildOutputsArgsForCall []struct {
+		arg1 int
+		arg2 func(db.BuildOutput) error
+	}
+	streamSuccessfulBuildOutputsReturns struct {
+		result1 error
+	}
+	streamSuccessfulBuildOutputsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SuccessfulBuildOutputsSinceStub        func(int, int, int) ([]db.BuildOutput, error)
+	successfulBuildOutputsSinceMutex       sync.RWMutex
+	successfulBuildOutputsSinceArgsForCall []struct {
+		arg1 int
+		arg2 int
+		arg3 int
+	}
+	successfulBuildOutputsSinceReturns struct {
+		result1 []db.BuildOutput
+		result2 error
+	}
+	successfulBuildOutputsSinceReturnsOnCall map[int]struct {
+		result1 []db.BuildOutput
+		result2 error
+	}
 	TeamIDStub        func() int
 	teamIDMutex       sync.RWMutex
 	teamIDArgsForCall []struct {
@@ -453,6 +501,19 @@ type FakePipeline struct {
 	teamNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	TrimEventsStub        func(time.Time) (int, error)
+	trimEventsMutex       sync.RWMutex
+	trimEventsArgsForCall []struct {
+		arg1 time.Time
+	}
+	trimEventsReturns struct {
+		result1 int
+		result2 error
+	}
+	trimEventsReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	UnpauseStub        func() error
 	unpauseMutex       sync.RWMutex
 	unpauseArgsForCall []struct {
@@ -1126,6 +1187,58 @@ func (fake *FakePipeline) DestroyReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipeline) EventRetention() time.Duration {
+	fake.eventRetentionMutex.Lock()
+	ret, specificReturn := fake.eventRetentionReturnsOnCall[len(fake.eventRetentionArgsForCall)]
+	fake.eventRetentionArgsForCall = append(fake.eventRetentionArgsForCall, struct {
+	}{})
+	fake.recordInvocation("EventRetention", []interface{}{})
+	fake.eventRetentionMutex.Unlock()
+	if fake.EventRetentionStub != nil {
+		return fake.EventRetentionStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.eventRetentionReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) EventRetentionCallCount() int {
+	fake.eventRetentionMutex.RLock()
+	defer fake.eventRetentionMutex.RUnlock()
+	return len(fake.eventRetentionArgsForCall)
+}
+
+func (fake *FakePipeline) EventRetentionCalls(stub func() time.Duration) {
+	fake.eventRetentionMutex.Lock()
+	defer fake.eventRetentionMutex.Unlock()
+	fake.EventRetentionStub = stub
+}
+
+func (fake *FakePipeline) EventRetentionReturns(result1 time.Duration) {
+	fake.eventRetentionMutex.Lock()
+	defer fake.eventRetentionMutex.Unlock()
+	fake.EventRetentionStub = nil
+	fake.eventRetentionReturns = struct {
+		result1 time.Duration
+	}{result1}
+}
+
+func (fake *FakePipeline) EventRetentionReturnsOnCall(i int, result1 time.Duration) {
+	fake.eventRetentionMutex.Lock()
+	defer fake.eventRetentionMutex.Unlock()
+	fake.EventRetentionStub = nil
+	if fake.eventRetentionReturnsOnCall == nil {
+		fake.eventRetentionReturnsOnCall = make(map[int]struct {
+			result1 time.Duration
+		})
+	}
+	fake.eventRetentionReturnsOnCall[i] = struct {
+		result1 time.Duration
+	}{result1}
+}
+
 func (fake *FakePipeline) Expose() error {
 	fake.exposeMutex.Lock()
 	ret, specificReturn := fake.exposeReturnsOnCall[len(fake.exposeArgsForCall)]
@@ -2456,6 +2569,192 @@ func (fake *FakePipeline) ResourcesReturnsOnCall(i int, result1 db.Resources, re
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) SetEventRetention(arg1 time.Duration) error {
+	fake.setEventRetentionMutex.Lock()
+	ret, specificReturn := fake.setEventRetentionReturnsOnCall[len(fake.setEventRetentionArgsForCall)]
+	fake.setEventRetentionArgsForCall = append(fake.setEventRetentionArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	fake.recordInvocation("SetEventRetention", []interface{}{arg1})
+	fake.setEventRetentionMutex.Unlock()
+	if fake.SetEventRetentionStub != nil {
+		return fake.SetEventRetentionStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setEventRetentionReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) SetEventRetentionCallCount() int {
+	fake.setEventRetentionMutex.RLock()
+	defer fake.setEventRetentionMutex.RUnlock()
+	return len(fake.setEventRetentionArgsForCall)
+}
+
+func (fake *FakePipeline) SetEventRetentionCalls(stub func(time.Duration) error) {
+	fake.setEventRetentionMutex.Lock()
+	defer fake.setEventRetentionMutex.Unlock()
+	fake.SetEventRetentionStub = stub
+}
+
+func (fake *FakePipeline) SetEventRetentionArgsForCall(i int) time.Duration {
+	fake.setEventRetentionMutex.RLock()
+	defer fake.setEventRetentionMutex.RUnlock()
+	argsForCall := fake.setEventRetentionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) SetEventRetentionReturns(result1 error) {
+	fake.setEventRetentionMutex.Lock()
+	defer fake.setEventRetentionMutex.Unlock()
+	fake.SetEventRetentionStub = nil
+	fake.setEventRetentionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) SetEventRetentionReturnsOnCall(i int, result1 error) {
+	fake.setEventRetentionMutex.Lock()
+	defer fake.setEventRetentionMutex.Unlock()
+	fake.SetEventRetentionStub = nil
+	if fake.setEventRetentionReturnsOnCall == nil {
+		fake.setEventRetentionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setEventRetentionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) StreamSuccessfulBuildOutputs(arg1 int, arg2 func(db.BuildOutput) error) error {
+	fake.streamSuccessfulBuildOutputsMutex.Lock()
+	ret, specificReturn := fake.streamSuccessfulBuildOutputsReturnsOnCall[len(fake.streamSuccessfulBuildOutputsArgsForCall)]
+	fake.streamSuccessfulBuildOutputsArgsForCall = append(fake.streamSuccessfulBuildOutputsArgsForCall, struct {
+		arg1 int
+		arg2 func(db.BuildOutput) error
+	}{arg1, arg2})
+	fake.recordInvocation("StreamSuccessfulBuildOutputs", []interface{}{arg1, arg2})
+	fake.streamSuccessfulBuildOutputsMutex.Unlock()
+	if fake.StreamSuccessfulBuildOutputsStub != nil {
+		return fake.StreamSuccessfulBuildOutputsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.streamSuccessfulBuildOutputsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) StreamSuccessfulBuildOutputsCallCount() int {
+	fake.streamSuccessfulBuildOutputsMutex.RLock()
+	defer fake.streamSuccessfulBuildOutputsMutex.RUnlock()
+	return len(fake.streamSuccessfulBuildOutputsArgsForCall)
+}
+
+func (fake *FakePipeline) StreamSuccessfulBuildOutputsCalls(stub func(int, func(db.BuildOutput) error) error) {
+	fake.streamSuccessfulBuildOutputsMutex.Lock()
+	defer fake.streamSuccessfulBuildOutputsMutex.Unlock()
+	fake.StreamSuccessfulBuildOutputsStub = stub
+}
+
+func (fake *FakePipeline) StreamSuccessfulBuildOutputsArgsForCall(i int) (int, func(db.BuildOutput) error) {
+	fake.streamSuccessfulBuildOutputsMutex.RLock()
+	defer fake.streamSuccessfulBuildOutputsMutex.RUnlock()
+	argsForCall := fake.streamSuccessfulBuildOutputsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePipeline) StreamSuccessfulBuildOutputsReturns(result1 error) {
+	fake.streamSuccessfulBuildOutputsMutex.Lock()
+	defer fake.streamSuccessfulBuildOutputsMutex.Unlock()
+	fake.StreamSuccessfulBuildOutputsStub = nil
+	fake.streamSuccessfulBuildOutputsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) StreamSuccessfulBuildOutputsReturnsOnCall(i int, result1 error) {
+	fake.streamSuccessfulBuildOutputsMutex.Lock()
+	defer fake.streamSuccessfulBuildOutputsMutex.Unlock()
+	fake.StreamSuccessfulBuildOutputsStub = nil
+	if fake.streamSuccessfulBuildOutputsReturnsOnCall == nil {
+		fake.streamSuccessfulBuildOutputsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.streamSuccessfulBuildOutputsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) SuccessfulBuildOutputsSince(arg1 int, arg2 int, arg3 int) ([]db.BuildOutput, error) {
+	fake.successfulBuildOutputsSinceMutex.Lock()
+	ret, specificReturn := fake.successfulBuildOutputsSinceReturnsOnCall[len(fake.successfulBuildOutputsSinceArgsForCall)]
+	fake.successfulBuildOutputsSinceArgsForCall = append(fake.successfulBuildOutputsSinceArgsForCall, struct {
+		arg1 int
+		arg2 int
+		arg3 int
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("SuccessfulBuildOutputsSince", []interface{}{arg1, arg2, arg3})
+	fake.successfulBuildOutputsSinceMutex.Unlock()
+	if fake.SuccessfulBuildOutputsSinceStub != nil {
+		return fake.SuccessfulBuildOutputsSinceStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.successfulBuildOutputsSinceReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) SuccessfulBuildOutputsSinceCallCount() int {
+	fake.successfulBuildOutputsSinceMutex.RLock()
+	defer fake.successfulBuildOutputsSinceMutex.RUnlock()
+	return len(fake.successfulBuildOutputsSinceArgsForCall)
+}
+
+func (fake *FakePipeline) SuccessfulBuildOutputsSinceCalls(stub func(int, int, int) ([]db.BuildOutput, error)) {
+	fake.successfulBuildOutputsSinceMutex.Lock()
+	defer fake.successfulBuildOutputsSinceMutex.Unlock()
+	fake.SuccessfulBuildOutputsSinceStub = stub
+}
+
+func (fake *FakePipeline) SuccessfulBuildOutputsSinceArgsForCall(i int) (int, int, int) {
+	fake.successfulBuildOutputsSinceMutex.RLock()
+	defer fake.successfulBuildOutputsSinceMutex.RUnlock()
+	argsForCall := fake.successfulBuildOutputsSinceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePipeline) SuccessfulBuildOutputsSinceReturns(result1 []db.BuildOutput, result2 error) {
+	fake.successfulBuildOutputsSinceMutex.Lock()
+	defer fake.successfulBuildOutputsSinceMutex.Unlock()
+	fake.SuccessfulBuildOutputsSinceStub = nil
+	fake.successfulBuildOutputsSinceReturns = struct {
+		result1 []db.BuildOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) SuccessfulBuildOutputsSinceReturnsOnCall(i int, result1 []db.BuildOutput, result2 error) {
+	fake.successfulBuildOutputsSinceMutex.Lock()
+	defer fake.successfulBuildOutputsSinceMutex.Unlock()
+	fake.SuccessfulBuildOutputsSinceStub = nil
+	if fake.successfulBuildOutputsSinceReturnsOnCall == nil {
+		fake.successfulBuildOutputsSinceReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildOutput
+			result2 error
+		})
+	}
+	fake.successfulBuildOutputsSinceReturnsOnCall[i] = struct {
+		result1 []db.BuildOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) TeamID() int {
 	fake.teamIDMutex.Lock()
 	ret, specificReturn := fake.teamIDReturnsOnCall[len(fake.teamIDArgsForCall)]
@@ -2560,6 +2859,69 @@ func (fake *FakePipeline) TeamNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakePipeline) TrimEvents(arg1 time.Time) (int, error) {
+	fake.trimEventsMutex.Lock()
+	ret, specificReturn := fake.trimEventsReturnsOnCall[len(fake.trimEventsArgsForCall)]
+	fake.trimEventsArgsForCall = append(fake.trimEventsArgsForCall, struct {
+		arg1 time.Time
+	}{arg1})
+	fake.recordInvocation("TrimEvents", []interface{}{arg1})
+	fake.trimEventsMutex.Unlock()
+	if fake.TrimEventsStub != nil {
+		return fake.TrimEventsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.trimEventsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) TrimEventsCallCount() int {
+	fake.trimEventsMutex.RLock()
+	defer fake.trimEventsMutex.RUnlock()
+	return len(fake.trimEventsArgsForCall)
+}
+
+func (fake *FakePipeline) TrimEventsCalls(stub func(time.Time) (int, error)) {
+	fake.trimEventsMutex.Lock()
+	defer fake.trimEventsMutex.Unlock()
+	fake.TrimEventsStub = stub
+}
+
+func (fake *FakePipeline) TrimEventsArgsForCall(i int) time.Time {
+	fake.trimEventsMutex.RLock()
+	defer fake.trimEventsMutex.RUnlock()
+	argsForCall := fake.trimEventsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) TrimEventsReturns(result1 int, result2 error) {
+	fake.trimEventsMutex.Lock()
+	defer fake.trimEventsMutex.Unlock()
+	fake.TrimEventsStub = nil
+	fake.trimEventsReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) TrimEventsReturnsOnCall(i int, result1 int, result2 error) {
+	fake.trimEventsMutex.Lock()
+	defer fake.trimEventsMutex.Unlock()
+	fake.TrimEventsStub = nil
+	if fake.trimEventsReturnsOnCall == nil {
+		fake.trimEventsReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.trimEventsReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) Unpause() error {
 	fake.unpauseMutex.Lock()
 	ret, specificReturn := fake.unpauseReturnsOnCall[len(fake.unpauseArgsForCall)]
@@ -2637,6 +2999,8 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.deleteBuildEventsByBuildIDsMutex.RUnlock()
 	fake.destroyMutex.RLock()
 	defer fake.destroyMutex.RUnlock()
+	fake.eventRetentionMutex.RLock()
+	defer fake.eventRetentionMutex.RUnlock()
 	fake.exposeMutex.RLock()
 	defer fake.exposeMutex.RUnlock()
 	fake.getAllPendingBuildsMutex.RLock()
@@ -2683,10 +3047,18 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.resourceVersionMutex.RUnlock()
 	fake.resourcesMutex.RLock()
 	defer fake.resourcesMutex.RUnlock()
+	fake.setEventRetentionMutex.RLock()
+	defer fake.setEventRetentionMutex.RUnlock()
+	fake.streamSuccessfulBuildOutputsMutex.RLock()
+	defer fake.streamSuccessfulBuildOutputsMutex.RUnlock()
+	fake.successfulBuildOutputsSinceMutex.RLock()
+	defer fake.successfulBuildOutputsSinceMutex.RUnlock()
 	fake.teamIDMutex.RLock()
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
+	fake.trimEventsMutex.RLock()
+	defer fake.trimEventsMutex.RUnlock()
 	fake.unpauseMutex.RLock()
 	defer fake.unpauseMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -91,6 +91,19 @@ type FakeResource struct {
 	disableVersionReturnsOnCall map[int]struct {
 		result1 error
 	}
+	EffectiveCheckConfigStub        func(db.CheckConfig) (db.CheckConfig, error)
+	effectiveCheckConfigMutex       sync.RWMutex
+	effectiveCheckConfigArgsForCall []struct {
+		arg1 db.CheckConfig
+	}
+	effectiveCheckConfigReturns struct {
+		result1 db.CheckConfig
+		result2 error
+	}
+	effectiveCheckConfigReturnsOnCall map[int]struct {
+		result1 db.CheckConfig
+		result2 error
+	}
 	EnableVersionStub        func(int) error
 	enableVersionMutex       sync.RWMutex
 	enableVersionArgsForCall []struct {
@@ -132,6 +145,16 @@ type FakeResource struct {
 	lastCheckEndTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	LastCheckReasonStub        func() string
+	lastCheckReasonMutex       sync.RWMutex
+	lastCheckReasonArgsForCall []struct {
+	}
+	lastCheckReasonReturns struct {
+		result1 string
+	}
+	lastCheckReasonReturnsOnCall map[int]struct {
+		result1 string
+	}
 	LastCheckStartTimeStub        func() time.Time
 	lastCheckStartTimeMutex       sync.RWMutex
 	lastCheckStartTimeArgsForCall []struct {
@@ -287,6 +310,17 @@ type FakeResource struct {
 	setCheckSetupErrorReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetLastCheckReasonStub        func(string) error
+	setLastCheckReasonMutex       sync.RWMutex
+	setLastCheckReasonArgsForCall []struct {
+		arg1 string
+	}
+	setLastCheckReasonReturns struct {
+		result1 error
+	}
+	setLastCheckReasonReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetPinCommentStub        func(string) error
 	setPinCommentMutex       sync.RWMutex
 	setPinCommentArgsForCall []struct {
@@ -322,6 +356,18 @@ type FakeResource struct {
 	sourceReturnsOnCall map[int]struct {
 		result1 atc.Source
 	}
+	TagVersionStub        func(int, string) error
+	tagVersionMutex       sync.RWMutex
+	tagVersionArgsForCall []struct {
+		arg1 int
+		arg2 string
+	}
+	tagVersionReturns struct {
+		result1 error
+	}
+	tagVersionReturnsOnCall map[int]struct {
+		result1 error
+	}
 	TagsStub        func() atc.Tags
 	tagsMutex       sync.RWMutex
 	tagsArgsForCall []struct {
@@ -362,6 +408,18 @@ type FakeResource struct {
 	unpinVersionReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UntagVersionStub        func(int, string) error
+	untagVersionMutex       sync.RWMutex
+	untagVersionArgsForCall []struct {
+		arg1 int
+		arg2 string
+	}
+	untagVersionReturns struct {
+		result1 error
+	}
+	untagVersionReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateMetadataStub        func(atc.Version, db.ResourceConfigMetadataFields) (bool, error)
 	updateMetadataMutex       sync.RWMutex
 	updateMetadataArgsForCall []struct {
@@ -393,6 +451,19 @@ type FakeResource struct {
 		result3 bool
 		result4 error
 	}
+	VersionsByTagStub        func(string) ([]db.ResourceConfigVersion, error)
+	versionsByTagMutex       sync.RWMutex
+	versionsByTagArgsForCall []struct {
+		arg1 string
+	}
+	versionsByTagReturns struct {
+		result1 []db.ResourceConfigVersion
+		result2 error
+	}
+	versionsByTagReturnsOnCall map[int]struct {
+		result1 []db.ResourceConfigVersion
+		result2 error
+	}
 	WebhookTokenStub        func() string
 	webhookTokenMutex       sync.RWMutex
 	webhookTokenArgsForCall []struct {
@@ -831,6 +902,69 @@ func (fake *FakeResource) DisableVersionReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeResource) EffectiveCheckConfig(arg1 db.CheckConfig) (db.CheckConfig, error) {
+	fake.effectiveCheckConfigMutex.Lock()
+	ret, specificReturn := fake.effectiveCheckConfigReturnsOnCall[len(fake.effectiveCheckConfigArgsForCall)]
+	fake.effectiveCheckConfigArgsForCall = append(fake.effectiveCheckConfigArgsForCall, struct {
+		arg1 db.CheckConfig
+	}{arg1})
+	fake.recordInvocation("EffectiveCheckConfig", []interface{}{arg1})
+	fake.effectiveCheckConfigMutex.Unlock()
+	if fake.EffectiveCheckConfigStub != nil {
+		return fake.EffectiveCheckConfigStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.effectiveCheckConfigReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) EffectiveCheckConfigCallCount() int {
+	fake.effectiveCheckConfigMutex.RLock()
+	defer fake.effectiveCheckConfigMutex.RUnlock()
+	return len(fake.effectiveCheckConfigArgsForCall)
+}
+
+func (fake *FakeResource) EffectiveCheckConfigCalls(stub func(db.CheckConfig) (db.CheckConfig, error)) {
+	fake.effectiveCheckConfigMutex.Lock()
+	defer fake.effectiveCheckConfigMutex.Unlock()
+	fake.EffectiveCheckConfigStub = stub
+}
+
+func (fake *FakeResource) EffectiveCheckConfigArgsForCall(i int) db.CheckConfig {
+	fake.effectiveCheckConfigMutex.RLock()
+	defer fake.effectiveCheckConfigMutex.RUnlock()
+	argsForCall := fake.effectiveCheckConfigArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResource) EffectiveCheckConfigReturns(result1 db.CheckConfig, result2 error) {
+	fake.effectiveCheckConfigMutex.Lock()
+	defer fake.effectiveCheckConfigMutex.Unlock()
+	fake.EffectiveCheckConfigStub = nil
+	fake.effectiveCheckConfigReturns = struct {
+		result1 db.CheckConfig
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) EffectiveCheckConfigReturnsOnCall(i int, result1 db.CheckConfig, result2 error) {
+	fake.effectiveCheckConfigMutex.Lock()
+	defer fake.effectiveCheckConfigMutex.Unlock()
+	fake.EffectiveCheckConfigStub = nil
+	if fake.effectiveCheckConfigReturnsOnCall == nil {
+		fake.effectiveCheckConfigReturnsOnCall = make(map[int]struct {
+			result1 db.CheckConfig
+			result2 error
+		})
+	}
+	fake.effectiveCheckConfigReturnsOnCall[i] = struct {
+		result1 db.CheckConfig
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) EnableVersion(arg1 int) error {
 	fake.enableVersionMutex.Lock()
 	ret, specificReturn := fake.enableVersionReturnsOnCall[len(fake.enableVersionArgsForCall)]
@@ -1047,6 +1181,58 @@ func (fake *FakeResource) LastCheckEndTimeReturnsOnCall(i int, result1 time.Time
 	}{result1}
 }
 
+func (fake *FakeResource) LastCheckReason() string {
+	fake.lastCheckReasonMutex.Lock()
+	ret, specificReturn := fake.lastCheckReasonReturnsOnCall[len(fake.lastCheckReasonArgsForCall)]
+	fake.lastCheckReasonArgsForCall = append(fake.lastCheckReasonArgsForCall, struct {
+	}{})
+	fake.recordInvocation("LastCheckReason", []interface{}{})
+	fake.lastCheckReasonMutex.Unlock()
+	if fake.LastCheckReasonStub != nil {
+		return fake.LastCheckReasonStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.lastCheckReasonReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) LastCheckReasonCallCount() int {
+	fake.lastCheckReasonMutex.RLock()
+	defer fake.lastCheckReasonMutex.RUnlock()
+	return len(fake.lastCheckReasonArgsForCall)
+}
+
+func (fake *FakeResource) LastCheckReasonCalls(stub func() string) {
+	fake.lastCheckReasonMutex.Lock()
+	defer fake.lastCheckReasonMutex.Unlock()
+	fake.LastCheckReasonStub = stub
+}
+
+func (fake *FakeResource) LastCheckReasonReturns(result1 string) {
+	fake.lastCheckReasonMutex.Lock()
+	defer fake.lastCheckReasonMutex.Unlock()
+	fake.LastCheckReasonStub = nil
+	fake.lastCheckReasonReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeResource) LastCheckReasonReturnsOnCall(i int, result1 string) {
+	fake.lastCheckReasonMutex.Lock()
+	defer fake.lastCheckReasonMutex.Unlock()
+	fake.LastCheckReasonStub = nil
+	if fake.lastCheckReasonReturnsOnCall == nil {
+		fake.lastCheckReasonReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.lastCheckReasonReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeResource) LastCheckStartTime() time.Time {
 	fake.lastCheckStartTimeMutex.Lock()
 	ret, specificReturn := fake.lastCheckStartTimeReturnsOnCall[len(fake.lastCheckStartTimeArgsForCall)]
@@ -1822,6 +2008,66 @@ func (fake *FakeResource) SetCheckSetupErrorReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *FakeResource) SetLastCheckReason(arg1 string) error {
+	fake.setLastCheckReasonMutex.Lock()
+	ret, specificReturn := fake.setLastCheckReasonReturnsOnCall[len(fake.setLastCheckReasonArgsForCall)]
+	fake.setLastCheckReasonArgsForCall = append(fake.setLastCheckReasonArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("SetLastCheckReason", []interface{}{arg1})
+	fake.setLastCheckReasonMutex.Unlock()
+	if fake.SetLastCheckReasonStub != nil {
+		return fake.SetLastCheckReasonStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setLastCheckReasonReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) SetLastCheckReasonCallCount() int {
+	fake.setLastCheckReasonMutex.RLock()
+	defer fake.setLastCheckReasonMutex.RUnlock()
+	return len(fake.setLastCheckReasonArgsForCall)
+}
+
+func (fake *FakeResource) SetLastCheckReasonCalls(stub func(string) error) {
+	fake.setLastCheckReasonMutex.Lock()
+	defer fake.setLastCheckReasonMutex.Unlock()
+	fake.SetLastCheckReasonStub = stub
+}
+
+func (fake *FakeResource) SetLastCheckReasonArgsForCall(i int) string {
+	fake.setLastCheckReasonMutex.RLock()
+	defer fake.setLastCheckReasonMutex.RUnlock()
+	argsForCall := fake.setLastCheckReasonArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResource) SetLastCheckReasonReturns(result1 error) {
+	fake.setLastCheckReasonMutex.Lock()
+	defer fake.setLastCheckReasonMutex.Unlock()
+	fake.SetLastCheckReasonStub = nil
+	fake.setLastCheckReasonReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) SetLastCheckReasonReturnsOnCall(i int, result1 error) {
+	fake.setLastCheckReasonMutex.Lock()
+	defer fake.setLastCheckReasonMutex.Unlock()
+	fake.SetLastCheckReasonStub = nil
+	if fake.setLastCheckReasonReturnsOnCall == nil {
+		fake.setLastCheckReasonReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setLastCheckReasonReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResource) SetPinComment(arg1 string) error {
 	fake.setPinCommentMutex.Lock()
 	ret, specificReturn := fake.setPinCommentReturnsOnCall[len(fake.setPinCommentArgsForCall)]
@@ -1998,6 +2244,67 @@ func (fake *FakeResource) SourceReturnsOnCall(i int, result1 atc.Source) {
 	}{result1}
 }
 
+func (fake *FakeResource) TagVersion(arg1 int, arg2 string) error {
+	fake.tagVersionMutex.Lock()
+	ret, specificReturn := fake.tagVersionReturnsOnCall[len(fake.tagVersionArgsForCall)]
+	fake.tagVersionArgsForCall = append(fake.tagVersionArgsForCall, struct {
+		arg1 int
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("TagVersion", []interface{}{arg1, arg2})
+	fake.tagVersionMutex.Unlock()
+	if fake.TagVersionStub != nil {
+		return fake.TagVersionStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.tagVersionReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) TagVersionCallCount() int {
+	fake.tagVersionMutex.RLock()
+	defer fake.tagVersionMutex.RUnlock()
+	return len(fake.tagVersionArgsForCall)
+}
+
+func (fake *FakeResource) TagVersionCalls(stub func(int, string) error) {
+	fake.tagVersionMutex.Lock()
+	defer fake.tagVersionMutex.Unlock()
+	fake.TagVersionStub = stub
+}
+
+func (fake *FakeResource) TagVersionArgsForCall(i int) (int, string) {
+	fake.tagVersionMutex.RLock()
+	defer fake.tagVersionMutex.RUnlock()
+	argsForCall := fake.tagVersionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeResource) TagVersionReturns(result1 error) {
+	fake.tagVersionMutex.Lock()
+	defer fake.tagVersionMutex.Unlock()
+	fake.TagVersionStub = nil
+	fake.tagVersionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) TagVersionReturnsOnCall(i int, result1 error) {
+	fake.tagVersionMutex.Lock()
+	defer fake.tagVersionMutex.Unlock()
+	fake.TagVersionStub = nil
+	if fake.tagVersionReturnsOnCall == nil {
+		fake.tagVersionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.tagVersionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResource) Tags() atc.Tags {
 	fake.tagsMutex.Lock()
 	ret, specificReturn := fake.tagsReturnsOnCall[len(fake.tagsArgsForCall)]
@@ -2206,6 +2513,67 @@ func (fake *FakeResource) UnpinVersionReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeResource) UntagVersion(arg1 int, arg2 string) error {
+	fake.untagVersionMutex.Lock()
+	ret, specificReturn := fake.untagVersionReturnsOnCall[len(fake.untagVersionArgsForCall)]
+	fake.untagVersionArgsForCall = append(fake.untagVersionArgsForCall, struct {
+		arg1 int
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("UntagVersion", []interface{}{arg1, arg2})
+	fake.untagVersionMutex.Unlock()
+	if fake.UntagVersionStub != nil {
+		return fake.UntagVersionStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.untagVersionReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) UntagVersionCallCount() int {
+	fake.untagVersionMutex.RLock()
+	defer fake.untagVersionMutex.RUnlock()
+	return len(fake.untagVersionArgsForCall)
+}
+
+func (fake *FakeResource) UntagVersionCalls(stub func(int, string) error) {
+	fake.untagVersionMutex.Lock()
+	defer fake.untagVersionMutex.Unlock()
+	fake.UntagVersionStub = stub
+}
+
+func (fake *FakeResource) UntagVersionArgsForCall(i int) (int, string) {
+	fake.untagVersionMutex.RLock()
+	defer fake.untagVersionMutex.RUnlock()
+	argsForCall := fake.untagVersionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeResource) UntagVersionReturns(result1 error) {
+	fake.untagVersionMutex.Lock()
+	defer fake.untagVersionMutex.Unlock()
+	fake.UntagVersionStub = nil
+	fake.untagVersionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) UntagVersionReturnsOnCall(i int, result1 error) {
+	fake.untagVersionMutex.Lock()
+	defer fake.untagVersionMutex.Unlock()
+	fake.UntagVersionStub = nil
+	if fake.untagVersionReturnsOnCall == nil {
+		fake.untagVersionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.untagVersionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResource) UpdateMetadata(arg1 atc.Version, arg2 db.ResourceConfigMetadataFields) (bool, error) {
 	fake.updateMetadataMutex.Lock()
 	ret, specificReturn := fake.updateMetadataReturnsOnCall[len(fake.updateMetadataArgsForCall)]
@@ -2339,6 +2707,69 @@ func (fake *FakeResource) VersionsReturnsOnCall(i int, result1 []atc.ResourceVer
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeResource) VersionsByTag(arg1 string) ([]db.ResourceConfigVersion, error) {
+	fake.versionsByTagMutex.Lock()
+	ret, specificReturn := fake.versionsByTagReturnsOnCall[len(fake.versionsByTagArgsForCall)]
+	fake.versionsByTagArgsForCall = append(fake.versionsByTagArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("VersionsByTag", []interface{}{arg1})
+	fake.versionsByTagMutex.Unlock()
+	if fake.VersionsByTagStub != nil {
+		return fake.VersionsByTagStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.versionsByTagReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) VersionsByTagCallCount() int {
+	fake.versionsByTagMutex.RLock()
+	defer fake.versionsByTagMutex.RUnlock()
+	return len(fake.versionsByTagArgsForCall)
+}
+
+func (fake *FakeResource) VersionsByTagCalls(stub func(string) ([]db.ResourceConfigVersion, error)) {
+	fake.versionsByTagMutex.Lock()
+	defer fake.versionsByTagMutex.Unlock()
+	fake.VersionsByTagStub = stub
+}
+
+func (fake *FakeResource) VersionsByTagArgsForCall(i int) string {
+	fake.versionsByTagMutex.RLock()
+	defer fake.versionsByTagMutex.RUnlock()
+	argsForCall := fake.versionsByTagArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResource) VersionsByTagReturns(result1 []db.ResourceConfigVersion, result2 error) {
+	fake.versionsByTagMutex.Lock()
+	defer fake.versionsByTagMutex.Unlock()
+	fake.VersionsByTagStub = nil
+	fake.versionsByTagReturns = struct {
+		result1 []db.ResourceConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) VersionsByTagReturnsOnCall(i int, result1 []db.ResourceConfigVersion, result2 error) {
+	fake.versionsByTagMutex.Lock()
+	defer fake.versionsByTagMutex.Unlock()
+	fake.VersionsByTagStub = nil
+	if fake.versionsByTagReturnsOnCall == nil {
+		fake.versionsByTagReturnsOnCall = make(map[int]struct {
+			result1 []db.ResourceConfigVersion
+			result2 error
+		})
+	}
+	fake.versionsByTagReturnsOnCall[i] = struct {
+		result1 []db.ResourceConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) WebhookToken() string {
 	fake.webhookTokenMutex.Lock()
 	ret, specificReturn := fake.webhookTokenReturnsOnCall[len(fake.webhookTokenArgsForCall)]
@@ -2410,6 +2841,8 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.currentPinnedVersionMutex.RUnlock()
 	fake.disableVersionMutex.RLock()
 	defer fake.disableVersionMutex.RUnlock()
+	fake.effectiveCheckConfigMutex.RLock()
+	defer fake.effectiveCheckConfigMutex.RUnlock()
 	fake.enableVersionMutex.RLock()
 	defer fake.enableVersionMutex.RUnlock()
 	fake.iDMutex.RLock()
@@ -2418,6 +2851,8 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.iconMutex.RUnlock()
 	fake.lastCheckEndTimeMutex.RLock()
 	defer fake.lastCheckEndTimeMutex.RUnlock()
+	fake.lastCheckReasonMutex.RLock()
+	defer fake.lastCheckReasonMutex.RUnlock()
 	fake.lastCheckStartTimeMutex.RLock()
 	defer fake.lastCheckStartTimeMutex.RUnlock()
 	fake.nameMutex.RLock()
@@ -2446,12 +2881,16 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.saveUncheckedVersionMutex.RUnlock()
 	fake.setCheckSetupErrorMutex.RLock()
 	defer fake.setCheckSetupErrorMutex.RUnlock()
+	fake.setLastCheckReasonMutex.RLock()
+	defer fake.setLastCheckReasonMutex.RUnlock()
 	fake.setPinCommentMutex.RLock()
 	defer fake.setPinCommentMutex.RUnlock()
 	fake.setResourceConfigMutex.RLock()
 	defer fake.setResourceConfigMutex.RUnlock()
 	fake.sourceMutex.RLock()
 	defer fake.sourceMutex.RUnlock()
+	fake.tagVersionMutex.RLock()
+	defer fake.tagVersionMutex.RUnlock()
 	fake.tagsMutex.RLock()
 	defer fake.tagsMutex.RUnlock()
 	fake.teamNameMutex.RLock()
@@ -2460,10 +2899,14 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.typeMutex.RUnlock()
 	fake.unpinVersionMutex.RLock()
 	defer fake.unpinVersionMutex.RUnlock()
+	fake.untagVersionMutex.RLock()
+	defer fake.untagVersionMutex.RUnlock()
 	fake.updateMetadataMutex.RLock()
 	defer fake.updateMetadataMutex.RUnlock()
 	fake.versionsMutex.RLock()
 	defer fake.versionsMutex.RUnlock()
+	fake.versionsByTagMutex.RLock()
+	defer fake.versionsByTagMutex.RUnlock()
 	fake.webhookTokenMutex.RLock()
 	defer fake.webhookTokenMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
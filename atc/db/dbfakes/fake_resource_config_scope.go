@@ -107,10 +107,11 @@ type FakeResourceConfigScope struct {
 	saveVersionsReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SetCheckErrorStub        func(error) error
+	SetCheckErrorStub        func(error, db.CheckBackoff) error
 	setCheckErrorMutex       sync.RWMutex
 	setCheckErrorArgsForCall []struct {
 		arg1 error
+		arg2 db.CheckBackoff
 	}
 	setCheckErrorReturns struct {
 		result1 error
@@ -611,16 +612,17 @@ func (fake *FakeResourceConfigScope) SaveVersionsReturnsOnCall(i int, result1 er
 	}{result1}
 }
 
-func (fake *FakeResourceConfigScope) SetCheckError(arg1 error) error {
+func (fake *FakeResourceConfigScope) SetCheckError(arg1 error, arg2 db.CheckBackoff) error {
 	fake.setCheckErrorMutex.Lock()
 	ret, specificReturn := fake.setCheckErrorReturnsOnCall[len(fake.setCheckErrorArgsForCall)]
 	fake.setCheckErrorArgsForCall = append(fake.setCheckErrorArgsForCall, struct {
 		arg1 error
-	}{arg1})
-	fake.recordInvocation("SetCheckError", []interface{}{arg1})
+		arg2 db.CheckBackoff
+	}{arg1, arg2})
+	fake.recordInvocation("SetCheckError", []interface{}{arg1, arg2})
 	fake.setCheckErrorMutex.Unlock()
 	if fake.SetCheckErrorStub != nil {
-		return fake.SetCheckErrorStub(arg1)
+		return fake.SetCheckErrorStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -635,17 +637,17 @@ func (fake *FakeResourceConfigScope) SetCheckErrorCallCount() int {
 	return len(fake.setCheckErrorArgsForCall)
 }
 
-func (fake *FakeResourceConfigScope) SetCheckErrorCalls(stub func(error) error) {
+func (fake *FakeResourceConfigScope) SetCheckErrorCalls(stub func(error, db.CheckBackoff) error) {
 	fake.setCheckErrorMutex.Lock()
 	defer fake.setCheckErrorMutex.Unlock()
 	fake.SetCheckErrorStub = stub
 }
 
-func (fake *FakeResourceConfigScope) SetCheckErrorArgsForCall(i int) error {
+func (fake *FakeResourceConfigScope) SetCheckErrorArgsForCall(i int) (error, db.CheckBackoff) {
 	fake.setCheckErrorMutex.RLock()
 	defer fake.setCheckErrorMutex.RUnlock()
 	argsForCall := fake.setCheckErrorArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeResourceConfigScope) SetCheckErrorReturns(result1 error) {
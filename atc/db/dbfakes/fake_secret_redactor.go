@@ -0,0 +1,115 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeSecretRedactor struct {
+	RedactStub        func([]byte) []byte
+	redactMutex       sync.RWMutex
+	redactArgsForCall []struct {
+		arg1 []byte
+	}
+	redactReturns struct {
+		result1 []byte
+	}
+	redactReturnsOnCall map[int]struct {
+		result1 []byte
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSecretRedactor) Redact(arg1 []byte) []byte {
+	var arg1Copy []byte
+	if arg1 != nil {
+		arg1Copy = make([]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.redactMutex.Lock()
+	ret, specificReturn := fake.redactReturnsOnCall[len(fake.redactArgsForCall)]
+	fake.redactArgsForCall = append(fake.redactArgsForCall, struct {
+		arg1 []byte
+	}{arg1Copy})
+	fake.recordInvocation("Redact", []interface{}{arg1Copy})
+	fake.redactMutex.Unlock()
+	if fake.RedactStub != nil {
+		return fake.RedactStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.redactReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeSecretRedactor) RedactCallCount() int {
+	fake.redactMutex.RLock()
+	defer fake.redactMutex.RUnlock()
+	return len(fake.redactArgsForCall)
+}
+
+func (fake *FakeSecretRedactor) RedactCalls(stub func([]byte) []byte) {
+	fake.redactMutex.Lock()
+	defer fake.redactMutex.Unlock()
+	fake.RedactStub = stub
+}
+
+func (fake *FakeSecretRedactor) RedactArgsForCall(i int) []byte {
+	fake.redactMutex.RLock()
+	defer fake.redactMutex.RUnlock()
+	argsForCall := fake.redactArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSecretRedactor) RedactReturns(result1 []byte) {
+	fake.redactMutex.Lock()
+	defer fake.redactMutex.Unlock()
+	fake.RedactStub = nil
+	fake.redactReturns = struct {
+		result1 []byte
+	}{result1}
+}
+
+func (fake *FakeSecretRedactor) RedactReturnsOnCall(i int, result1 []byte) {
+	fake.redactMutex.Lock()
+	defer fake.redactMutex.Unlock()
+	fake.RedactStub = nil
+	if fake.redactReturnsOnCall == nil {
+		fake.redactReturnsOnCall = make(map[int]struct {
+			result1 []byte
+		})
+	}
+	fake.redactReturnsOnCall[i] = struct {
+		result1 []byte
+	}{result1}
+}
+
+func (fake *FakeSecretRedactor) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.redactMutex.RLock()
+	defer fake.redactMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSecretRedactor) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.SecretRedactor = new(FakeSecretRedactor)
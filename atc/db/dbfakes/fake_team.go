@@ -85,6 +85,19 @@ type FakeTeam struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateOneOffBuildWithTagsStub        func([]string) (db.Build, error)
+	createOneOffBuildWithTagsMutex       sync.RWMutex
+	createOneOffBuildWithTagsArgsForCall []struct {
+		arg1 []string
+	}
+	createOneOffBuildWithTagsReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createOneOffBuildWithTagsReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	CreateStartedBuildStub        func(atc.Plan) (db.Build, error)
 	createStartedBuildMutex       sync.RWMutex
 	createStartedBuildArgsForCall []struct {
@@ -213,6 +226,19 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	FinishAbandonedBuildsStub        func(time.Time) (int, error)
+	finishAbandonedBuildsMutex       sync.RWMutex
+	finishAbandonedBuildsArgsForCall []struct {
+		arg1 time.Time
+	}
+	finishAbandonedBuildsReturns struct {
+		result1 int
+		result2 error
+	}
+	finishAbandonedBuildsReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	IDStub        func() int
 	iDMutex       sync.RWMutex
 	iDArgsForCall []struct {
@@ -260,6 +286,21 @@ type FakeTeam struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	OneOffBuildsStub        func(db.Page) ([]db.Build, db.Pagination, error)
+	oneOffBuildsMutex       sync.RWMutex
+	oneOffBuildsArgsForCall []struct {
+		arg1 db.Page
+	}
+	oneOffBuildsReturns struct {
+		result1 []db.Build
+		result2 db.Pagination
+		result3 error
+	}
+	oneOffBuildsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 db.Pagination
+		result3 error
+	}
 	OrderPipelinesStub        func([]string) error
 	orderPipelinesMutex       sync.RWMutex
 	orderPipelinesArgsForCall []struct {
@@ -753,6 +794,74 @@ func (fake *FakeTeam) CreateOneOffBuildReturnsOnCall(i int, result1 db.Build, re
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) CreateOneOffBuildWithTags(arg1 []string) (db.Build, error) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.createOneOffBuildWithTagsMutex.Lock()
+	ret, specificReturn := fake.createOneOffBuildWithTagsReturnsOnCall[len(fake.createOneOffBuildWithTagsArgsForCall)]
+	fake.createOneOffBuildWithTagsArgsForCall = append(fake.createOneOffBuildWithTagsArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	fake.recordInvocation("CreateOneOffBuildWithTags", []interface{}{arg1Copy})
+	fake.createOneOffBuildWithTagsMutex.Unlock()
+	if fake.CreateOneOffBuildWithTagsStub != nil {
+		return fake.CreateOneOffBuildWithTagsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createOneOffBuildWithTagsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) CreateOneOffBuildWithTagsCallCount() int {
+	fake.createOneOffBuildWithTagsMutex.RLock()
+	defer fake.createOneOffBuildWithTagsMutex.RUnlock()
+	return len(fake.createOneOffBuildWithTagsArgsForCall)
+}
+
+func (fake *FakeTeam) CreateOneOffBuildWithTagsCalls(stub func([]string) (db.Build, error)) {
+	fake.createOneOffBuildWithTagsMutex.Lock()
+	defer fake.createOneOffBuildWithTagsMutex.Unlock()
+	fake.CreateOneOffBuildWithTagsStub = stub
+}
+
+func (fake *FakeTeam) CreateOneOffBuildWithTagsArgsForCall(i int) []string {
+	fake.createOneOffBuildWithTagsMutex.RLock()
+	defer fake.createOneOffBuildWithTagsMutex.RUnlock()
+	argsForCall := fake.createOneOffBuildWithTagsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) CreateOneOffBuildWithTagsReturns(result1 db.Build, result2 error) {
+	fake.createOneOffBuildWithTagsMutex.Lock()
+	defer fake.createOneOffBuildWithTagsMutex.Unlock()
+	fake.CreateOneOffBuildWithTagsStub = nil
+	fake.createOneOffBuildWithTagsReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) CreateOneOffBuildWithTagsReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.createOneOffBuildWithTagsMutex.Lock()
+	defer fake.createOneOffBuildWithTagsMutex.Unlock()
+	fake.CreateOneOffBuildWithTagsStub = nil
+	if fake.createOneOffBuildWithTagsReturnsOnCall == nil {
+		fake.createOneOffBuildWithTagsReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createOneOffBuildWithTagsReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) CreateStartedBuild(arg1 atc.Plan) (db.Build, error) {
 	fake.createStartedBuildMutex.Lock()
 	ret, specificReturn := fake.createStartedBuildReturnsOnCall[len(fake.createStartedBuildArgsForCall)]
@@ -1329,6 +1438,69 @@ func (fake *FakeTeam) FindWorkerForVolumeReturnsOnCall(i int, result1 db.Worker,
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) FinishAbandonedBuilds(arg1 time.Time) (int, error) {
+	fake.finishAbandonedBuildsMutex.Lock()
+	ret, specificReturn := fake.finishAbandonedBuildsReturnsOnCall[len(fake.finishAbandonedBuildsArgsForCall)]
+	fake.finishAbandonedBuildsArgsForCall = append(fake.finishAbandonedBuildsArgsForCall, struct {
+		arg1 time.Time
+	}{arg1})
+	fake.recordInvocation("FinishAbandonedBuilds", []interface{}{arg1})
+	fake.finishAbandonedBuildsMutex.Unlock()
+	if fake.FinishAbandonedBuildsStub != nil {
+		return fake.FinishAbandonedBuildsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.finishAbandonedBuildsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) FinishAbandonedBuildsCallCount() int {
+	fake.finishAbandonedBuildsMutex.RLock()
+	defer fake.finishAbandonedBuildsMutex.RUnlock()
+	return len(fake.finishAbandonedBuildsArgsForCall)
+}
+
+func (fake *FakeTeam) FinishAbandonedBuildsCalls(stub func(time.Time) (int, error)) {
+	fake.finishAbandonedBuildsMutex.Lock()
+	defer fake.finishAbandonedBuildsMutex.Unlock()
+	fake.FinishAbandonedBuildsStub = stub
+}
+
+func (fake *FakeTeam) FinishAbandonedBuildsArgsForCall(i int) time.Time {
+	fake.finishAbandonedBuildsMutex.RLock()
+	defer fake.finishAbandonedBuildsMutex.RUnlock()
+	argsForCall := fake.finishAbandonedBuildsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) FinishAbandonedBuildsReturns(result1 int, result2 error) {
+	fake.finishAbandonedBuildsMutex.Lock()
+	defer fake.finishAbandonedBuildsMutex.Unlock()
+	fake.FinishAbandonedBuildsStub = nil
+	fake.finishAbandonedBuildsReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) FinishAbandonedBuildsReturnsOnCall(i int, result1 int, result2 error) {
+	fake.finishAbandonedBuildsMutex.Lock()
+	defer fake.finishAbandonedBuildsMutex.Unlock()
+	fake.FinishAbandonedBuildsStub = nil
+	if fake.finishAbandonedBuildsReturnsOnCall == nil {
+		fake.finishAbandonedBuildsReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.finishAbandonedBuildsReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) ID() int {
 	fake.iDMutex.Lock()
 	ret, specificReturn := fake.iDReturnsOnCall[len(fake.iDArgsForCall)]
@@ -1560,6 +1732,72 @@ func (fake *FakeTeam) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeTeam) OneOffBuilds(arg1 db.Page) ([]db.Build, db.Pagination, error) {
+	fake.oneOffBuildsMutex.Lock()
+	ret, specificReturn := fake.oneOffBuildsReturnsOnCall[len(fake.oneOffBuildsArgsForCall)]
+	fake.oneOffBuildsArgsForCall = append(fake.oneOffBuildsArgsForCall, struct {
+		arg1 db.Page
+	}{arg1})
+	fake.recordInvocation("OneOffBuilds", []interface{}{arg1})
+	fake.oneOffBuildsMutex.Unlock()
+	if fake.OneOffBuildsStub != nil {
+		return fake.OneOffBuildsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.oneOffBuildsReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) OneOffBuildsCallCount() int {
+	fake.oneOffBuildsMutex.RLock()
+	defer fake.oneOffBuildsMutex.RUnlock()
+	return len(fake.oneOffBuildsArgsForCall)
+}
+
+func (fake *FakeTeam) OneOffBuildsCalls(stub func(db.Page) ([]db.Build, db.Pagination, error)) {
+	fake.oneOffBuildsMutex.Lock()
+	defer fake.oneOffBuildsMutex.Unlock()
+	fake.OneOffBuildsStub = stub
+}
+
+func (fake *FakeTeam) OneOffBuildsArgsForCall(i int) db.Page {
+	fake.oneOffBuildsMutex.RLock()
+	defer fake.oneOffBuildsMutex.RUnlock()
+	argsForCall := fake.oneOffBuildsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) OneOffBuildsReturns(result1 []db.Build, result2 db.Pagination, result3 error) {
+	fake.oneOffBuildsMutex.Lock()
+	defer fake.oneOffBuildsMutex.Unlock()
+	fake.OneOffBuildsStub = nil
+	fake.oneOffBuildsReturns = struct {
+		result1 []db.Build
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) OneOffBuildsReturnsOnCall(i int, result1 []db.Build, result2 db.Pagination, result3 error) {
+	fake.oneOffBuildsMutex.Lock()
+	defer fake.oneOffBuildsMutex.Unlock()
+	fake.OneOffBuildsStub = nil
+	if fake.oneOffBuildsReturnsOnCall == nil {
+		fake.oneOffBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 db.Pagination
+			result3 error
+		})
+	}
+	fake.oneOffBuildsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) OrderPipelines(arg1 []string) error {
 	var arg1Copy []string
 	if arg1 != nil {
@@ -2245,6 +2483,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.containersMutex.RUnlock()
 	fake.createOneOffBuildMutex.RLock()
 	defer fake.createOneOffBuildMutex.RUnlock()
+	fake.createOneOffBuildWithTagsMutex.RLock()
+	defer fake.createOneOffBuildWithTagsMutex.RUnlock()
 	fake.createStartedBuildMutex.RLock()
 	defer fake.createStartedBuildMutex.RUnlock()
 	fake.deleteMutex.RLock()
@@ -2263,6 +2503,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.findWorkerForContainerMutex.RUnlock()
 	fake.findWorkerForVolumeMutex.RLock()
 	defer fake.findWorkerForVolumeMutex.RUnlock()
+	fake.finishAbandonedBuildsMutex.RLock()
+	defer fake.finishAbandonedBuildsMutex.RUnlock()
 	fake.iDMutex.RLock()
 	defer fake.iDMutex.RUnlock()
 	fake.isCheckContainerMutex.RLock()
@@ -2271,6 +2513,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.isContainerWithinTeamMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.oneOffBuildsMutex.RLock()
+	defer fake.oneOffBuildsMutex.RUnlock()
 	fake.orderPipelinesMutex.RLock()
 	defer fake.orderPipelinesMutex.RUnlock()
 	fake.pipelineMutex.RLock()
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
@@ -34,10 +35,14 @@ type Job interface {
 	Unpause() error
 
 	CreateBuild() (Build, error)
+	CreateBuildWithInputs(inputMapping algorithm.InputMapping, resolved bool) (Build, error)
+	CreateRetryBuild(parent Build) (Build, error)
+	CreateBuildWithKey(idempotencyKey string, ttl time.Duration) (Build, error)
 	Builds(page Page) ([]Build, Pagination, error)
 	BuildsWithTime(page Page) ([]Build, Pagination, error)
 	Build(name string) (Build, bool, error)
 	FinishedAndNextBuild() (Build, Build, error)
+	LatestSuccessfulBuildOutputs() ([]BuildOutput, bool, error)
 	UpdateFirstLoggedBuildID(newFirstLoggedBuildID int) error
 	EnsurePendingBuildExists() error
 	GetPendingBuilds() ([]Build, error)
@@ -50,6 +55,7 @@ type Job interface {
 
 	SetMaxInFlightReached(bool) error
 	GetRunningBuildsBySerialGroup(serialGroups []string) ([]Build, error)
+	RunningBuilds() ([]Build, error)
 	GetNextPendingBuildBySerialGroup(serialGroups []string) (Build, bool, error)
 
 	ClearTaskCache(string, string) (int64, error)
@@ -185,6 +191,67 @@ func (j *job) FinishedAndNextBuild() (Build, Build, error) {
 	return finished, next, nil
 }
 
+// LatestSuccessfulBuildOutputs returns the outputs of the job's most recent
+// successful build. It returns false if the job has never succeeded.
+func (j *job) LatestSuccessfulBuildOutputs() ([]BuildOutput, bool, error) {
+	var buildID int
+	err := psql.Select("id").
+		From("builds").
+		Where(sq.Eq{
+			"job_id": j.id,
+			"status": BuildStatusSucceeded,
+		}).
+		OrderBy("id DESC").
+		Limit(1).
+		RunWith(j.conn).
+		QueryRow().
+		Scan(&buildID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	rows, err := psql.Select("o.id", "o.name", "o.resource_id", "v.version").
+		From("build_resource_config_version_outputs o").
+		Join("resource_config_versions v ON v.version_md5 = o.version_md5").
+		Join("resources r ON r.id = o.resource_id").
+		Where(sq.Expr("r.resource_config_scope_id = v.resource_config_scope_id")).
+		Where(sq.NotEq{"v.check_order": 0}).
+		Where(sq.Eq{"o.build_id": buildID}).
+		OrderBy("o.id ASC").
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer Close(rows)
+
+	outputs := []BuildOutput{}
+	for rows.Next() {
+		var (
+			output      BuildOutput
+			versionBlob string
+		)
+
+		err = rows.Scan(&output.ID, &output.Name, &output.ResourceID, &versionBlob)
+		if err != nil {
+			return nil, false, err
+		}
+
+		err = json.Unmarshal([]byte(versionBlob), &output.Version)
+		if err != nil {
+			return nil, false, err
+		}
+
+		outputs = append(outputs, output)
+	}
+
+	return outputs, true, nil
+}
+
 func (j *job) UpdateFirstLoggedBuildID(newFirstLoggedBuildID int) error {
 	if j.firstLoggedBuildID > newFirstLoggedBuildID {
 		return FirstLoggedBuildIDDecreasedError{
@@ -274,15 +341,23 @@ func (j *job) GetNextPendingBuildBySerialGroup(serialGroups []string) (Build, bo
 		return nil, false, err
 	}
 
-	row := buildsQuery.Options(`DISTINCT ON (b.id)`).
-		Join(`jobs_serial_groups jsg ON j.id = jsg.job_id`).
+	serialGroupJobIDs, args, err := sq.Select("job_id").
+		From("jobs_serial_groups").
+		Where(sq.Eq{"serial_group": serialGroups}).
+		ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+
+	row := buildsQuery.
+		Where(sq.Expr("j.id IN ("+serialGroupJobIDs+")", args...)).
 		Where(sq.Eq{
-			"jsg.serial_group":    serialGroups,
 			"b.status":            BuildStatusPending,
+			"b.held":              false,
 			"j.paused":            false,
 			"j.inputs_determined": true,
 			"j.pipeline_id":       j.pipelineID}).
-		OrderBy("b.id ASC").
+		OrderBy(pendingBuildsOrder...).
 		Limit(1).
 		RunWith(j.conn).
 		QueryRow()
@@ -299,6 +374,39 @@ func (j *job) GetNextPendingBuildBySerialGroup(serialGroups []string) (Build, bo
 	return build, true, nil
 }
 
+// RunningBuilds returns the job's builds which have been scheduled but have
+// not completed, which are the builds counted against its max in flight.
+func (j *job) RunningBuilds() ([]Build, error) {
+	rows, err := buildsQuery.
+		Where(sq.Eq{
+			"b.job_id":    j.id,
+			"b.completed": false,
+			"b.scheduled": true,
+		}).
+		OrderBy("b.id ASC").
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	bs := []Build{}
+
+	for rows.Next() {
+		build := &build{conn: j.conn, lockFactory: j.lockFactory}
+		err = scanBuild(build, rows, j.conn.EncryptionStrategy())
+		if err != nil {
+			return nil, err
+		}
+
+		bs = append(bs, build)
+	}
+
+	return bs, nil
+}
+
 func (j *job) GetRunningBuildsBySerialGroup(serialGroups []string) ([]Build, error) {
 	err := j.updateSerialGroups(serialGroups)
 	if err != nil {
@@ -440,8 +548,8 @@ func (j *job) EnsurePendingBuildExists() error {
 	}
 
 	rows, err := tx.Query(`
-		INSERT INTO builds (name, job_id, pipeline_id, team_id, status)
-		SELECT $1, $2, $3, $4, 'pending'
+		INSERT INTO builds (name, job_id, pipeline_id, team_id, status, origin)
+		SELECT $1, $2, $3, $4, 'pending', 'scheduler'
 		WHERE NOT EXISTS
 			(SELECT id FROM builds WHERE job_id = $2 AND status = 'pending')
 		RETURNING id
@@ -495,7 +603,7 @@ func (j *job) GetPendingBuilds() ([]Build, error) {
 			"b.job_id": j.id,
 			"b.status": BuildStatusPending,
 		}).
-		OrderBy("b.id ASC").
+		OrderBy(pendingBuildsOrder...).
 		RunWith(j.conn).
 		Query()
 	if err != nil {
@@ -538,6 +646,190 @@ func (j *job) CreateBuild() (Build, error) {
 		"team_id":            j.teamID,
 		"status":             BuildStatusPending,
 		"manually_triggered": true,
+		"origin":             BuildOriginAPI,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = updateNextBuildForJob(tx, j.id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
+// CreateBuildWithInputs creates a build like CreateBuild and, in the same
+// transaction, saves the given mapping as the job's next inputs, so that the
+// scheduler cannot determine other inputs in between. Resolved says whether
+// the mapping satisfies all of the job's inputs.
+func (j *job) CreateBuildWithInputs(inputMapping algorithm.InputMapping, resolved bool) (Build, error) {
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	buildName, err := j.getNewBuildName(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	build := &build{conn: j.conn, lockFactory: j.lockFactory}
+	err = createBuild(tx, build, map[string]interface{}{
+		"name":               buildName,
+		"job_id":             j.id,
+		"pipeline_id":        j.pipelineID,
+		"team_id":            j.teamID,
+		"status":             BuildStatusPending,
+		"manually_triggered": true,
+		"origin":             BuildOriginAPI,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = updateNextBuildForJob(tx, j.id)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = psql.Update("jobs").
+		Set("inputs_determined", resolved).
+		Where(sq.Eq{"id": j.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	err = j.replaceInputMapping(tx, "next_build_inputs", inputMapping)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
+// CreateRetryBuild creates a pending build of the job as a rerun of the given
+// build, with a retry count one higher than the parent's.
+func (j *job) CreateRetryBuild(parent Build) (Build, error) {
+	if parent.JobID() != j.id {
+		return nil, ErrRetryOfOtherJobBuild
+	}
+
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	buildName, err := j.getNewBuildName(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	build := &build{conn: j.conn, lockFactory: j.lockFactory}
+	err = createBuild(tx, build, map[string]interface{}{
+		"name":        buildName,
+		"job_id":      j.id,
+		"pipeline_id": j.pipelineID,
+		"team_id":     j.teamID,
+		"status":      BuildStatusPending,
+		"rerun_of":    parent.ID(),
+		"retry_count": parent.RetryCount() + 1,
+		"origin":      BuildOriginRerun,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = updateNextBuildForJob(tx, j.id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
+// CreateBuildWithKey creates a build like CreateBuild, unless a build of the
+// job was already created with the same key less than its ttl ago, in which
+// case that build is returned instead. The key of the new build expires after
+// the given ttl.
+func (j *job) CreateBuildWithKey(idempotencyKey string, ttl time.Duration) (Build, error) {
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	existing := &build{conn: j.conn, lockFactory: j.lockFactory}
+	row := buildsQuery.
+		Where(sq.Eq{
+			"b.job_id":          j.id,
+			"b.idempotency_key": idempotencyKey,
+		}).
+		Where(sq.Expr("b.idempotency_key_expires_at > now()")).
+		RunWith(tx).
+		QueryRow()
+
+	err = scanBuild(existing, row, j.conn.EncryptionStrategy())
+	if err == nil {
+		return existing, tx.Commit()
+	}
+
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	_, err = psql.Update("builds").
+		Set("idempotency_key", nil).
+		Set("idempotency_key_expires_at", nil).
+		Where(sq.Eq{
+			"job_id":          j.id,
+			"idempotency_key": idempotencyKey,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	buildName, err := j.getNewBuildName(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	build := &build{conn: j.conn, lockFactory: j.lockFactory}
+	err = createBuild(tx, build, map[string]interface{}{
+		"name":                       buildName,
+		"job_id":                     j.id,
+		"pipeline_id":                j.pipelineID,
+		"team_id":                    j.teamID,
+		"status":                     BuildStatusPending,
+		"manually_triggered":         true,
+		"origin":                     BuildOriginAPI,
+		"idempotency_key":            idempotencyKey,
+		"idempotency_key_expires_at": sq.Expr(fmt.Sprintf("now() + '%d seconds'::interval", int(ttl.Seconds()))),
 	})
 	if err != nil {
 		return nil, err
@@ -725,6 +1017,17 @@ func (j *job) saveJobInputMapping(table string, inputMapping algorithm.InputMapp
 		return err
 	}
 
+	err = j.replaceInputMapping(tx, table, inputMapping)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// replaceInputMapping makes the job's rows in the given input mapping table
+// match the mapping, leaving unchanged inputs alone.
+func (j *job) replaceInputMapping(tx Tx, table string, inputMapping algorithm.InputMapping) error {
 	rows, err := psql.Select("input_name, resource_config_version_id, resource_id, first_occurrence").
 		From(table).
 		Where(sq.Eq{"job_id": j.id}).
@@ -781,7 +1084,7 @@ func (j *job) saveJobInputMapping(table string, inputMapping algorithm.InputMapp
 		}
 	}
 
-	return tx.Commit()
+	return nil
 }
 
 func (j *job) nextBuild() (Build, error) {
@@ -246,6 +246,71 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("LatestSuccessfulBuildOutputs", func() {
+		var resource db.Resource
+
+		BeforeEach(func() {
+			setupTx, err := dbConn.Begin()
+			Expect(err).ToNot(HaveOccurred())
+
+			brt := db.BaseResourceType{
+				Name: "some-type",
+			}
+
+			_, err = brt.FindOrCreate(setupTx, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(setupTx.Commit()).To(Succeed())
+
+			var found bool
+			resource, found, err = pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, err = resource.SetResourceConfig(atc.Source{"some": "source"}, atc.VersionedResourceTypes{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns false when the job has no successful build", func() {
+			build, err := job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.Finish(db.BuildStatusFailed)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, found, err := job.LatestSuccessfulBuildOutputs()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("returns the outputs of the latest successful build", func() {
+			failedBuild, err := job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			succeededBuild, err := job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = failedBuild.SaveOutput("some-type", atc.Source{"some": "source"}, atc.VersionedResourceTypes{}, atc.Version{"ver": "1"}, nil, "some-output", "some-resource")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = failedBuild.Finish(db.BuildStatusFailed)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = succeededBuild.SaveOutput("some-type", atc.Source{"some": "source"}, atc.VersionedResourceTypes{}, atc.Version{"ver": "2"}, nil, "some-output", "some-resource")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = succeededBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			outputs, found, err := job.LatestSuccessfulBuildOutputs()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(outputs).To(HaveLen(1))
+			Expect(outputs[0].Name).To(Equal("some-output"))
+			Expect(outputs[0].Version).To(Equal(atc.Version{"ver": "2"}))
+			Expect(outputs[0].ResourceID).To(Equal(resource.ID()))
+		})
+	})
+
 	Describe("UpdateFirstLoggedBuildID", func() {
 		It("updates FirstLoggedBuildID on a job", func() {
 			By("starting out as 0")
@@ -515,6 +580,55 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("RunningBuilds", func() {
+		It("returns the job's running builds in the order they were created", func() {
+			_, err := job.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			var runningBuilds []db.Build
+			for i := 0; i < 2; i++ {
+				build, err := job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				scheduled, err := build.Schedule()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scheduled).To(BeTrue())
+
+				started, err := build.Start(atc.Plan{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+
+				runningBuilds = append(runningBuilds, build)
+			}
+
+			finishedBuild, err := job.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			scheduled, err := finishedBuild.Schedule()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(scheduled).To(BeTrue())
+
+			err = finishedBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			otherJob, found, err := pipeline.Job("some-other-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			otherBuild, err := otherJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = otherBuild.Schedule()
+			Expect(err).NotTo(HaveOccurred())
+
+			builds, err := job.RunningBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(2))
+			Expect(builds[0].ID()).To(Equal(runningBuilds[0].ID()))
+			Expect(builds[1].ID()).To(Equal(runningBuilds[1].ID()))
+		})
+	})
+
 	Describe("GetRunningBuildsBySerialGroup", func() {
 		Describe("same job", func() {
 			var startedBuild, scheduledBuild db.Build
@@ -649,6 +763,46 @@ var _ = Describe("Job", func() {
 			})
 		})
 
+		Context("when the most pending build is held", func() {
+			It("returns the next build which is not held", func() {
+				heldBuild, err := job1.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				nextBuild, err := job1.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = job1.SaveNextInputMapping(nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = heldBuild.Hold()
+				Expect(err).NotTo(HaveOccurred())
+
+				build, found, err := job1.GetNextPendingBuildBySerialGroup([]string{"serial-group"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.ID()).To(Equal(nextBuild.ID()))
+			})
+		})
+
+		Context("when a manually triggered build is created after an automatic one", func() {
+			It("returns the manually triggered build first", func() {
+				err := job1.EnsurePendingBuildExists()
+				Expect(err).NotTo(HaveOccurred())
+
+				manualBuild, err := job1.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = job1.SaveNextInputMapping(nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				build, found, err := job1.GetNextPendingBuildBySerialGroup([]string{"serial-group"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.ID()).To(Equal(manualBuild.ID()))
+				Expect(build.IsManuallyTriggered()).To(BeTrue())
+			})
+		})
+
 		It("should return the next most pending build in a group of jobs", func() {
 			buildOne, err := job1.CreateBuild()
 			Expect(err).NotTo(HaveOccurred())
@@ -1096,6 +1250,48 @@ var _ = Describe("Job", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeFalse())
 		})
+
+		Context("when the build is created with inputs", func() {
+			var inputMapping algorithm.InputMapping
+
+			BeforeEach(func() {
+				inputMapping = algorithm.InputMapping{
+					"some-input": algorithm.InputVersion{
+						VersionID:       versions[1].ID,
+						ResourceID:      resource.ID(),
+						FirstOccurrence: true,
+					},
+				}
+			})
+
+			It("seeds the next inputs which the created build immediately adopts", func() {
+				build, err := job.CreateBuildWithInputs(inputMapping, true)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(build.Status()).To(Equal(db.BuildStatusPending))
+
+				nextInputs, found, err := job.GetNextBuildInputs()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = build.UseInputs(nextInputs)
+				Expect(err).NotTo(HaveOccurred())
+
+				inputs, _, err := build.Resources()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(inputs).To(ConsistOf([]db.BuildInput{
+					{Name: "some-input", Version: atc.Version{"version": "v2"}, ResourceID: resource.ID(), FirstOccurrence: true},
+				}))
+			})
+
+			It("does not report the inputs as found when they are not resolved", func() {
+				_, err := job.CreateBuildWithInputs(inputMapping, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, found, err := job.GetNextBuildInputs()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
 	})
 
 	Describe("a build is created for a job", func() {
@@ -1276,6 +1472,135 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("CreateBuildWithKey", func() {
+		It("returns the same build when called twice with the same key", func() {
+			build, err := job.CreateBuildWithKey("some-key", time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+
+			sameBuild, err := job.CreateBuildWithKey("some-key", time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sameBuild.ID()).To(Equal(build.ID()))
+
+			builds, _, err := job.Builds(db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+		})
+
+		It("creates separate builds for different keys", func() {
+			build, err := job.CreateBuildWithKey("some-key", time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+
+			otherBuild, err := job.CreateBuildWithKey("some-other-key", time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(otherBuild.ID()).NotTo(Equal(build.ID()))
+
+			builds, _, err := job.Builds(db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(2))
+		})
+
+		It("scopes keys to the job", func() {
+			otherJob, found, err := pipeline.Job("some-other-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			build, err := job.CreateBuildWithKey("some-key", time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+
+			otherBuild, err := otherJob.CreateBuildWithKey("some-key", time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(otherBuild.ID()).NotTo(Equal(build.ID()))
+			Expect(otherBuild.JobID()).To(Equal(otherJob.ID()))
+		})
+
+		Context("when the key has expired", func() {
+			It("creates a new build", func() {
+				build, err := job.CreateBuildWithKey("some-key", 0)
+				Expect(err).NotTo(HaveOccurred())
+
+				newBuild, err := job.CreateBuildWithKey("some-key", time.Hour)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(newBuild.ID()).NotTo(Equal(build.ID()))
+			})
+		})
+	})
+
+	Describe("CreateRetryBuild", func() {
+		var parent db.Build
+
+		BeforeEach(func() {
+			var err error
+			parent, err = job.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("reports a retry count of zero for builds which are not retries", func() {
+			Expect(parent.RetryCount()).To(BeZero())
+		})
+
+		It("records rerun as the origin of the retry", func() {
+			build, err := job.CreateRetryBuild(parent)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.Origin()).To(Equal(db.BuildOriginRerun))
+		})
+
+		It("increments the retry count along the chain of retries", func() {
+			build := parent
+			for i := 1; i <= 3; i++ {
+				var err error
+				build, err = job.CreateRetryBuild(build)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(build.Status()).To(Equal(db.BuildStatusPending))
+				Expect(build.RetryCount()).To(Equal(i))
+			}
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.RetryCount()).To(Equal(3))
+		})
+
+		It("allows capping retries based on the retry count", func() {
+			maxRetries := 2
+
+			retries := 0
+			for build := parent; build.RetryCount() < maxRetries; retries++ {
+				var err error
+				build, err = job.CreateRetryBuild(build)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			Expect(retries).To(Equal(maxRetries))
+		})
+
+		It("does not retry builds of other jobs", func() {
+			otherJob, found, err := pipeline.Job("some-other-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, err = otherJob.CreateRetryBuild(parent)
+			Expect(err).To(Equal(db.ErrRetryOfOtherJobBuild))
+		})
+	})
+
+	Describe("build origins", func() {
+		It("records the API as the origin of manually created builds", func() {
+			build, err := job.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.Origin()).To(Equal(db.BuildOriginAPI))
+		})
+
+		It("records the scheduler as the origin of pending builds it ensures", func() {
+			err := job.EnsurePendingBuildExists()
+			Expect(err).NotTo(HaveOccurred())
+
+			pendingBuilds, err := job.GetPendingBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pendingBuilds).To(HaveLen(1))
+			Expect(pendingBuilds[0].Origin()).To(Equal(db.BuildOriginScheduler))
+		})
+	})
+
 	Describe("EnsurePendingBuildExists", func() {
 		Context("when only a started build exists", func() {
 			BeforeEach(func() {
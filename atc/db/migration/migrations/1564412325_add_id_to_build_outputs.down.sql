@@ -0,0 +1,5 @@
+BEGIN;
+
+  ALTER TABLE build_resource_config_version_outputs DROP COLUMN id;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+  ALTER TABLE build_resource_config_version_outputs ADD COLUMN id serial PRIMARY KEY;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+  ALTER TABLE builds
+    DROP COLUMN cached_preparation,
+    DROP COLUMN cached_preparation_key;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+  ALTER TABLE builds
+    ADD COLUMN cached_preparation text,
+    ADD COLUMN cached_preparation_key text;
+
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE resource_version_tags;
+COMMIT;
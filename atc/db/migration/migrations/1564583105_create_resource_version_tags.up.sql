@@ -0,0 +1,13 @@
+BEGIN;
+  CREATE TABLE resource_version_tags (
+    resource_id integer NOT NULL REFERENCES resources (id) ON DELETE CASCADE,
+    version_md5 text NOT NULL,
+    tag text NOT NULL
+  );
+
+  CREATE UNIQUE INDEX resource_version_tags_resource_id_version_md5_tag_uniq
+  ON resource_version_tags (resource_id, version_md5, tag);
+
+  CREATE INDEX resource_version_tags_resource_id_tag_idx
+  ON resource_version_tags (resource_id, tag);
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE builds
+    DROP COLUMN inputs_determined_at;
+
+COMMIT;
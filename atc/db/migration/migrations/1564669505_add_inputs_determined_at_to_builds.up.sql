@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE builds
+    ADD COLUMN inputs_determined_at timestamp with time zone;
+
+COMMIT;
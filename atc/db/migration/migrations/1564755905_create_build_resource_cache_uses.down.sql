@@ -0,0 +1,5 @@
+BEGIN;
+
+  DROP TABLE build_resource_cache_uses;
+
+COMMIT;
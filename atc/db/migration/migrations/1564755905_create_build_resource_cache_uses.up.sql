@@ -0,0 +1,14 @@
+BEGIN;
+
+  CREATE TABLE build_resource_cache_uses (
+    build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    resource_cache_id integer NOT NULL REFERENCES resource_caches (id) ON DELETE CASCADE
+  );
+
+  CREATE UNIQUE INDEX build_resource_cache_uses_uniq
+    ON build_resource_cache_uses (build_id, resource_cache_id);
+
+  CREATE INDEX build_resource_cache_uses_resource_cache_id
+    ON build_resource_cache_uses (resource_cache_id);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE builds
+    DROP COLUMN waiting_for_worker_tags;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE builds
+    ADD COLUMN waiting_for_worker_tags text[];
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+  ALTER TABLE builds
+    DROP COLUMN rerun_of,
+    DROP COLUMN retry_count;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+  ALTER TABLE builds
+    ADD COLUMN rerun_of integer REFERENCES builds (id) ON DELETE SET NULL,
+    ADD COLUMN retry_count integer NOT NULL DEFAULT 0;
+
+  CREATE INDEX builds_rerun_of ON builds (rerun_of);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE builds
+    ADD COLUMN held boolean NOT NULL DEFAULT false;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+  ALTER TABLE builds
+    DROP COLUMN origin;
+
+  DROP TYPE build_origin;
+
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+
+  CREATE TYPE build_origin AS ENUM (
+    'scheduler',
+    'api',
+    'webhook',
+    'rerun'
+  );
+
+  ALTER TABLE builds
+    ADD COLUMN origin build_origin NOT NULL DEFAULT 'scheduler';
+
+  UPDATE builds
+    SET origin = 'api'
+    WHERE manually_triggered OR job_id IS NULL;
+
+  UPDATE builds
+    SET origin = 'rerun'
+    WHERE rerun_of IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+  ALTER TABLE builds
+    ADD COLUMN abort_reason text;
+
+  UPDATE builds
+    SET abort_reason = 'user'
+    WHERE aborted;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+  DROP INDEX builds_job_id_idempotency_key_uniq;
+
+  ALTER TABLE builds
+    DROP COLUMN idempotency_key,
+    DROP COLUMN idempotency_key_expires_at;
+
+COMMIT;
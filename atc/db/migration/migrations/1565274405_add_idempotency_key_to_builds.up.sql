@@ -0,0 +1,11 @@
+BEGIN;
+
+  ALTER TABLE builds
+    ADD COLUMN idempotency_key text,
+    ADD COLUMN idempotency_key_expires_at timestamp with time zone;
+
+  CREATE UNIQUE INDEX builds_job_id_idempotency_key_uniq
+    ON builds (job_id, idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+
+COMMIT;
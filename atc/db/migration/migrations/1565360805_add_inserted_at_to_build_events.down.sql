@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE build_events
+    DROP COLUMN inserted_at;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+  ALTER TABLE build_events
+    ADD COLUMN inserted_at timestamp with time zone;
+
+  ALTER TABLE build_events
+    ALTER COLUMN inserted_at SET DEFAULT now();
+
+COMMIT;
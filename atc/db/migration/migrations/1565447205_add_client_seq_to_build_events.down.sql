@@ -0,0 +1,22 @@
+BEGIN;
+
+  CREATE OR REPLACE FUNCTION on_team_insert() RETURNS TRIGGER AS $$
+  BEGIN
+          EXECUTE format('CREATE TABLE IF NOT EXISTS team_build_events_%s () INHERITS (build_events)', NEW.id);
+          RETURN NULL;
+  END;
+  $$ LANGUAGE plpgsql;
+
+  CREATE OR REPLACE FUNCTION on_pipeline_insert() RETURNS TRIGGER AS $$
+  BEGIN
+          EXECUTE format('CREATE TABLE IF NOT EXISTS pipeline_build_events_%s () INHERITS (build_events)', NEW.id);
+          EXECUTE format('CREATE INDEX IF NOT EXISTS pipeline_build_events_%s_build_id ON pipeline_build_events_%s (build_id)', NEW.id, NEW.id);
+          EXECUTE format('CREATE UNIQUE INDEX IF NOT EXISTS pipeline_build_events_%s_build_id_event_id ON pipeline_build_events_%s (build_id, event_id)', NEW.id, NEW.id);
+          RETURN NULL;
+  END;
+  $$ LANGUAGE plpgsql;
+
+  ALTER TABLE build_events
+    DROP COLUMN client_seq;
+
+COMMIT;
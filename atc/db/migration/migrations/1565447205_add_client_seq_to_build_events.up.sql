@@ -0,0 +1,38 @@
+BEGIN;
+
+  ALTER TABLE build_events
+    ADD COLUMN client_seq bigint;
+
+  CREATE OR REPLACE FUNCTION on_team_insert() RETURNS TRIGGER AS $$
+  BEGIN
+          EXECUTE format('CREATE TABLE IF NOT EXISTS team_build_events_%s () INHERITS (build_events)', NEW.id);
+          EXECUTE format('CREATE UNIQUE INDEX IF NOT EXISTS team_build_events_%s_build_id_client_seq ON team_build_events_%s (build_id, client_seq) WHERE client_seq IS NOT NULL', NEW.id, NEW.id);
+          RETURN NULL;
+  END;
+  $$ LANGUAGE plpgsql;
+
+  CREATE OR REPLACE FUNCTION on_pipeline_insert() RETURNS TRIGGER AS $$
+  BEGIN
+          EXECUTE format('CREATE TABLE IF NOT EXISTS pipeline_build_events_%s () INHERITS (build_events)', NEW.id);
+          EXECUTE format('CREATE INDEX IF NOT EXISTS pipeline_build_events_%s_build_id ON pipeline_build_events_%s (build_id)', NEW.id, NEW.id);
+          EXECUTE format('CREATE UNIQUE INDEX IF NOT EXISTS pipeline_build_events_%s_build_id_event_id ON pipeline_build_events_%s (build_id, event_id)', NEW.id, NEW.id);
+          EXECUTE format('CREATE UNIQUE INDEX IF NOT EXISTS pipeline_build_events_%s_build_id_client_seq ON pipeline_build_events_%s (build_id, client_seq) WHERE client_seq IS NOT NULL', NEW.id, NEW.id);
+          RETURN NULL;
+  END;
+  $$ LANGUAGE plpgsql;
+
+  DO $$
+  DECLARE
+    t record;
+  BEGIN
+    FOR t IN SELECT id FROM teams LOOP
+      EXECUTE format('CREATE UNIQUE INDEX IF NOT EXISTS team_build_events_%s_build_id_client_seq ON team_build_events_%s (build_id, client_seq) WHERE client_seq IS NOT NULL', t.id, t.id);
+    END LOOP;
+
+    FOR t IN SELECT id FROM pipelines LOOP
+      EXECUTE format('CREATE UNIQUE INDEX IF NOT EXISTS pipeline_build_events_%s_build_id_client_seq ON pipeline_build_events_%s (build_id, client_seq) WHERE client_seq IS NOT NULL', t.id, t.id);
+    END LOOP;
+  END
+  $$;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE pipelines
+    DROP COLUMN event_retention;
+
+COMMIT;
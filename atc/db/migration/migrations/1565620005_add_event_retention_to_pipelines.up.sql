@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE pipelines
+    ADD COLUMN event_retention interval;
+
+COMMIT;
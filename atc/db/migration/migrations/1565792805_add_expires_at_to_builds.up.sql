@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE builds
+    ADD COLUMN expires_at timestamp with time zone;
+
+COMMIT;
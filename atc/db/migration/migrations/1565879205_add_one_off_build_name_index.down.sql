@@ -0,0 +1,5 @@
+BEGIN;
+
+  DROP INDEX builds_team_id_one_off_name;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+  CREATE UNIQUE INDEX builds_team_id_one_off_name
+    ON builds (team_id, name)
+    WHERE job_id IS NULL;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+  ALTER TABLE builds
+    DROP COLUMN inputs_count,
+    DROP COLUMN outputs_count;
+
+COMMIT;
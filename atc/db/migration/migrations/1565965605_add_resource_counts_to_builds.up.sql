@@ -0,0 +1,7 @@
+BEGIN;
+
+  ALTER TABLE builds
+    ADD COLUMN inputs_count integer NOT NULL DEFAULT 0,
+    ADD COLUMN outputs_count integer NOT NULL DEFAULT 0;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE builds
+    ADD COLUMN worker_names text[] NOT NULL DEFAULT '{}';
+
+COMMIT;
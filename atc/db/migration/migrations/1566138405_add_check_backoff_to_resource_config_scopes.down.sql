@@ -0,0 +1,7 @@
+BEGIN;
+
+  ALTER TABLE resource_config_scopes
+    DROP COLUMN check_failure_count,
+    DROP COLUMN next_check_after;
+
+COMMIT;
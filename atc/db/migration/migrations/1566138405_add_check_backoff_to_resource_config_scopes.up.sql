@@ -0,0 +1,7 @@
+BEGIN;
+
+  ALTER TABLE resource_config_scopes
+    ADD COLUMN check_failure_count integer NOT NULL DEFAULT 0,
+    ADD COLUMN next_check_after timestamp with time zone;
+
+COMMIT;
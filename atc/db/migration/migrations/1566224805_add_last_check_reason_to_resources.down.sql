@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE resources
+    DROP COLUMN last_check_reason;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE resources
+    ADD COLUMN last_check_reason text;
+
+COMMIT;
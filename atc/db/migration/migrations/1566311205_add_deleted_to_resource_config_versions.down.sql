@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE resource_config_versions
+    DROP COLUMN deleted;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE resource_config_versions
+    ADD COLUMN deleted boolean NOT NULL DEFAULT false;
+
+COMMIT;
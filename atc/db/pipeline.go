@@ -40,6 +40,7 @@ type Pipeline interface {
 	ConfigVersion() ConfigVersion
 	Public() bool
 	Paused() bool
+	EventRetention() time.Duration
 
 	CheckPaused() (bool, error)
 	Reload() (bool, error)
@@ -58,10 +59,14 @@ type Pipeline interface {
 	BuildsWithTime(page Page) ([]Build, Pagination, error)
 
 	DeleteBuildEventsByBuildIDs(buildIDs []int) error
+	SetEventRetention(retention time.Duration) error
+	TrimEvents(before time.Time) (int, error)
 
 	AcquireSchedulingLock(lager.Logger, time.Duration) (lock.Lock, bool, error)
 
 	LoadVersionsDB() (*algorithm.VersionsDB, error)
+	SuccessfulBuildOutputsSince(buildID int, afterOutputID int, limit int) ([]BuildOutput, error)
+	StreamSuccessfulBuildOutputs(buildID int, fn func(BuildOutput) error) error
 
 	Resource(name string) (Resource, bool, error)
 	ResourceByID(id int) (Resource, bool, error)
@@ -95,6 +100,8 @@ type pipeline struct {
 	paused        bool
 	public        bool
 
+	eventRetention time.Duration
+
 	cacheIndex int
 	versionsDB *algorithm.VersionsDB
 
@@ -113,7 +120,8 @@ var pipelinesQuery = psql.Select(`
 		p.team_id,
 		t.name,
 		p.paused,
-		p.public
+		p.public,
+		EXTRACT(EPOCH FROM p.event_retention)
 	`).
 	From("pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id")
@@ -134,6 +142,10 @@ func (p *pipeline) ConfigVersion() ConfigVersion { return p.configVersion }
 func (p *pipeline) Public() bool                 { return p.public }
 func (p *pipeline) Paused() bool                 { return p.paused }
 
+// EventRetention returns how long the events of the pipeline's builds are
+// kept, or 0 if the pipeline has no policy of its own.
+func (p *pipeline) EventRetention() time.Duration { return p.eventRetention }
+
 // IMPORTANT: This method is broken with the new resource config versions changes
 func (p *pipeline) Causality(versionedResourceID int) ([]Cause, error) {
 	rows, err := p.conn.Query(`
@@ -272,7 +284,7 @@ func (p *pipeline) GetAllPendingBuilds() (map[string][]Build, error) {
 			"j.active":      true,
 			"b.pipeline_id": p.id,
 		}).
-		OrderBy("b.id").
+		OrderBy(pendingBuildsOrder...).
 		RunWith(p.conn).
 		Query()
 	if err != nil {
@@ -664,6 +676,106 @@ func (p *pipeline) Destroy() error {
 	return err
 }
 
+// SuccessfulBuildOutputsSince returns at most limit outputs of the given
+// build saved after the output with the given ID, ordered by ID, along with
+// the build's job. Nothing is returned unless the build belongs to the
+// pipeline and succeeded.
+func (p *pipeline) SuccessfulBuildOutputsSince(buildID int, afterOutputID int, limit int) ([]BuildOutput, error) {
+	rows, err := p.successfulBuildOutputsQuery(buildID).
+		Where(sq.Gt{
+			"o.id": afterOutputID,
+		}).
+		Limit(uint64(limit)).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	outputs := []BuildOutput{}
+	for rows.Next() {
+		output, err := scanSuccessfulBuildOutput(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs = append(outputs, output)
+	}
+
+	return outputs, nil
+}
+
+// StreamSuccessfulBuildOutputs calls fn with each output returned by
+// SuccessfulBuildOutputsSince, reading them from the database one at a time
+// rather than holding all of them in memory. It stops at the first error
+// returned by fn and returns it.
+func (p *pipeline) StreamSuccessfulBuildOutputs(buildID int, fn func(BuildOutput) error) error {
+	rows, err := p.successfulBuildOutputsQuery(buildID).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		output, err := scanSuccessfulBuildOutput(rows)
+		if err != nil {
+			return err
+		}
+
+		err = fn(output)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (p *pipeline) successfulBuildOutputsQuery(buildID int) sq.SelectBuilder {
+	return psql.Select("o.id", "o.name", "v.version", "b.job_id").
+		From("build_resource_config_version_outputs o").
+		Join("builds b ON b.id = o.build_id").
+		Join("resource_config_versions v ON v.version_md5 = o.version_md5").
+		Join("resources r ON r.id = o.resource_id").
+		Where(sq.Expr("r.resource_config_scope_id = v.resource_config_scope_id")).
+		Where(sq.NotEq{
+			"v.check_order": 0,
+		}).
+		Where(sq.Eq{
+			"o.build_id":    buildID,
+			"b.status":      BuildStatusSucceeded,
+			"r.pipeline_id": p.id,
+		}).
+		OrderBy("o.id ASC")
+}
+
+func scanSuccessfulBuildOutput(rows *sql.Rows) (BuildOutput, error) {
+	var (
+		output      BuildOutput
+		versionBlob string
+		jobID       sql.NullInt64
+	)
+
+	err := rows.Scan(&output.ID, &output.Name, &versionBlob, &jobID)
+	if err != nil {
+		return BuildOutput{}, err
+	}
+
+	err = json.Unmarshal([]byte(versionBlob), &output.Version)
+	if err != nil {
+		return BuildOutput{}, err
+	}
+
+	output.JobID = int(jobID.Int64)
+
+	return output, nil
+}
+
 func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 	var cacheIndex int
 	err := psql.Select("cache_index").
@@ -699,6 +811,7 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 			"v.check_order": 0,
 		}).
 		Where(sq.Eq{
+			"v.deleted":     false,
 			"b.status":      BuildStatusSucceeded,
 			"r.pipeline_id": p.id,
 		}).
@@ -733,6 +846,7 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 			"v.check_order": 0,
 		}).
 		Where(sq.Eq{
+			"v.deleted":     false,
 			"r.pipeline_id": p.id,
 		}).
 		RunWith(p.conn).
@@ -775,6 +889,7 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		}).
 		Where(sq.Eq{
 			"r.pipeline_id": p.id,
+			"v.deleted":     false,
 			"d.resource_id": nil,
 			"d.version_md5": nil,
 		}).
@@ -889,6 +1004,50 @@ func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 	return err
 }
 
+// SetEventRetention sets how long the events of the pipeline's builds are
+// kept. A retention of 0 removes the pipeline's policy.
+func (p *pipeline) SetEventRetention(retention time.Duration) error {
+	var interval interface{}
+	if retention > 0 {
+		interval = sq.Expr("? * interval '1 second'", retention.Seconds())
+	}
+
+	_, err := psql.Update("pipelines").
+		Set("event_retention", interval).
+		Where(sq.Eq{"id": p.id}).
+		RunWith(p.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	p.eventRetention = retention
+
+	return nil
+}
+
+// TrimEvents trims the log events emitted before the given time by the
+// pipeline's completed builds, like Build.TrimEvents. Kept builds are left
+// alone. It returns the number of events trimmed.
+func (p *pipeline) TrimEvents(before time.Time) (int, error) {
+	result, err := psql.Update(fmt.Sprintf("pipeline_build_events_%d", p.id)).
+		Set("payload", sq.Expr(trimmedLogPayload)).
+		Where(sq.Expr(trimmableLogEvents, before.Unix())).
+		Where(sq.Expr("build_id IN (SELECT id FROM builds WHERE pipeline_id = ? AND completed AND NOT keep)", p.id)).
+		RunWith(p.conn).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(affected), nil
+}
+
 func (p *pipeline) AcquireSchedulingLock(logger lager.Logger, interval time.Duration) (lock.Lock, bool, error) {
 	lock, acquired, err := p.lockFactory.Acquire(
 		logger.Session("lock", lager.Data{
@@ -952,6 +1111,7 @@ func (p *pipeline) CreateOneOffBuild() (Build, error) {
 		"pipeline_id": p.id,
 		"team_id":     p.teamID,
 		"status":      BuildStatusPending,
+		"origin":      BuildOriginAPI,
 	})
 	if err != nil {
 		return nil, err
@@ -994,6 +1154,7 @@ func (p *pipeline) CreateStartedBuild(plan atc.Plan) (Build, error) {
 		"private_plan": encryptedPlan,
 		"public_plan":  plan.Public(),
 		"nonce":        nonce,
+		"origin":       BuildOriginAPI,
 	})
 	if err != nil {
 		return nil, err
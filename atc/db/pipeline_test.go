@@ -1,6 +1,7 @@
 package db_test
 
 import (
+	"errors"
 	"strconv"
 	"time"
 
@@ -1093,6 +1094,61 @@ var _ = Describe("Pipeline", func() {
 				))
 			})
 		})
+
+		Context("when a put deleted a version", func() {
+			It("omits the version from the versions DB", func() {
+				aJob, found, err := pipelineDB.Job("a-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				build1, err := aJob.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = resourceConfigScope.SaveVersions([]atc.Version{{"version": "deleted"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				deletedVersion, found, err := resourceConfigScope.LatestVersion()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = resourceConfigScope.SaveVersions([]atc.Version{{"version": "kept"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				keptVersion, found, err := resourceConfigScope.LatestVersion()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = build1.UseInputs([]db.BuildInput{{
+					Name:       "deleted-input",
+					Version:    atc.Version{"version": "deleted"},
+					ResourceID: resource.ID(),
+				}})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build1.SaveOutputDeleted("some-resource", atc.Version{"version": "deleted"})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build1.Finish(db.BuildStatusSucceeded)
+				Expect(err).ToNot(HaveOccurred())
+
+				versions, err := pipelineDB.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+
+				By("omitting it from the list of resource versions")
+				Expect(versions.ResourceVersions).To(ConsistOf(
+					algorithm.ResourceVersion{
+						VersionID:  keptVersion.ID(),
+						ResourceID: resource.ID(),
+						CheckOrder: keptVersion.CheckOrder(),
+					},
+				))
+				Expect(deletedVersion.ID()).ToNot(Equal(keptVersion.ID()))
+
+				By("omitting it from build inputs and outputs")
+				Expect(versions.BuildInputs).To(BeEmpty())
+				Expect(versions.BuildOutputs).To(BeEmpty())
+			})
+		})
 	})
 
 	Describe("Destroy", func() {
@@ -1176,6 +1232,130 @@ var _ = Describe("Pipeline", func() {
 				Expect(pendingBuilds["job-name"]).ToNot(BeNil())
 			})
 		})
+
+		Context("when an automatic and a manually triggered build are pending", func() {
+			var manualBuild db.Build
+
+			BeforeEach(func() {
+				err := job.EnsurePendingBuildExists()
+				Expect(err).ToNot(HaveOccurred())
+
+				manualBuild, err = job.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the manually triggered build first", func() {
+				pendingBuildsForJob, err := job.GetPendingBuilds()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pendingBuildsForJob).To(HaveLen(2))
+				Expect(pendingBuildsForJob[0].ID()).To(Equal(manualBuild.ID()))
+				Expect(pendingBuildsForJob[1].IsManuallyTriggered()).To(BeFalse())
+
+				pendingBuilds, err := pipeline.GetAllPendingBuilds()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pendingBuilds["job-name"]).To(HaveLen(2))
+				Expect(pendingBuilds["job-name"][0].ID()).To(Equal(manualBuild.ID()))
+			})
+		})
+	})
+
+	Describe("SuccessfulBuildOutputsSince", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = job.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			for i := 1; i <= 5; i++ {
+				err = build.SaveOutput("some-type", atc.Source{"some": "source"}, atc.VersionedResourceTypes{}, atc.Version{"ver": strconv.Itoa(i)}, nil, "output-"+strconv.Itoa(i), "some-resource")
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		Context("when the build has succeeded", func() {
+			BeforeEach(func() {
+				Expect(build.Finish(db.BuildStatusSucceeded)).To(Succeed())
+			})
+
+			It("pages through the same outputs as a full retrieval", func() {
+				allOutputs, err := build.OutputsSince(0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(allOutputs).To(HaveLen(5))
+
+				for i := range allOutputs {
+					allOutputs[i].JobID = job.ID()
+				}
+
+				pagedOutputs := []db.BuildOutput{}
+				cursor := 0
+				for {
+					page, err := pipeline.SuccessfulBuildOutputsSince(build.ID(), cursor, 2)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(len(page)).To(BeNumerically("<=", 2))
+
+					if len(page) == 0 {
+						break
+					}
+
+					pagedOutputs = append(pagedOutputs, page...)
+					cursor = page[len(page)-1].ID
+				}
+
+				Expect(pagedOutputs).To(Equal(allOutputs))
+			})
+
+			It("includes the job which produced the outputs", func() {
+				outputs, err := pipeline.SuccessfulBuildOutputsSince(build.ID(), 0, 10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(outputs).To(HaveLen(5))
+
+				for _, output := range outputs {
+					Expect(output.JobID).To(Equal(job.ID()))
+				}
+			})
+
+			It("streams the same outputs as a full retrieval", func() {
+				allOutputs, err := pipeline.SuccessfulBuildOutputsSince(build.ID(), 0, 10)
+				Expect(err).NotTo(HaveOccurred())
+
+				streamedOutputs := []db.BuildOutput{}
+				err = pipeline.StreamSuccessfulBuildOutputs(build.ID(), func(output db.BuildOutput) error {
+					streamedOutputs = append(streamedOutputs, output)
+					return nil
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(streamedOutputs).To(Equal(allOutputs))
+			})
+
+			It("stops streaming at the first error from the callback", func() {
+				disaster := errors.New("nope")
+
+				calls := 0
+				err := pipeline.StreamSuccessfulBuildOutputs(build.ID(), func(output db.BuildOutput) error {
+					calls++
+					if calls == 2 {
+						return disaster
+					}
+
+					return nil
+				})
+				Expect(err).To(Equal(disaster))
+				Expect(calls).To(Equal(2))
+			})
+		})
+
+		Context("when the build has not succeeded", func() {
+			BeforeEach(func() {
+				Expect(build.Finish(db.BuildStatusFailed)).To(Succeed())
+			})
+
+			It("returns no outputs", func() {
+				outputs, err := pipeline.SuccessfulBuildOutputsSince(build.ID(), 0, 10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(outputs).To(BeEmpty())
+			})
+		})
 	})
 
 	Describe("VersionsDB caching", func() {
@@ -1580,13 +1760,16 @@ var _ = Describe("Pipeline", func() {
 
 			build2Event1, err := events2.Next()
 			Expect(err).ToNot(HaveOccurred())
-			Expect(build2Event1).To(Equal(envelope(event.Log{
+			Expect(build2Event1).To(matchEnvelope(event.Log{
 				Payload: "log 2",
-			})))
+			}))
 
 			_, err = events2.Next() // finish event
 			Expect(err).ToNot(HaveOccurred())
 
+			_, err = events2.Next() // summary event
+			Expect(err).ToNot(HaveOccurred())
+
 			_, err = events2.Next()
 			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
 
@@ -1606,6 +1789,9 @@ var _ = Describe("Pipeline", func() {
 			_, err = events4.Next() // finish event
 			Expect(err).ToNot(HaveOccurred())
 
+			_, err = events4.Next() // summary event
+			Expect(err).ToNot(HaveOccurred())
+
 			_, err = events4.Next()
 			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
 
@@ -1637,6 +1823,146 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("SetEventRetention", func() {
+		It("sets and clears the pipeline's event retention", func() {
+			Expect(pipeline.EventRetention()).To(BeZero())
+
+			err := pipeline.SetEventRetention(90 * time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipeline.EventRetention()).To(Equal(90 * time.Minute))
+
+			found, err := pipeline.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(pipeline.EventRetention()).To(Equal(90 * time.Minute))
+
+			err = pipeline.SetEventRetention(0)
+			Expect(err).ToNot(HaveOccurred())
+
+			found, err = pipeline.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(pipeline.EventRetention()).To(BeZero())
+		})
+	})
+
+	Describe("TrimEvents", func() {
+		It("trims old log events of completed builds but keeps status events", func() {
+			err := pipeline.SetEventRetention(time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+
+			twoHoursAgo := time.Now().Add(-2 * time.Hour).Unix()
+
+			build, err := job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{
+				Time:    twoHoursAgo,
+				Payload: "old log",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			newLogTime := time.Now().Unix()
+
+			err = build.SaveEvent(event.Log{
+				Time:    newLogTime,
+				Payload: "new log",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			runningBuild, err := job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = runningBuild.SaveEvent(event.Log{
+				Time:    twoHoursAgo,
+				Payload: "running log",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			trimmed, err := pipeline.TrimEvents(time.Now().Add(-pipeline.EventRetention()))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(trimmed).To(Equal(1))
+
+			events, err := build.Events(0)
+			Expect(err).ToNot(HaveOccurred())
+			defer db.Close(events)
+
+			ev, err := events.Next()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event.ParseEvent(ev.Version, ev.Event, *ev.Data)).To(Equal(event.Log{
+				Time:    twoHoursAgo,
+				Payload: "",
+			}))
+
+			ev, err = events.Next()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event.ParseEvent(ev.Version, ev.Event, *ev.Data)).To(Equal(event.Log{
+				Time:    newLogTime,
+				Payload: "new log",
+			}))
+
+			ev, err = events.Next()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ev.Event).To(Equal(event.EventTypeStatus))
+
+			ev, err = events.Next()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ev.Event).To(Equal(event.EventTypeBuildSummary))
+
+			_, err = events.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+
+			By("leaving the events of running builds alone")
+			runningEvents, err := runningBuild.Events(0)
+			Expect(err).ToNot(HaveOccurred())
+			defer db.Close(runningEvents)
+
+			ev, err = runningEvents.Next()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ev).To(matchEnvelope(event.Log{
+				Time:    twoHoursAgo,
+				Payload: "running log",
+			}))
+		})
+
+		It("leaves the log events of kept builds alone", func() {
+			twoHoursAgo := time.Now().Add(-2 * time.Hour).Unix()
+
+			build, err := job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{
+				Time:    twoHoursAgo,
+				Payload: "kept log",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SetKeep(true)
+			Expect(err).ToNot(HaveOccurred())
+
+			trimmed, err := pipeline.TrimEvents(time.Now().Add(-time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(trimmed).To(BeZero())
+
+			events, err := build.Events(0)
+			Expect(err).ToNot(HaveOccurred())
+			defer db.Close(events)
+
+			ev, err := events.Next()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ev).To(matchEnvelope(event.Log{
+				Time:    twoHoursAgo,
+				Payload: "kept log",
+			}))
+		})
+	})
+
 	Describe("Jobs", func() {
 		var jobs []db.Job
 
@@ -2005,10 +2331,10 @@ var _ = Describe("Pipeline", func() {
 
 			defer db.Close(events)
 
-			Expect(events.Next()).To(Equal(envelope(event.Status{
+			Expect(events.Next()).To(matchEnvelope(event.Status{
 				Status: atc.StatusStarted,
 				Time:   startedBuild.StartTime().Unix(),
-			})))
+			}))
 		})
 	})
 
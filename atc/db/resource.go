@@ -37,11 +37,14 @@ type Resource interface {
 	APIPinnedVersion() atc.Version
 	PinComment() string
 	SetPinComment(string) error
+	LastCheckReason() string
+	SetLastCheckReason(string) error
 	ResourceConfigID() int
 	ResourceConfigScopeID() int
 	Icon() string
 
 	CurrentPinnedVersion() atc.Version
+	EffectiveCheckConfig(defaults CheckConfig) (CheckConfig, error)
 
 	ResourceConfigVersionID(atc.Version) (int, bool, error)
 	Versions(page Page) ([]atc.ResourceVersion, Pagination, bool, error)
@@ -54,6 +57,10 @@ type Resource interface {
 	PinVersion(rcvID int) error
 	UnpinVersion() error
 
+	TagVersion(rcvID int, tag string) error
+	UntagVersion(rcvID int, tag string) error
+	VersionsByTag(tag string) ([]ResourceConfigVersion, error)
+
 	SetResourceConfig(atc.Source, atc.VersionedResourceTypes) (ResourceConfigScope, error)
 	SetCheckSetupError(error) error
 	NotifyScan() error
@@ -61,7 +68,15 @@ type Resource interface {
 	Reload() (bool, error)
 }
 
-var resourcesQuery = psql.Select("r.id, r.name, r.type, r.config, r.check_error, rs.last_check_start_time, rs.last_check_end_time, r.pipeline_id, r.nonce, r.resource_config_id, r.resource_config_scope_id, p.name, t.name, rs.check_error, rp.version, rp.comment_text").
+// CheckConfig is the configuration a resource is checked with once its own
+// settings have been applied on top of the global defaults.
+type CheckConfig struct {
+	Interval time.Duration
+	Timeout  time.Duration
+	Tags     atc.Tags
+}
+
+var resourcesQuery = psql.Select("r.id, r.name, r.type, r.config, r.check_error, rs.last_check_start_time, rs.last_check_end_time, r.pipeline_id, r.nonce, r.resource_config_id, r.resource_config_scope_id, p.name, t.name, rs.check_error, rp.version, rp.comment_text, r.last_check_reason").
 	From("resources r").
 	Join("pipelines p ON p.id = r.pipeline_id").
 	Join("teams t ON t.id = p.team_id").
@@ -89,6 +104,7 @@ type resource struct {
 	configPinnedVersion   atc.Version
 	apiPinnedVersion      atc.Version
 	pinComment            string
+	lastCheckReason       string
 	resourceConfigID      int
 	resourceConfigScopeID int
 	icon                  string
@@ -156,6 +172,7 @@ func (r *resource) WebhookToken() string             { return r.webhookToken }
 func (r *resource) ConfigPinnedVersion() atc.Version { return r.configPinnedVersion }
 func (r *resource) APIPinnedVersion() atc.Version    { return r.apiPinnedVersion }
 func (r *resource) PinComment() string               { return r.pinComment }
+func (r *resource) LastCheckReason() string          { return r.lastCheckReason }
 func (r *resource) ResourceConfigID() int            { return r.resourceConfigID }
 func (r *resource) ResourceConfigScopeID() int       { return r.resourceConfigScopeID }
 func (r *resource) Icon() string                     { return r.icon }
@@ -361,6 +378,23 @@ func (r *resource) SetPinComment(comment string) error {
 	return err
 }
 
+// SetLastCheckReason records why the resource was last checked on request,
+// e.g. the webhook which asked for the check.
+func (r *resource) SetLastCheckReason(reason string) error {
+	_, err := psql.Update("resources").
+		Set("last_check_reason", reason).
+		Where(sq.Eq{"id": r.ID()}).
+		RunWith(r.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	r.lastCheckReason = reason
+
+	return nil
+}
+
 func (r *resource) CurrentPinnedVersion() atc.Version {
 	if r.configPinnedVersion != nil {
 		return r.configPinnedVersion
@@ -370,6 +404,36 @@ func (r *resource) CurrentPinnedVersion() atc.Version {
 	return nil
 }
 
+// EffectiveCheckConfig layers the resource's check_every, check_timeout and
+// tags over the given defaults' interval and timeout.
+func (r *resource) EffectiveCheckConfig(defaults CheckConfig) (CheckConfig, error) {
+	config := CheckConfig{
+		Interval: defaults.Interval,
+		Timeout:  defaults.Timeout,
+		Tags:     r.tags,
+	}
+
+	if r.checkEvery != "" {
+		interval, err := time.ParseDuration(r.checkEvery)
+		if err != nil {
+			return CheckConfig{}, err
+		}
+
+		config.Interval = interval
+	}
+
+	if r.checkTimeout != "" {
+		timeout, err := time.ParseDuration(r.checkTimeout)
+		if err != nil {
+			return CheckConfig{}, err
+		}
+
+		config.Timeout = timeout
+	}
+
+	return config, nil
+}
+
 func (r *resource) Versions(page Page) ([]atc.ResourceVersion, Pagination, bool, error) {
 	query := `
 		SELECT v.id, v.version, v.metadata, v.check_order,
@@ -580,6 +644,88 @@ func (r *resource) UnpinVersion() error {
 	return nil
 }
 
+func (r *resource) TagVersion(rcvID int, tag string) error {
+	results, err := r.conn.Exec(`
+		INSERT INTO resource_version_tags (resource_id, version_md5, tag)
+		SELECT $1, rcv.version_md5, $3
+		FROM resource_config_versions rcv
+		WHERE rcv.id = $2
+		AND rcv.resource_config_scope_id = (SELECT resource_config_scope_id FROM resources WHERE id = $1)
+		ON CONFLICT (resource_id, version_md5, tag) DO UPDATE SET tag = EXCLUDED.tag
+		`, r.id, rcvID, tag)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := results.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	return nil
+}
+
+func (r *resource) UntagVersion(rcvID int, tag string) error {
+	results, err := r.conn.Exec(`
+		DELETE FROM resource_version_tags
+		WHERE resource_id = $1
+		AND version_md5 = (SELECT version_md5 FROM resource_config_versions rcv WHERE rcv.id = $2)
+		AND tag = $3
+		`, r.id, rcvID, tag)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := results.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	return nil
+}
+
+// VersionsByTag returns the versions of the resource's current resource
+// config scope that carry the given tag, newest first.
+func (r *resource) VersionsByTag(tag string) ([]ResourceConfigVersion, error) {
+	rows, err := resourceConfigVersionQuery.
+		Join("resource_version_tags t ON t.version_md5 = v.version_md5").
+		Where(sq.Eq{
+			"t.resource_id":              r.id,
+			"t.tag":                      tag,
+			"v.resource_config_scope_id": r.resourceConfigScopeID,
+		}).
+		OrderBy("v.check_order DESC").
+		RunWith(r.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	versions := []ResourceConfigVersion{}
+	for rows.Next() {
+		rcv := &resourceConfigVersion{conn: r.conn}
+
+		err = scanResourceConfigVersion(rcv, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, rcv)
+	}
+
+	return versions, nil
+}
+
 func (r *resource) toggleVersion(rcvID int, enable bool) error {
 	tx, err := r.conn.Begin()
 	if err != nil {
@@ -632,10 +778,11 @@ func scanResource(r *resource, row scannable) error {
 	var (
 		configBlob                                                                  []byte
 		checkErr, rcsCheckErr, nonce, rcID, rcScopeID, apiPinnedVersion, pinComment sql.NullString
+		lastCheckReason                                                             sql.NullString
 		lastCheckStartTime, lastCheckEndTime                                        pq.NullTime
 	)
 
-	err := row.Scan(&r.id, &r.name, &r.type_, &configBlob, &checkErr, &lastCheckStartTime, &lastCheckEndTime, &r.pipelineID, &nonce, &rcID, &rcScopeID, &r.pipelineName, &r.teamName, &rcsCheckErr, &apiPinnedVersion, &pinComment)
+	err := row.Scan(&r.id, &r.name, &r.type_, &configBlob, &checkErr, &lastCheckStartTime, &lastCheckEndTime, &r.pipelineID, &nonce, &rcID, &rcScopeID, &r.pipelineName, &r.teamName, &rcsCheckErr, &apiPinnedVersion, &pinComment, &lastCheckReason)
 	if err != nil {
 		return err
 	}
@@ -685,6 +832,8 @@ func scanResource(r *resource, row scannable) error {
 		r.pinComment = ""
 	}
 
+	r.lastCheckReason = lastCheckReason.String
+
 	if checkErr.Valid {
 		r.checkSetupError = errors.New(checkErr.String)
 	} else {
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
 
@@ -46,7 +47,7 @@ var _ = Describe("ResourceCacheFactory", func() {
 			Name: "some-image-type",
 		}
 
-		resourceCacheLifecycle = db.NewResourceCacheLifecycle(dbConn)
+		resourceCacheLifecycle = db.NewResourceCacheLifecycle(dbConn, 5*time.Minute)
 
 		usedImageBaseResourceType, err = imageBaseResourceType.FindOrCreate(setupTx, false)
 		Expect(err).NotTo(HaveOccurred())
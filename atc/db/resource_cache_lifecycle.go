@@ -1,7 +1,9 @@
 package db
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	sq "github.com/Masterminds/squirrel"
@@ -17,12 +19,16 @@ type ResourceCacheLifecycle interface {
 }
 
 type resourceCacheLifecycle struct {
-	conn Conn
+	conn           Conn
+	useGracePeriod time.Duration
 }
 
-func NewResourceCacheLifecycle(conn Conn) ResourceCacheLifecycle {
+// NewResourceCacheLifecycle returns a ResourceCacheLifecycle which keeps the
+// resource caches used by a build for useGracePeriod after the build finished.
+func NewResourceCacheLifecycle(conn Conn, useGracePeriod time.Duration) ResourceCacheLifecycle {
 	return &resourceCacheLifecycle{
-		conn: conn,
+		conn:           conn,
+		useGracePeriod: useGracePeriod,
 	}
 }
 
@@ -44,6 +50,16 @@ func (f *resourceCacheLifecycle) CleanUsesForFinishedBuilds(logger lager.Logger)
 		}).
 		RunWith(f.conn).
 		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Delete("build_resource_cache_uses u USING builds b").
+		Where(sq.Expr("u.build_id = b.id")).
+		Where(sq.Eq{"b.completed": true}).
+		Where(sq.Expr(fmt.Sprintf("b.end_time + '%d seconds'::interval <= now()", int(f.useGracePeriod.Seconds())))).
+		RunWith(f.conn).
+		Exec()
 	return err
 }
 
@@ -56,6 +72,14 @@ func (f *resourceCacheLifecycle) CleanUpInvalidCaches(logger lager.Logger) error
 		return err
 	}
 
+	buildCacheIds, _, err := sq.
+		Select("resource_cache_id").
+		From("build_resource_cache_uses").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
 	resourceConfigCacheIds, _, err := sq.
 		Select("resource_cache_id").
 		From("resource_configs").
@@ -90,6 +114,7 @@ func (f *resourceCacheLifecycle) CleanUpInvalidCaches(logger lager.Logger) error
 	query, args, err := sq.Delete("resource_caches").
 		Where("id NOT IN (" + strings.Join([]string{
 			stillInUseCacheIds,
+			buildCacheIds,
 			resourceConfigCacheIds,
 			buildImageCacheIds,
 			nextBuildInputsCacheIds,
@@ -17,7 +17,7 @@ var _ = Describe("ResourceCacheLifecycle", func() {
 	var resourceCacheLifecycle db.ResourceCacheLifecycle
 
 	BeforeEach(func() {
-		resourceCacheLifecycle = db.NewResourceCacheLifecycle(dbConn)
+		resourceCacheLifecycle = db.NewResourceCacheLifecycle(dbConn, 5*time.Minute)
 	})
 
 	Describe("CleanUpInvalidCaches", func() {
@@ -164,6 +164,115 @@ var _ = Describe("ResourceCacheLifecycle", func() {
 			})
 		})
 
+		Context("the resource cache is registered as used by a build", func() {
+			var build db.Build
+
+			BeforeEach(func() {
+				var err error
+				build, err = defaultJob.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				resourceCache := createResourceCacheWithUser(db.ForBuild(build.ID()))
+
+				err = build.RegisterResourceCacheUse(resourceCache)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.SetInterceptible(false)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = resourceCacheLifecycle.CleanUsesForFinishedBuilds(logger)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("doesn't delete the resource cache while the build is running", func() {
+				err := resourceCacheLifecycle.CleanUpInvalidCaches(logger.Session("resource-cache-lifecycle"))
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(countResourceCaches()).ToNot(BeZero())
+			})
+
+			Context("when the build has finished", func() {
+				BeforeEach(func() {
+					err := build.Finish(db.BuildStatusSucceeded)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("doesn't delete the resource cache within the grace period", func() {
+					err := resourceCacheLifecycle.CleanUsesForFinishedBuilds(logger)
+					Expect(err).ToNot(HaveOccurred())
+
+					err = resourceCacheLifecycle.CleanUpInvalidCaches(logger.Session("resource-cache-lifecycle"))
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(countResourceCaches()).ToNot(BeZero())
+				})
+
+				It("deletes the resource cache after the grace period", func() {
+					_, err := dbConn.Exec("UPDATE builds SET end_time = now() - interval '1 hour' WHERE id = $1", build.ID())
+					Expect(err).ToNot(HaveOccurred())
+
+					err = resourceCacheLifecycle.CleanUsesForFinishedBuilds(logger)
+					Expect(err).ToNot(HaveOccurred())
+
+					err = resourceCacheLifecycle.CleanUpInvalidCaches(logger.Session("resource-cache-lifecycle"))
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(countResourceCaches()).To(BeZero())
+				})
+
+				Context("when there is no grace period", func() {
+					BeforeEach(func() {
+						resourceCacheLifecycle = db.NewResourceCacheLifecycle(dbConn, 0)
+					})
+
+					It("deletes the resource cache as soon as the build has finished", func() {
+						err := resourceCacheLifecycle.CleanUsesForFinishedBuilds(logger)
+						Expect(err).ToNot(HaveOccurred())
+
+						err = resourceCacheLifecycle.CleanUpInvalidCaches(logger.Session("resource-cache-lifecycle"))
+						Expect(err).ToNot(HaveOccurred())
+
+						Expect(countResourceCaches()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the use is registered after the build has finished", func() {
+				BeforeEach(func() {
+					err := build.Finish(db.BuildStatusAborted)
+					Expect(err).ToNot(HaveOccurred())
+
+					lateCache := createResourceCacheWithUser(db.ForBuild(build.ID()))
+
+					err = build.RegisterResourceCacheUse(lateCache)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("keeps the late use until a grace period after the build ended", func() {
+					err := resourceCacheLifecycle.CleanUsesForFinishedBuilds(logger)
+					Expect(err).ToNot(HaveOccurred())
+
+					var uses int
+					err = dbConn.QueryRow("SELECT COUNT(*) FROM build_resource_cache_uses WHERE build_id = $1", build.ID()).Scan(&uses)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(uses).To(Equal(1))
+				})
+
+				It("releases the late use once the build ended longer than a grace period ago", func() {
+					_, err := dbConn.Exec("UPDATE builds SET end_time = now() - interval '1 hour' WHERE id = $1", build.ID())
+					Expect(err).ToNot(HaveOccurred())
+
+					err = resourceCacheLifecycle.CleanUsesForFinishedBuilds(logger)
+					Expect(err).ToNot(HaveOccurred())
+
+					var uses int
+					err = dbConn.QueryRow("SELECT COUNT(*) FROM build_resource_cache_uses WHERE build_id = $1", build.ID()).Scan(&uses)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(uses).To(BeZero())
+				})
+			})
+		})
+
 		Context("the resource cache is used by a container", func() {
 			var (
 				container      db.CreatingContainer
@@ -11,6 +11,14 @@ import (
 	"github.com/concourse/concourse/atc/db/lock"
 )
 
+// CheckBackoff is how long checks of a resource config scope are held off
+// after a failed check. It is Min after the first failure and doubles with
+// each further failure, up to Max.
+type CheckBackoff struct {
+	Min time.Duration
+	Max time.Duration
+}
+
 //go:generate counterfeiter . ResourceConfigScope
 
 // ResourceConfigScope represents the relationship between a possible pipeline resource and a resource config.
@@ -30,7 +38,7 @@ type ResourceConfigScope interface {
 	FindVersion(atc.Version) (ResourceConfigVersion, bool, error)
 	LatestVersion() (ResourceConfigVersion, bool, error)
 
-	SetCheckError(error) error
+	SetCheckError(error, CheckBackoff) error
 
 	AcquireResourceCheckingLock(
 		logger lager.Logger,
@@ -158,18 +166,29 @@ func (r *resourceConfigScope) LatestVersion() (ResourceConfigVersion, bool, erro
 	return rcv, true, nil
 }
 
-func (r *resourceConfigScope) SetCheckError(cause error) error {
+// SetCheckError records the outcome of a check. Each failure pushes out the
+// time before which the scope will not be checked on its interval again by
+// the given backoff, and a successful check clears it.
+func (r *resourceConfigScope) SetCheckError(cause error, backoff CheckBackoff) error {
 	var err error
 
 	if cause == nil {
 		_, err = psql.Update("resource_config_scopes").
 			Set("check_error", nil).
+			Set("check_failure_count", 0).
+			Set("next_check_after", nil).
 			Where(sq.Eq{"id": r.id}).
 			RunWith(r.conn).
 			Exec()
 	} else {
 		_, err = psql.Update("resource_config_scopes").
 			Set("check_error", cause.Error()).
+			Set("check_failure_count", sq.Expr("check_failure_count + 1")).
+			Set("next_check_after", sq.Expr(
+				"now() + LEAST(? * power(2, check_failure_count), ?) * '1 second'::interval",
+				backoff.Min.Seconds(),
+				backoff.Max.Seconds(),
+			)).
 			Where(sq.Eq{"id": r.id}).
 			RunWith(r.conn).
 			Exec()
@@ -187,6 +206,9 @@ func (r *resourceConfigScope) AcquireResourceCheckingLock(
 	)
 }
 
+// UpdateLastCheckStartTime marks the start of a check. Unless immediate, it
+// does nothing if the interval has not elapsed since the last check or the
+// scope is backing off after failed checks.
 func (r *resourceConfigScope) UpdateLastCheckStartTime(
 	interval time.Duration,
 	immediate bool,
@@ -202,7 +224,7 @@ func (r *resourceConfigScope) UpdateLastCheckStartTime(
 
 	condition := ""
 	if !immediate {
-		condition = "AND now() - last_check_start_time > ($2 || ' SECONDS')::INTERVAL"
+		condition = "AND now() - last_check_start_time > ($2 || ' SECONDS')::INTERVAL AND (next_check_after IS NULL OR next_check_after <= now())"
 		params = append(params, interval.Seconds())
 	}
 
@@ -267,12 +289,13 @@ func saveResourceVersion(tx Tx, r ResourceConfigScope, version atc.Version, meta
 		return false, err
 	}
 
+	// saving a version which a put deleted means that it exists again
 	var checkOrder int
 	err = tx.QueryRow(`
 		INSERT INTO resource_config_versions (resource_config_scope_id, version, version_md5, metadata)
 		SELECT $1, $2, md5($3), $4
 		ON CONFLICT (resource_config_scope_id, version_md5)
-		DO UPDATE SET metadata = COALESCE(NULLIF(excluded.metadata, 'null'::jsonb), resource_config_versions.metadata)
+		DO UPDATE SET metadata = COALESCE(NULLIF(excluded.metadata, 'null'::jsonb), resource_config_versions.metadata), deleted = false
 		RETURNING check_order
 		`, r.ID(), string(versionJSON), string(versionJSON), string(metadataJSON)).Scan(&checkOrder)
 	if err != nil {
@@ -1,6 +1,7 @@
 package db_test
 
 import (
+	"errors"
 	"time"
 
 	"github.com/concourse/concourse/atc"
@@ -261,6 +262,72 @@ var _ = Describe("Resource Config Scope", func() {
 		})
 	})
 
+	Describe("SetCheckError", func() {
+		backoff := db.CheckBackoff{Min: 10 * time.Second, Max: time.Minute}
+
+		nextCheckAfter := func() time.Time {
+			var next time.Time
+			err := dbConn.QueryRow(`SELECT next_check_after FROM resource_config_scopes WHERE id = $1`, resourceScope.ID()).Scan(&next)
+			Expect(err).ToNot(HaveOccurred())
+			return next
+		}
+
+		It("pushes out the next allowed check time with each failure", func() {
+			err := resourceScope.SetCheckError(errors.New("oops"), backoff)
+			Expect(err).ToNot(HaveOccurred())
+
+			first := nextCheckAfter()
+			Expect(first).To(BeTemporally("~", time.Now().Add(backoff.Min), time.Second))
+
+			err = resourceScope.SetCheckError(errors.New("oops"), backoff)
+			Expect(err).ToNot(HaveOccurred())
+
+			second := nextCheckAfter()
+			Expect(second).To(BeTemporally("~", time.Now().Add(2*backoff.Min), time.Second))
+			Expect(second).To(BeTemporally(">", first))
+		})
+
+		It("does not push out the next allowed check time further than the max backoff", func() {
+			for i := 0; i < 5; i++ {
+				err := resourceScope.SetCheckError(errors.New("oops"), backoff)
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			Expect(nextCheckAfter()).To(BeTemporally("~", time.Now().Add(backoff.Max), time.Second))
+		})
+
+		It("holds off checks on the interval until the backoff has passed", func() {
+			err := resourceScope.SetCheckError(errors.New("oops"), backoff)
+			Expect(err).ToNot(HaveOccurred())
+
+			updated, err := resourceScope.UpdateLastCheckStartTime(0, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updated).To(BeFalse())
+
+			By("still allowing immediate checks")
+			updated, err = resourceScope.UpdateLastCheckStartTime(0, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updated).To(BeTrue())
+		})
+
+		It("clears the backoff once a check succeeds", func() {
+			err := resourceScope.SetCheckError(errors.New("oops"), backoff)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = resourceScope.SetCheckError(nil, backoff)
+			Expect(err).ToNot(HaveOccurred())
+
+			var next *time.Time
+			err = dbConn.QueryRow(`SELECT next_check_after FROM resource_config_scopes WHERE id = $1`, resourceScope.ID()).Scan(&next)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(next).To(BeNil())
+
+			updated, err := resourceScope.UpdateLastCheckStartTime(0, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updated).To(BeTrue())
+		})
+	})
+
 	Describe("UpdateLastCheckEndTime", func() {
 		var (
 			someResource        db.Resource
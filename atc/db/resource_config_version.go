@@ -25,6 +25,10 @@ type ResourceConfigVersions []ResourceConfigVersion
 type ResourceConfigMetadataField struct {
 	Name  string
 	Value string
+
+	// Type optionally tells the UI how to render the value, e.g. "link" or
+	// "yaml". Fields without a type are rendered as plain text.
+	Type string `json:",omitempty"`
 }
 
 type ResourceConfigMetadataFields []ResourceConfigMetadataField
@@ -35,6 +39,7 @@ func NewResourceConfigMetadataFields(atcm []atc.MetadataField) ResourceConfigMet
 		metadata[i] = ResourceConfigMetadataField{
 			Name:  md.Name,
 			Value: md.Value,
+			Type:  md.Type,
 		}
 	}
 
@@ -47,6 +52,7 @@ func (rmf ResourceConfigMetadataFields) ToATCMetadata() []atc.MetadataField {
 		metadata[i] = atc.MetadataField{
 			Name:  md.Name,
 			Value: md.Value,
+			Type:  md.Type,
 		}
 	}
 
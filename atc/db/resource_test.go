@@ -3,6 +3,7 @@ package db_test
 import (
 	"errors"
 	"strconv"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -139,7 +140,7 @@ var _ = Describe("Resource", func() {
 					resourceScope, err = resource.SetResourceConfig(atc.Source{"some": "repository"}, atc.VersionedResourceTypes{})
 					Expect(err).NotTo(HaveOccurred())
 
-					err = resourceScope.SetCheckError(errors.New("oops"))
+					err = resourceScope.SetCheckError(errors.New("oops"), db.CheckBackoff{})
 					Expect(err).NotTo(HaveOccurred())
 
 					found, err = resource.Reload()
@@ -279,7 +280,7 @@ var _ = Describe("Resource", func() {
 					resourceScope1, err = resource1.SetResourceConfig(atc.Source{"some": "repository"}, atc.VersionedResourceTypes{})
 					Expect(err).NotTo(HaveOccurred())
 
-					err = resourceScope1.SetCheckError(errors.New("oops"))
+					err = resourceScope1.SetCheckError(errors.New("oops"), db.CheckBackoff{})
 					Expect(err).NotTo(HaveOccurred())
 
 					found, err = resource1.Reload()
@@ -622,6 +623,30 @@ var _ = Describe("Resource", func() {
 		})
 	})
 
+	Describe("SetLastCheckReason", func() {
+		var resource db.Resource
+
+		BeforeEach(func() {
+			var err error
+			resource, _, err = pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("has no reason when the resource is first created", func() {
+			Expect(resource.LastCheckReason()).To(BeEmpty())
+		})
+
+		It("saves the reason on the resource", func() {
+			err := resource.SetLastCheckReason("triggered by PR #123 webhook")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resource.LastCheckReason()).To(Equal("triggered by PR #123 webhook"))
+
+			returnedResource, _, err := pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(returnedResource.LastCheckReason()).To(Equal("triggered by PR #123 webhook"))
+		})
+	})
+
 	Describe("ResourceConfigVersion", func() {
 		var (
 			resource                   db.Resource
@@ -1113,6 +1138,250 @@ var _ = Describe("Resource", func() {
 		})
 	})
 
+	Describe("EffectiveCheckConfig", func() {
+		defaults := db.CheckConfig{
+			Interval: 2 * time.Minute,
+			Timeout:  30 * time.Minute,
+		}
+
+		Context("when the resource does not configure its checking", func() {
+			It("uses the global defaults", func() {
+				resource, found, err := pipeline.Resource("some-resource")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				config, err := resource.EffectiveCheckConfig(defaults)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(config).To(Equal(db.CheckConfig{
+					Interval: 2 * time.Minute,
+					Timeout:  30 * time.Minute,
+				}))
+			})
+		})
+
+		Context("when the resource configures its checking", func() {
+			It("overrides the global defaults", func() {
+				resource, found, err := pipeline.Resource("some-resource-custom-check")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				config, err := resource.EffectiveCheckConfig(defaults)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(config).To(Equal(db.CheckConfig{
+					Interval: 10 * time.Millisecond,
+					Timeout:  time.Minute,
+				}))
+			})
+		})
+
+		Context("when the resource only configures some of its checking", func() {
+			BeforeEach(func() {
+				var err error
+				pipeline, _, err = defaultTeam.SavePipeline(
+					"pipeline-with-resources",
+					atc.Config{
+						Resources: atc.ResourceConfigs{
+							{
+								Name:         "some-resource",
+								Type:         "registry-image",
+								Source:       atc.Source{"some": "repository"},
+								CheckTimeout: "5m",
+								Tags:         atc.Tags{"some-tag"},
+							},
+						},
+					},
+					pipeline.ConfigVersion(),
+					false,
+				)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("layers the configured values over the global defaults", func() {
+				resource, found, err := pipeline.Resource("some-resource")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				config, err := resource.EffectiveCheckConfig(defaults)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(config).To(Equal(db.CheckConfig{
+					Interval: 2 * time.Minute,
+					Timeout:  5 * time.Minute,
+					Tags:     atc.Tags{"some-tag"},
+				}))
+			})
+		})
+
+		Context("when the resource configures an invalid interval", func() {
+			BeforeEach(func() {
+				var err error
+				pipeline, _, err = defaultTeam.SavePipeline(
+					"pipeline-with-resources",
+					atc.Config{
+						Resources: atc.ResourceConfigs{
+							{
+								Name:       "some-resource",
+								Type:       "registry-image",
+								Source:     atc.Source{"some": "repository"},
+								CheckEvery: "bogus",
+							},
+						},
+					},
+					pipeline.ConfigVersion(),
+					false,
+				)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns an error", func() {
+				resource, found, err := pipeline.Resource("some-resource")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, err = resource.EffectiveCheckConfig(defaults)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("TagVersion/UntagVersion/VersionsByTag", func() {
+		var (
+			resource   db.Resource
+			v1ID, v2ID int
+			v3ID       int
+		)
+
+		BeforeEach(func() {
+			var found bool
+			var err error
+			resource, found, err = pipeline.Resource("some-other-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			setupTx, err := dbConn.Begin()
+			Expect(err).ToNot(HaveOccurred())
+
+			brt := db.BaseResourceType{
+				Name: "git",
+			}
+
+			_, err = brt.FindOrCreate(setupTx, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(setupTx.Commit()).To(Succeed())
+
+			resourceScope, err := resource.SetResourceConfig(atc.Source{"some": "other-repository"}, atc.VersionedResourceTypes{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = resourceScope.SaveVersions([]atc.Version{
+				atc.Version{"version": "v1"},
+				atc.Version{"version": "v2"},
+				atc.Version{"version": "v3"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			found, err = resource.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			for version, id := range map[string]*int{"v1": &v1ID, "v2": &v2ID, "v3": &v3ID} {
+				rcv, found, err := resourceScope.FindVersion(atc.Version{"version": version})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				*id = rcv.ID()
+			}
+		})
+
+		Context("when two versions are tagged with the same tag", func() {
+			BeforeEach(func() {
+				Expect(resource.TagVersion(v1ID, "release-1.2")).To(Succeed())
+				Expect(resource.TagVersion(v3ID, "release-1.2")).To(Succeed())
+				Expect(resource.TagVersion(v2ID, "release-1.3")).To(Succeed())
+			})
+
+			It("returns both versions, newest first", func() {
+				versions, err := resource.VersionsByTag("release-1.2")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(versions).To(HaveLen(2))
+				Expect(versions[0].ID()).To(Equal(v3ID))
+				Expect(versions[0].Version()).To(Equal(db.Version{"version": "v3"}))
+				Expect(versions[1].ID()).To(Equal(v1ID))
+				Expect(versions[1].Version()).To(Equal(db.Version{"version": "v1"}))
+			})
+
+			It("does not return versions with other tags", func() {
+				versions, err := resource.VersionsByTag("release-1.3")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(versions).To(HaveLen(1))
+				Expect(versions[0].ID()).To(Equal(v2ID))
+			})
+
+			It("is idempotent", func() {
+				Expect(resource.TagVersion(v1ID, "release-1.2")).To(Succeed())
+
+				versions, err := resource.VersionsByTag("release-1.2")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(versions).To(HaveLen(2))
+			})
+
+			Context("when one of the versions is untagged", func() {
+				BeforeEach(func() {
+					Expect(resource.UntagVersion(v1ID, "release-1.2")).To(Succeed())
+				})
+
+				It("only returns the remaining version", func() {
+					versions, err := resource.VersionsByTag("release-1.2")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(versions).To(HaveLen(1))
+					Expect(versions[0].ID()).To(Equal(v3ID))
+				})
+
+				It("errors when untagging it again", func() {
+					err := resource.UntagVersion(v1ID, "release-1.2")
+					Expect(err).To(HaveOccurred())
+				})
+			})
+		})
+
+		Context("when tagging a version that does not exist", func() {
+			It("returns an error", func() {
+				err := resource.TagVersion(-1, "release-1.2")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("when tagging a version of another resource", func() {
+			It("returns an error without tagging it", func() {
+				otherResource, found, err := pipeline.Resource("some-resource-custom-check")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				otherScope, err := otherResource.SetResourceConfig(atc.Source{"some": "some-repository"}, atc.VersionedResourceTypes{})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = otherScope.SaveVersions([]atc.Version{{"version": "foreign"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				foreignVersion, found, err := otherScope.FindVersion(atc.Version{"version": "foreign"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = resource.TagVersion(foreignVersion.ID(), "release-1.2")
+				Expect(err).To(HaveOccurred())
+
+				versions, err := resource.VersionsByTag("release-1.2")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(versions).To(BeEmpty())
+			})
+		})
+
+		Context("when no versions have the tag", func() {
+			It("returns no versions", func() {
+				versions, err := resource.VersionsByTag("nope")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(versions).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("PinVersion/UnpinVersion", func() {
 		var resource db.Resource
 		var resID int
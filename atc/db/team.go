@@ -43,11 +43,14 @@ type Team interface {
 	OrderPipelines([]string) error
 
 	CreateOneOffBuild() (Build, error)
+	CreateOneOffBuildWithTags(tags []string) (Build, error)
 	CreateStartedBuild(plan atc.Plan) (Build, error)
+	FinishAbandonedBuilds(cutoff time.Time) (int, error)
 
 	PrivateAndPublicBuilds(Page) ([]Build, Pagination, error)
 	Builds(page Page) ([]Build, Pagination, error)
 	BuildsWithTime(page Page) ([]Build, Pagination, error)
+	OneOffBuilds(page Page) ([]Build, Pagination, error)
 
 	SaveWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error)
 	Workers() ([]Worker, error)
@@ -653,6 +656,12 @@ func (t *team) OrderPipelines(pipelineNames []string) error {
 }
 
 func (t *team) CreateOneOffBuild() (Build, error) {
+	return t.CreateOneOffBuildWithTags(nil)
+}
+
+// CreateOneOffBuildWithTags creates a one-off build whose steps only run on
+// workers with the given tags, unless a step specifies tags of its own.
+func (t *team) CreateOneOffBuildWithTags(tags []string) (Build, error) {
 	tx, err := t.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -665,6 +674,8 @@ func (t *team) CreateOneOffBuild() (Build, error) {
 		"name":    sq.Expr("nextval('one_off_name')"),
 		"team_id": t.id,
 		"status":  BuildStatusPending,
+		"origin":  BuildOriginAPI,
+		"tags":    pq.StringArray(tags),
 	})
 	if err != nil {
 		return nil, err
@@ -706,6 +717,7 @@ func (t *team) CreateStartedBuild(plan atc.Plan) (Build, error) {
 		"private_plan": encryptedPlan,
 		"public_plan":  plan.Public(),
 		"nonce":        nonce,
+		"origin":       BuildOriginAPI,
 	})
 	if err != nil {
 		return nil, err
@@ -735,6 +747,66 @@ func (t *team) CreateStartedBuild(plan atc.Plan) (Build, error) {
 	return build, nil
 }
 
+// FinishAbandonedBuilds errors any of the team's started builds which have
+// had no activity since the cutoff, returning how many were finished. A
+// build's last activity is when its latest event was saved, or when it
+// started if it has saved none since. Builds which are finished concurrently
+// by another ATC are skipped.
+func (t *team) FinishAbandonedBuilds(cutoff time.Time) (int, error) {
+	rows, err := buildsQuery.
+		Where(sq.Eq{
+			"t.id":     t.id,
+			"b.status": BuildStatusStarted,
+		}).
+		Where(sq.Expr(`GREATEST(b.start_time, (
+			SELECT max(e.inserted_at)
+			FROM build_events e
+			WHERE e.build_id = b.id
+		)) < ?`, cutoff)).
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return 0, err
+	}
+
+	defer Close(rows)
+
+	abandoned := []*build{}
+	for rows.Next() {
+		build := &build{conn: t.conn, lockFactory: t.lockFactory}
+		err = scanBuild(build, rows, t.conn.EncryptionStrategy())
+		if err != nil {
+			return 0, err
+		}
+
+		abandoned = append(abandoned, build)
+	}
+
+	finished := 0
+	for _, build := range abandoned {
+		err = build.finishWhere(BuildStatusErrored, []atc.Event{
+			event.Error{
+				Message: "build was abandoned: no activity since " + cutoff.Format(time.RFC3339),
+				Time:    time.Now().Unix(),
+			},
+		}, FinishOptions{}, sq.Eq{
+			"id":     build.id,
+			"status": BuildStatusStarted,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				continue
+			}
+
+			return finished, err
+		}
+
+		finished++
+	}
+
+	return finished, nil
+}
+
 func (t *team) PrivateAndPublicBuilds(page Page) ([]Build, Pagination, error) {
 	newBuildsQuery := buildsQuery.
 		Where(sq.Or{sq.Eq{"p.public": true}, sq.Eq{"t.id": t.id}})
@@ -750,6 +822,24 @@ func (t *team) Builds(page Page) ([]Build, Pagination, error) {
 	return getBuildsWithPagination(buildsQuery.Where(sq.Eq{"t.id": t.id}), minMaxIdQuery, page, t.conn, t.lockFactory)
 }
 
+// OneOffBuilds returns the team's builds which do not belong to a job, newest
+// first.
+func (t *team) OneOffBuilds(page Page) ([]Build, Pagination, error) {
+	newBuildsQuery := buildsQuery.
+		Where(sq.Eq{
+			"t.id":     t.id,
+			"b.job_id": nil,
+		})
+
+	newMinMaxIdQuery := minMaxIdQuery.
+		Where(sq.Eq{
+			"b.team_id": t.id,
+			"b.job_id":  nil,
+		})
+
+	return getBuildsWithPagination(newBuildsQuery, newMinMaxIdQuery, page, t.conn, t.lockFactory)
+}
+
 func (t *team) SaveWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error) {
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -1179,12 +1269,19 @@ func (t *team) findContainer(whereClause sq.Sqlizer) (CreatingContainer, Created
 }
 
 func scanPipeline(p *pipeline, scan scannable) error {
-	var groups sql.NullString
-	err := scan.Scan(&p.id, &p.name, &groups, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public)
+	var (
+		groups         sql.NullString
+		eventRetention sql.NullFloat64
+	)
+	err := scan.Scan(&p.id, &p.name, &groups, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &eventRetention)
 	if err != nil {
 		return err
 	}
 
+	if eventRetention.Valid {
+		p.eventRetention = time.Duration(eventRetention.Float64 * float64(time.Second))
+	}
+
 	if groups.Valid {
 		var pipelineGroups atc.GroupConfigs
 		err = json.Unmarshal([]byte(groups.String), &pipelineGroups)
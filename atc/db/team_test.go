@@ -1058,6 +1058,29 @@ var _ = Describe("Team", func() {
 			Expect(oneOffBuild.Status()).To(Equal(db.BuildStatusPending))
 			Expect(oneOffBuild.CreateTime()).To(BeTemporally("~", time.Now(), 100*time.Millisecond))
 		})
+
+		It("records the API as the build's origin", func() {
+			Expect(oneOffBuild.Origin()).To(Equal(db.BuildOriginAPI))
+		})
+
+		It("has no tags", func() {
+			Expect(oneOffBuild.Tags()).To(BeEmpty())
+		})
+	})
+
+	Describe("CreateOneOffBuildWithTags", func() {
+		It("stores the tags on the build", func() {
+			oneOffBuild, err := team.CreateOneOffBuildWithTags([]string{"some-tag", "other-tag"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(oneOffBuild.Tags()).To(Equal([]string{"some-tag", "other-tag"}))
+
+			found, err := oneOffBuild.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(oneOffBuild.Tags()).To(Equal([]string{"some-tag", "other-tag"}))
+		})
 	})
 
 	Describe("CreateStartedBuild", func() {
@@ -1104,6 +1127,7 @@ var _ = Describe("Team", func() {
 			Expect(startedBuild.Name()).To(Equal(strconv.Itoa(startedBuild.ID())))
 			Expect(startedBuild.TeamName()).To(Equal(team.Name()))
 			Expect(startedBuild.Status()).To(Equal(db.BuildStatusStarted))
+			Expect(startedBuild.Origin()).To(Equal(db.BuildOriginAPI))
 		})
 
 		It("saves the public plan", func() {
@@ -1123,10 +1147,99 @@ var _ = Describe("Team", func() {
 
 			defer db.Close(events)
 
-			Expect(events.Next()).To(Equal(envelope(event.Status{
+			Expect(events.Next()).To(matchEnvelope(event.Status{
 				Status: atc.StatusStarted,
 				Time:   startedBuild.StartTime().Unix(),
-			})))
+			}))
+		})
+	})
+
+	Describe("FinishAbandonedBuilds", func() {
+		var (
+			staleBuild db.Build
+			freshBuild db.Build
+		)
+
+		BeforeEach(func() {
+			var err error
+			staleBuild, err = team.CreateStartedBuild(atc.Plan{ID: atc.PlanID("stale")})
+			Expect(err).ToNot(HaveOccurred())
+
+			freshBuild, err = team.CreateStartedBuild(atc.Plan{ID: atc.PlanID("fresh")})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec("UPDATE builds SET start_time = now() - interval '2 hours' WHERE id = $1", staleBuild.ID())
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec("UPDATE build_events SET inserted_at = now() - interval '2 hours' WHERE build_id = $1", staleBuild.ID())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("errors only the builds with no activity since the cutoff", func() {
+			count, err := team.FinishAbandonedBuilds(time.Now().Add(-time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(1))
+
+			found, err := staleBuild.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(staleBuild.Status()).To(Equal(db.BuildStatusErrored))
+
+			found, err = freshBuild.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(freshBuild.Status()).To(Equal(db.BuildStatusStarted))
+		})
+
+		It("saves an error event explaining why the build was finished", func() {
+			_, err := team.FinishAbandonedBuilds(time.Now().Add(-time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+
+			events, err := staleBuild.Events(0)
+			Expect(err).ToNot(HaveOccurred())
+
+			defer db.Close(events)
+
+			_, err = events.Next() // started status
+			Expect(err).ToNot(HaveOccurred())
+
+			ev, err := events.Next()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ev.Event).To(Equal(event.EventTypeError))
+
+			ev, err = events.Next()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ev.Event).To(Equal(event.EventTypeStatus))
+		})
+
+		Context("when a build which started before the cutoff is still saving events", func() {
+			BeforeEach(func() {
+				err := staleBuild.SaveEvent(event.Log{
+					Payload: "still going",
+					Time:    time.Now().Unix(),
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("leaves the build running", func() {
+				count, err := team.FinishAbandonedBuilds(time.Now().Add(-time.Hour))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(count).To(BeZero())
+
+				found, err := staleBuild.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(staleBuild.Status()).To(Equal(db.BuildStatusStarted))
+			})
+		})
+
+		It("does not count builds which have already been finished", func() {
+			_, err := team.FinishAbandonedBuilds(time.Now().Add(-time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+
+			count, err := team.FinishAbandonedBuilds(time.Now().Add(-time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(BeZero())
 		})
 	})
 
@@ -1395,6 +1508,60 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("OneOffBuilds", func() {
+		var firstOneOff, secondOneOff db.Build
+
+		BeforeEach(func() {
+			var err error
+			firstOneOff, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			pipeline, _, err := team.SavePipeline("some-pipeline", atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+					},
+				},
+			}, db.ConfigVersion(1), false)
+			Expect(err).ToNot(HaveOccurred())
+
+			job, found, err := pipeline.Job("some-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, err = job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			secondOneOff, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = otherTeam.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns only the team's one-off builds, newest first", func() {
+			builds, _, err := team.OneOffBuilds(db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(2))
+			Expect(builds[0].ID()).To(Equal(secondOneOff.ID()))
+			Expect(builds[1].ID()).To(Equal(firstOneOff.ID()))
+		})
+
+		It("paginates through the one-off builds", func() {
+			builds, pagination, err := team.OneOffBuilds(db.Page{Limit: 1})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(secondOneOff.ID()))
+			Expect(pagination.Next).NotTo(BeNil())
+
+			builds, pagination, err = team.OneOffBuilds(*pagination.Next)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(firstOneOff.ID()))
+			Expect(pagination.Next).To(BeNil())
+		})
+	})
+
 	Describe("Builds", func() {
 		var (
 			expectedBuilds                              []db.Build
@@ -242,6 +242,11 @@ func (builder *stepBuilder) buildRetryStep(build db.Build, plan atc.Plan) exec.S
 }
 
 func (builder *stepBuilder) buildGetStep(build db.Build, plan atc.Plan) exec.Step {
+	if len(plan.Get.Tags) == 0 && len(build.Tags()) > 0 {
+		get := *plan.Get
+		get.Tags = build.Tags()
+		plan.Get = &get
+	}
 
 	containerMetadata := builder.containerMetadata(
 		build,
@@ -264,6 +269,11 @@ func (builder *stepBuilder) buildGetStep(build db.Build, plan atc.Plan) exec.Ste
 }
 
 func (builder *stepBuilder) buildPutStep(build db.Build, plan atc.Plan) exec.Step {
+	if len(plan.Put.Tags) == 0 && len(build.Tags()) > 0 {
+		put := *plan.Put
+		put.Tags = build.Tags()
+		plan.Put = &put
+	}
 
 	containerMetadata := builder.containerMetadata(
 		build,
@@ -286,6 +296,11 @@ func (builder *stepBuilder) buildPutStep(build db.Build, plan atc.Plan) exec.Ste
 }
 
 func (builder *stepBuilder) buildTaskStep(build db.Build, plan atc.Plan) exec.Step {
+	if len(plan.Task.Tags) == 0 && len(build.Tags()) > 0 {
+		task := *plan.Task
+		task.Tags = build.Tags()
+		plan.Task = &task
+	}
 
 	containerMetadata := builder.containerMetadata(
 		build,
@@ -518,6 +518,29 @@ var _ = Describe("Builder", func() {
 								BuildName:    "42",
 							}))
 						})
+
+						Context("when the build has tags", func() {
+							BeforeEach(func() {
+								fakeBuild.TagsReturns([]string{"some-build-tag"})
+							})
+
+							It("runs the task on workers with the build's tags", func() {
+								plan, _, _, _, _ := fakeStepFactory.TaskStepArgsForCall(0)
+								Expect(plan.Task.Tags).To(Equal(atc.Tags{"some-build-tag"}))
+								Expect(expectedPlan.Task.Tags).To(BeEmpty())
+							})
+
+							Context("when the task has tags of its own", func() {
+								BeforeEach(func() {
+									expectedPlan.Task.Tags = atc.Tags{"some-task-tag"}
+								})
+
+								It("keeps the task's tags", func() {
+									plan, _, _, _, _ := fakeStepFactory.TaskStepArgsForCall(0)
+									Expect(plan.Task.Tags).To(Equal(atc.Tags{"some-task-tag"}))
+								})
+							})
+						})
 					})
 
 					Context("that contains outputs", func() {
@@ -11,6 +11,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/worker"
 )
 
 func NewDelegateFactory() *delegateFactory {
@@ -95,6 +96,10 @@ func (d *getDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus,
 	logger.Info("finished", lager.Data{"exit-status": exitStatus})
 }
 
+func (d *getDelegate) ResourceCacheUsed(resourceCache db.UsedResourceCache) error {
+	return d.build.RegisterResourceCacheUse(resourceCache)
+}
+
 func (d *getDelegate) UpdateVersion(log lager.Logger, plan atc.GetPlan, info exec.VersionInfo) {
 	logger := log.WithData(lager.Data{
 		"pipeline-name": d.build.PipelineName(),
@@ -246,6 +251,14 @@ func (d *taskDelegate) Initializing(logger lager.Logger, taskConfig atc.TaskConf
 	logger.Info("initializing")
 }
 
+func (d *taskDelegate) SelectedWorker(logger lager.Logger, workerName string) {
+	err := d.build.SaveWorkerName(workerName)
+	if err != nil {
+		logger.Error("failed-to-save-worker-name", err, lager.Data{"worker": workerName})
+		return
+	}
+}
+
 func (d *taskDelegate) Starting(logger lager.Logger, taskConfig atc.TaskConfig) {
 	err := d.build.SaveEvent(event.StartTask{
 		Origin:     d.eventOrigin,
@@ -293,9 +306,50 @@ type buildStepDelegate struct {
 }
 
 func (delegate *buildStepDelegate) ImageVersionDetermined(resourceCache db.UsedResourceCache) error {
+	err := delegate.build.RegisterResourceCacheUse(resourceCache)
+	if err != nil {
+		return err
+	}
+
 	return delegate.build.SaveImageResourceVersion(resourceCache)
 }
 
+func (delegate *buildStepDelegate) ImageChecked(logger lager.Logger, imageResource worker.ImageResource, version atc.Version) {
+	err := delegate.build.SaveEvent(event.ImageCheck{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time:           delegate.clock.Now().Unix(),
+		ResourceType:   imageResource.Type,
+		CheckedVersion: version,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-image-check-event", err)
+	}
+}
+
+func (delegate *buildStepDelegate) ImageFetched(logger lager.Logger, imageResource worker.ImageResource, version atc.Version) {
+	err := delegate.build.SaveEvent(event.ImageGet{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time:           delegate.clock.Now().Unix(),
+		ResourceType:   imageResource.Type,
+		FetchedVersion: version,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-image-get-event", err)
+	}
+}
+
+// WaitingForWorker records on the build that no worker has the given tags.
+func (delegate *buildStepDelegate) WaitingForWorker(logger lager.Logger, tags atc.Tags) {
+	err := delegate.build.SetWaitingForWorker(append([]string{}, tags...))
+	if err != nil {
+		logger.Error("failed-to-set-waiting-for-worker", err)
+	}
+}
+
 func (delegate *buildStepDelegate) Stdout() io.Writer {
 	return newDBEventWriter(
 		delegate.build,
@@ -331,6 +385,19 @@ func (delegate *buildStepDelegate) Errored(logger lager.Logger, message string)
 	}
 }
 
+func (delegate *buildStepDelegate) SecretAccessed(logger lager.Logger, secretPath string) {
+	err := delegate.build.SaveEvent(event.SecretAccessed{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time: delegate.clock.Now().Unix(),
+		Path: secretPath,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-secret-accessed-event", err)
+	}
+}
+
 func newDBEventWriter(build db.Build, origin event.Origin, clock clock.Clock) io.Writer {
 	return &dbEventWriter{
 		build:  build,
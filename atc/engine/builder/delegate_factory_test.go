@@ -14,6 +14,7 @@ import (
 	"github.com/concourse/concourse/atc/engine/builder"
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/worker"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -70,6 +71,21 @@ var _ = Describe("DelegateFactory", func() {
 			})
 		})
 
+		Describe("ResourceCacheUsed", func() {
+			var fakeResourceCache *dbfakes.FakeUsedResourceCache
+
+			BeforeEach(func() {
+				fakeResourceCache = new(dbfakes.FakeUsedResourceCache)
+			})
+
+			It("registers the build's use of the resource cache", func() {
+				Expect(delegate.ResourceCacheUsed(fakeResourceCache)).To(Succeed())
+
+				Expect(fakeBuild.RegisterResourceCacheUseCallCount()).To(Equal(1))
+				Expect(fakeBuild.RegisterResourceCacheUseArgsForCall(0)).To(Equal(fakeResourceCache))
+			})
+		})
+
 		Describe("UpdateVersion", func() {
 			JustBeforeEach(func() {
 				plan := atc.GetPlan{Resource: "some-resource"}
@@ -234,6 +250,17 @@ var _ = Describe("DelegateFactory", func() {
 			})
 		})
 
+		Describe("SelectedWorker", func() {
+			JustBeforeEach(func() {
+				delegate.SelectedWorker(logger, "some-worker")
+			})
+
+			It("records the worker on the build", func() {
+				Expect(fakeBuild.SaveWorkerNameCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveWorkerNameArgsForCall(0)).To(Equal("some-worker"))
+			})
+		})
+
 		Describe("Starting", func() {
 			JustBeforeEach(func() {
 				delegate.Starting(logger, config)
@@ -284,6 +311,74 @@ var _ = Describe("DelegateFactory", func() {
 				Expect(fakeBuild.SaveImageResourceVersionCallCount()).To(Equal(1))
 				Expect(fakeBuild.SaveImageResourceVersionArgsForCall(0)).To(Equal(fakeResourceCache))
 			})
+
+			It("registers the build's use of the resource cache", func() {
+				Expect(fakeBuild.RegisterResourceCacheUseCallCount()).To(Equal(1))
+				Expect(fakeBuild.RegisterResourceCacheUseArgsForCall(0)).To(Equal(fakeResourceCache))
+			})
+		})
+
+		Describe("ImageChecked", func() {
+			JustBeforeEach(func() {
+				delegate.ImageChecked(logger, worker.ImageResource{Type: "registry-image"}, atc.Version{"digest": "sha256:abc"})
+			})
+
+			It("saves an image-check event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.ImageCheck{
+					Origin:         event.Origin{ID: "some-plan-id"},
+					Time:           123456789,
+					ResourceType:   "registry-image",
+					CheckedVersion: atc.Version{"digest": "sha256:abc"},
+				}))
+			})
+		})
+
+		Describe("ImageFetched", func() {
+			JustBeforeEach(func() {
+				delegate.ImageFetched(logger, worker.ImageResource{Type: "registry-image"}, atc.Version{"digest": "sha256:abc"})
+			})
+
+			It("saves an image-get event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.ImageGet{
+					Origin:         event.Origin{ID: "some-plan-id"},
+					Time:           123456789,
+					ResourceType:   "registry-image",
+					FetchedVersion: atc.Version{"digest": "sha256:abc"},
+				}))
+			})
+		})
+
+		Describe("SecretAccessed", func() {
+			JustBeforeEach(func() {
+				delegate.SecretAccessed(logger, "/concourse/main/some-secret")
+			})
+
+			It("saves a secret-accessed event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.SecretAccessed{
+					Origin: event.Origin{ID: "some-plan-id"},
+					Time:   123456789,
+					Path:   "/concourse/main/some-secret",
+				}))
+			})
+		})
+
+		Describe("WaitingForWorker", func() {
+			It("records the unsatisfiable tags on the build", func() {
+				delegate.WaitingForWorker(logger, atc.Tags{"some-tag"})
+
+				Expect(fakeBuild.SetWaitingForWorkerCallCount()).To(Equal(1))
+				Expect(fakeBuild.SetWaitingForWorkerArgsForCall(0)).To(Equal([]string{"some-tag"}))
+			})
+
+			It("records a worker with no tags as missing rather than clearing it", func() {
+				delegate.WaitingForWorker(logger, nil)
+
+				Expect(fakeBuild.SetWaitingForWorkerCallCount()).To(Equal(1))
+				Expect(fakeBuild.SetWaitingForWorkerArgsForCall(0)).To(Equal([]string{}))
+			})
 		})
 
 		Describe("Stdout", func() {
@@ -111,6 +111,26 @@ const (
 	OriginSourceStderr OriginSource = "stderr"
 )
 
+type ImageCheck struct {
+	Origin         Origin      `json:"origin"`
+	Time           int64       `json:"time"`
+	ResourceType   string      `json:"resource_type"`
+	CheckedVersion atc.Version `json:"version"`
+}
+
+func (ImageCheck) EventType() atc.EventType  { return EventTypeImageCheck }
+func (ImageCheck) Version() atc.EventVersion { return "1.0" }
+
+type ImageGet struct {
+	Origin         Origin      `json:"origin"`
+	Time           int64       `json:"time"`
+	ResourceType   string      `json:"resource_type"`
+	FetchedVersion atc.Version `json:"version"`
+}
+
+func (ImageGet) EventType() atc.EventType  { return EventTypeImageGet }
+func (ImageGet) Version() atc.EventVersion { return "1.0" }
+
 type InitializeGet struct {
 	Origin Origin `json:"origin"`
 	Time   int64  `json:"time,omitempty"`
@@ -164,3 +184,28 @@ type FinishPut struct {
 
 func (FinishPut) EventType() atc.EventType  { return EventTypeFinishPut }
 func (FinishPut) Version() atc.EventVersion { return "5.1" }
+
+// SecretAccessed records the path of a credential that was looked up while
+// running a step. The secret's value is never included, and the event is left
+// out of the build's event stream served by the API.
+type SecretAccessed struct {
+	Origin Origin `json:"origin"`
+	Time   int64  `json:"time"`
+	Path   string `json:"path"`
+}
+
+func (SecretAccessed) EventType() atc.EventType  { return EventTypeSecretAccessed }
+func (SecretAccessed) Version() atc.EventVersion { return "1.0" }
+
+// BuildSummary is saved after the final status event of a build. Duration is
+// in seconds, and is zero for builds which never started.
+type BuildSummary struct {
+	Status   atc.BuildStatus `json:"status"`
+	Time     int64           `json:"time"`
+	Duration int64           `json:"duration"`
+	Inputs   int             `json:"inputs"`
+	Outputs  int             `json:"outputs"`
+}
+
+func (BuildSummary) EventType() atc.EventType  { return EventTypeBuildSummary }
+func (BuildSummary) Version() atc.EventVersion { return "1.0" }
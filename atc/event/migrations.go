@@ -0,0 +1,64 @@
+package event
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// Migration rewrites the payload of an event into the payload of a newer
+// version of the event.
+type Migration func(payload []byte) ([]byte, error)
+
+type migrationKey struct {
+	eventType atc.EventType
+	version   atc.EventVersion
+}
+
+type migration struct {
+	to      atc.EventVersion
+	migrate Migration
+}
+
+var (
+	migrations  = map[migrationKey]migration{}
+	migrationsL sync.RWMutex
+)
+
+// RegisterMigration registers a migration which upgrades events of the given
+// type and version to a newer version when they are read back.
+func RegisterMigration(eventType atc.EventType, from atc.EventVersion, to atc.EventVersion, migrate Migration) {
+	migrationsL.Lock()
+	defer migrationsL.Unlock()
+
+	migrations[migrationKey{eventType, from}] = migration{to, migrate}
+}
+
+// Upgraded returns the envelope with its event upgraded by each registered
+// migration in turn, until no migration applies to its version.
+func (e Envelope) Upgraded() (Envelope, error) {
+	migrationsL.RLock()
+	defer migrationsL.RUnlock()
+
+	seen := map[atc.EventVersion]bool{}
+	for e.Data != nil && !seen[e.Version] {
+		seen[e.Version] = true
+
+		m, found := migrations[migrationKey{e.Event, e.Version}]
+		if !found {
+			break
+		}
+
+		payload, err := m.migrate(*e.Data)
+		if err != nil {
+			return Envelope{}, err
+		}
+
+		data := json.RawMessage(payload)
+		e.Data = &data
+		e.Version = m.to
+	}
+
+	return e, nil
+}
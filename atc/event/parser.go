@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 )
@@ -46,6 +47,10 @@ func init() {
 	RegisterEvent(Status{})
 	RegisterEvent(Log{})
 	RegisterEvent(Error{})
+	RegisterEvent(ImageCheck{})
+	RegisterEvent(ImageGet{})
+	RegisterEvent(SecretAccessed{})
+	RegisterEvent(BuildSummary{})
 
 	// deprecated:
 	RegisterEvent(InitializeV10{})
@@ -92,6 +97,10 @@ type Envelope struct {
 	Data    *json.RawMessage `json:"data"`
 	Event   atc.EventType    `json:"event"`
 	Version atc.EventVersion `json:"version"`
+
+	// InsertedAt is when the event was saved, if known. Events saved before
+	// this was recorded do not have it.
+	InsertedAt *time.Time `json:"inserted_at,omitempty"`
 }
 
 func (m Message) MarshalJSON() ([]byte, error) {
@@ -1,6 +1,9 @@
 package event_test
 
 import (
+	"encoding/json"
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -49,3 +52,89 @@ var _ = Describe("ParseEvent", func() {
 		}))
 	})
 })
+
+var _ = Describe("Envelope", func() {
+	var data json.RawMessage
+
+	BeforeEach(func() {
+		data = json.RawMessage(`{"hello":"sup"}`)
+	})
+
+	It("omits the insert time when it is not known", func() {
+		payload, err := json.Marshal(event.Envelope{
+			Data:    &data,
+			Event:   "fake",
+			Version: "5.1",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(payload).To(MatchJSON(`{"data":{"hello":"sup"},"event":"fake","version":"5.1"}`))
+	})
+
+	It("includes the insert time when it is known", func() {
+		insertedAt := time.Date(2019, 8, 9, 10, 0, 0, 0, time.UTC)
+
+		payload, err := json.Marshal(event.Envelope{
+			Data:       &data,
+			Event:      "fake",
+			Version:    "5.1",
+			InsertedAt: &insertedAt,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(payload).To(MatchJSON(`{"data":{"hello":"sup"},"event":"fake","version":"5.1","inserted_at":"2019-08-09T10:00:00Z"}`))
+	})
+})
+
+var _ = Describe("Upgraded", func() {
+	BeforeEach(func() {
+		event.RegisterMigration("fake-migrated", "1.0", "2.0", func(payload []byte) ([]byte, error) {
+			var v1 struct {
+				Hello string `json:"hello"`
+			}
+
+			err := json.Unmarshal(payload, &v1)
+			if err != nil {
+				return nil, err
+			}
+
+			return json.Marshal(map[string]string{"greeting": v1.Hello})
+		})
+	})
+
+	It("applies the migration registered for the event's version", func() {
+		data := json.RawMessage(`{"hello":"sup"}`)
+
+		upgraded, err := event.Envelope{
+			Data:    &data,
+			Event:   "fake-migrated",
+			Version: "1.0",
+		}.Upgraded()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upgraded.Version).To(Equal(atc.EventVersion("2.0")))
+		Expect(*upgraded.Data).To(MatchJSON(`{"greeting":"sup"}`))
+	})
+
+	It("leaves events without a migration alone", func() {
+		data := json.RawMessage(`{"greeting":"sup"}`)
+
+		envelope := event.Envelope{
+			Data:    &data,
+			Event:   "fake-migrated",
+			Version: "2.0",
+		}
+
+		upgraded, err := envelope.Upgraded()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upgraded).To(Equal(envelope))
+	})
+
+	It("returns errors from the migration", func() {
+		data := json.RawMessage(`not json`)
+
+		_, err := event.Envelope{
+			Data:    &data,
+			Event:   "fake-migrated",
+			Version: "1.0",
+		}.Upgraded()
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -38,4 +38,16 @@ const (
 
 	// error occurred
 	EventTypeError atc.EventType = "error"
+
+	// checked for the latest version of an image
+	EventTypeImageCheck atc.EventType = "image-check"
+
+	// fetched an image
+	EventTypeImageGet atc.EventType = "image-get"
+
+	// looked up a credential
+	EventTypeSecretAccessed atc.EventType = "secret-accessed"
+
+	// build finished (follows the final status)
+	EventTypeBuildSummary atc.EventType = "build-summary"
 )
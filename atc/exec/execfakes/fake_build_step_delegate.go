@@ -6,8 +6,10 @@ import (
 	"sync"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/worker"
 )
 
 type FakeBuildStepDelegate struct {
@@ -17,6 +19,20 @@ type FakeBuildStepDelegate struct {
 		arg1 lager.Logger
 		arg2 string
 	}
+	ImageCheckedStub        func(lager.Logger, worker.ImageResource, atc.Version)
+	imageCheckedMutex       sync.RWMutex
+	imageCheckedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.ImageResource
+		arg3 atc.Version
+	}
+	ImageFetchedStub        func(lager.Logger, worker.ImageResource, atc.Version)
+	imageFetchedMutex       sync.RWMutex
+	imageFetchedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.ImageResource
+		arg3 atc.Version
+	}
 	ImageVersionDeterminedStub        func(db.UsedResourceCache) error
 	imageVersionDeterminedMutex       sync.RWMutex
 	imageVersionDeterminedArgsForCall []struct {
@@ -28,6 +44,12 @@ type FakeBuildStepDelegate struct {
 	imageVersionDeterminedReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SecretAccessedStub        func(lager.Logger, string)
+	secretAccessedMutex       sync.RWMutex
+	secretAccessedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
 	StderrStub        func() io.Writer
 	stderrMutex       sync.RWMutex
 	stderrArgsForCall []struct {
@@ -48,6 +70,12 @@ type FakeBuildStepDelegate struct {
 	stdoutReturnsOnCall map[int]struct {
 		result1 io.Writer
 	}
+	WaitingForWorkerStub        func(lager.Logger, atc.Tags)
+	waitingForWorkerMutex       sync.RWMutex
+	waitingForWorkerArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Tags
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -84,6 +112,72 @@ func (fake *FakeBuildStepDelegate) ErroredArgsForCall(i int) (lager.Logger, stri
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeBuildStepDelegate) ImageChecked(arg1 lager.Logger, arg2 worker.ImageResource, arg3 atc.Version) {
+	fake.imageCheckedMutex.Lock()
+	fake.imageCheckedArgsForCall = append(fake.imageCheckedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.ImageResource
+		arg3 atc.Version
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ImageChecked", []interface{}{arg1, arg2, arg3})
+	fake.imageCheckedMutex.Unlock()
+	if fake.ImageCheckedStub != nil {
+		fake.ImageCheckedStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) ImageCheckedCallCount() int {
+	fake.imageCheckedMutex.RLock()
+	defer fake.imageCheckedMutex.RUnlock()
+	return len(fake.imageCheckedArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) ImageCheckedCalls(stub func(lager.Logger, worker.ImageResource, atc.Version)) {
+	fake.imageCheckedMutex.Lock()
+	defer fake.imageCheckedMutex.Unlock()
+	fake.ImageCheckedStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) ImageCheckedArgsForCall(i int) (lager.Logger, worker.ImageResource, atc.Version) {
+	fake.imageCheckedMutex.RLock()
+	defer fake.imageCheckedMutex.RUnlock()
+	argsForCall := fake.imageCheckedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBuildStepDelegate) ImageFetched(arg1 lager.Logger, arg2 worker.ImageResource, arg3 atc.Version) {
+	fake.imageFetchedMutex.Lock()
+	fake.imageFetchedArgsForCall = append(fake.imageFetchedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.ImageResource
+		arg3 atc.Version
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ImageFetched", []interface{}{arg1, arg2, arg3})
+	fake.imageFetchedMutex.Unlock()
+	if fake.ImageFetchedStub != nil {
+		fake.ImageFetchedStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) ImageFetchedCallCount() int {
+	fake.imageFetchedMutex.RLock()
+	defer fake.imageFetchedMutex.RUnlock()
+	return len(fake.imageFetchedArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) ImageFetchedCalls(stub func(lager.Logger, worker.ImageResource, atc.Version)) {
+	fake.imageFetchedMutex.Lock()
+	defer fake.imageFetchedMutex.Unlock()
+	fake.ImageFetchedStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) ImageFetchedArgsForCall(i int) (lager.Logger, worker.ImageResource, atc.Version) {
+	fake.imageFetchedMutex.RLock()
+	defer fake.imageFetchedMutex.RUnlock()
+	argsForCall := fake.imageFetchedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
 func (fake *FakeBuildStepDelegate) ImageVersionDetermined(arg1 db.UsedResourceCache) error {
 	fake.imageVersionDeterminedMutex.Lock()
 	ret, specificReturn := fake.imageVersionDeterminedReturnsOnCall[len(fake.imageVersionDeterminedArgsForCall)]
@@ -144,6 +238,38 @@ func (fake *FakeBuildStepDelegate) ImageVersionDeterminedReturnsOnCall(i int, re
 	}{result1}
 }
 
+func (fake *FakeBuildStepDelegate) SecretAccessed(arg1 lager.Logger, arg2 string) {
+	fake.secretAccessedMutex.Lock()
+	fake.secretAccessedArgsForCall = append(fake.secretAccessedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("SecretAccessed", []interface{}{arg1, arg2})
+	fake.secretAccessedMutex.Unlock()
+	if fake.SecretAccessedStub != nil {
+		fake.SecretAccessedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) SecretAccessedCallCount() int {
+	fake.secretAccessedMutex.RLock()
+	defer fake.secretAccessedMutex.RUnlock()
+	return len(fake.secretAccessedArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) SecretAccessedCalls(stub func(lager.Logger, string)) {
+	fake.secretAccessedMutex.Lock()
+	defer fake.secretAccessedMutex.Unlock()
+	fake.SecretAccessedStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) SecretAccessedArgsForCall(i int) (lager.Logger, string) {
+	fake.secretAccessedMutex.RLock()
+	defer fake.secretAccessedMutex.RUnlock()
+	argsForCall := fake.secretAccessedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeBuildStepDelegate) Stderr() io.Writer {
 	fake.stderrMutex.Lock()
 	ret, specificReturn := fake.stderrReturnsOnCall[len(fake.stderrArgsForCall)]
@@ -248,17 +374,57 @@ func (fake *FakeBuildStepDelegate) StdoutReturnsOnCall(i int, result1 io.Writer)
 	}{result1}
 }
 
+func (fake *FakeBuildStepDelegate) WaitingForWorker(arg1 lager.Logger, arg2 atc.Tags) {
+	fake.waitingForWorkerMutex.Lock()
+	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Tags
+	}{arg1, arg2})
+	fake.recordInvocation("WaitingForWorker", []interface{}{arg1, arg2})
+	fake.waitingForWorkerMutex.Unlock()
+	if fake.WaitingForWorkerStub != nil {
+		fake.WaitingForWorkerStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) WaitingForWorkerCallCount() int {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	return len(fake.waitingForWorkerArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) WaitingForWorkerCalls(stub func(lager.Logger, atc.Tags)) {
+	fake.waitingForWorkerMutex.Lock()
+	defer fake.waitingForWorkerMutex.Unlock()
+	fake.WaitingForWorkerStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) WaitingForWorkerArgsForCall(i int) (lager.Logger, atc.Tags) {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	argsForCall := fake.waitingForWorkerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeBuildStepDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
+	fake.imageCheckedMutex.RLock()
+	defer fake.imageCheckedMutex.RUnlock()
+	fake.imageFetchedMutex.RLock()
+	defer fake.imageFetchedMutex.RUnlock()
 	fake.imageVersionDeterminedMutex.RLock()
 	defer fake.imageVersionDeterminedMutex.RUnlock()
+	fake.secretAccessedMutex.RLock()
+	defer fake.secretAccessedMutex.RUnlock()
 	fake.stderrMutex.RLock()
 	defer fake.stderrMutex.RUnlock()
 	fake.stdoutMutex.RLock()
 	defer fake.stdoutMutex.RUnlock()
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -9,6 +9,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/worker"
 )
 
 type FakeGetDelegate struct {
@@ -25,6 +26,20 @@ type FakeGetDelegate struct {
 		arg2 exec.ExitStatus
 		arg3 exec.VersionInfo
 	}
+	ImageCheckedStub        func(lager.Logger, worker.ImageResource, atc.Version)
+	imageCheckedMutex       sync.RWMutex
+	imageCheckedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.ImageResource
+		arg3 atc.Version
+	}
+	ImageFetchedStub        func(lager.Logger, worker.ImageResource, atc.Version)
+	imageFetchedMutex       sync.RWMutex
+	imageFetchedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.ImageResource
+		arg3 atc.Version
+	}
 	ImageVersionDeterminedStub        func(db.UsedResourceCache) error
 	imageVersionDeterminedMutex       sync.RWMutex
 	imageVersionDeterminedArgsForCall []struct {
@@ -41,6 +56,23 @@ type FakeGetDelegate struct {
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	ResourceCacheUsedStub        func(db.UsedResourceCache) error
+	resourceCacheUsedMutex       sync.RWMutex
+	resourceCacheUsedArgsForCall []struct {
+		arg1 db.UsedResourceCache
+	}
+	resourceCacheUsedReturns struct {
+		result1 error
+	}
+	resourceCacheUsedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SecretAccessedStub        func(lager.Logger, string)
+	secretAccessedMutex       sync.RWMutex
+	secretAccessedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
 	StartingStub        func(lager.Logger)
 	startingMutex       sync.RWMutex
 	startingArgsForCall []struct {
@@ -73,6 +105,12 @@ type FakeGetDelegate struct {
 		arg2 atc.GetPlan
 		arg3 exec.VersionInfo
 	}
+	WaitingForWorkerStub        func(lager.Logger, atc.Tags)
+	waitingForWorkerMutex       sync.RWMutex
+	waitingForWorkerArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Tags
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -142,6 +180,72 @@ func (fake *FakeGetDelegate) FinishedArgsForCall(i int) (lager.Logger, exec.Exit
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
+func (fake *FakeGetDelegate) ImageChecked(arg1 lager.Logger, arg2 worker.ImageResource, arg3 atc.Version) {
+	fake.imageCheckedMutex.Lock()
+	fake.imageCheckedArgsForCall = append(fake.imageCheckedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.ImageResource
+		arg3 atc.Version
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ImageChecked", []interface{}{arg1, arg2, arg3})
+	fake.imageCheckedMutex.Unlock()
+	if fake.ImageCheckedStub != nil {
+		fake.ImageCheckedStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeGetDelegate) ImageCheckedCallCount() int {
+	fake.imageCheckedMutex.RLock()
+	defer fake.imageCheckedMutex.RUnlock()
+	return len(fake.imageCheckedArgsForCall)
+}
+
+func (fake *FakeGetDelegate) ImageCheckedCalls(stub func(lager.Logger, worker.ImageResource, atc.Version)) {
+	fake.imageCheckedMutex.Lock()
+	defer fake.imageCheckedMutex.Unlock()
+	fake.ImageCheckedStub = stub
+}
+
+func (fake *FakeGetDelegate) ImageCheckedArgsForCall(i int) (lager.Logger, worker.ImageResource, atc.Version) {
+	fake.imageCheckedMutex.RLock()
+	defer fake.imageCheckedMutex.RUnlock()
+	argsForCall := fake.imageCheckedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeGetDelegate) ImageFetched(arg1 lager.Logger, arg2 worker.ImageResource, arg3 atc.Version) {
+	fake.imageFetchedMutex.Lock()
+	fake.imageFetchedArgsForCall = append(fake.imageFetchedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.ImageResource
+		arg3 atc.Version
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ImageFetched", []interface{}{arg1, arg2, arg3})
+	fake.imageFetchedMutex.Unlock()
+	if fake.ImageFetchedStub != nil {
+		fake.ImageFetchedStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeGetDelegate) ImageFetchedCallCount() int {
+	fake.imageFetchedMutex.RLock()
+	defer fake.imageFetchedMutex.RUnlock()
+	return len(fake.imageFetchedArgsForCall)
+}
+
+func (fake *FakeGetDelegate) ImageFetchedCalls(stub func(lager.Logger, worker.ImageResource, atc.Version)) {
+	fake.imageFetchedMutex.Lock()
+	defer fake.imageFetchedMutex.Unlock()
+	fake.ImageFetchedStub = stub
+}
+
+func (fake *FakeGetDelegate) ImageFetchedArgsForCall(i int) (lager.Logger, worker.ImageResource, atc.Version) {
+	fake.imageFetchedMutex.RLock()
+	defer fake.imageFetchedMutex.RUnlock()
+	argsForCall := fake.imageFetchedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
 func (fake *FakeGetDelegate) ImageVersionDetermined(arg1 db.UsedResourceCache) error {
 	fake.imageVersionDeterminedMutex.Lock()
 	ret, specificReturn := fake.imageVersionDeterminedReturnsOnCall[len(fake.imageVersionDeterminedArgsForCall)]
@@ -233,6 +337,98 @@ func (fake *FakeGetDelegate) InitializingArgsForCall(i int) lager.Logger {
 	return argsForCall.arg1
 }
 
+func (fake *FakeGetDelegate) ResourceCacheUsed(arg1 db.UsedResourceCache) error {
+	fake.resourceCacheUsedMutex.Lock()
+	ret, specificReturn := fake.resourceCacheUsedReturnsOnCall[len(fake.resourceCacheUsedArgsForCall)]
+	fake.resourceCacheUsedArgsForCall = append(fake.resourceCacheUsedArgsForCall, struct {
+		arg1 db.UsedResourceCache
+	}{arg1})
+	fake.recordInvocation("ResourceCacheUsed", []interface{}{arg1})
+	fake.resourceCacheUsedMutex.Unlock()
+	if fake.ResourceCacheUsedStub != nil {
+		return fake.ResourceCacheUsedStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.resourceCacheUsedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeGetDelegate) ResourceCacheUsedCallCount() int {
+	fake.resourceCacheUsedMutex.RLock()
+	defer fake.resourceCacheUsedMutex.RUnlock()
+	return len(fake.resourceCacheUsedArgsForCall)
+}
+
+func (fake *FakeGetDelegate) ResourceCacheUsedCalls(stub func(db.UsedResourceCache) error) {
+	fake.resourceCacheUsedMutex.Lock()
+	defer fake.resourceCacheUsedMutex.Unlock()
+	fake.ResourceCacheUsedStub = stub
+}
+
+func (fake *FakeGetDelegate) ResourceCacheUsedArgsForCall(i int) db.UsedResourceCache {
+	fake.resourceCacheUsedMutex.RLock()
+	defer fake.resourceCacheUsedMutex.RUnlock()
+	argsForCall := fake.resourceCacheUsedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeGetDelegate) ResourceCacheUsedReturns(result1 error) {
+	fake.resourceCacheUsedMutex.Lock()
+	defer fake.resourceCacheUsedMutex.Unlock()
+	fake.ResourceCacheUsedStub = nil
+	fake.resourceCacheUsedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGetDelegate) ResourceCacheUsedReturnsOnCall(i int, result1 error) {
+	fake.resourceCacheUsedMutex.Lock()
+	defer fake.resourceCacheUsedMutex.Unlock()
+	fake.ResourceCacheUsedStub = nil
+	if fake.resourceCacheUsedReturnsOnCall == nil {
+		fake.resourceCacheUsedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resourceCacheUsedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGetDelegate) SecretAccessed(arg1 lager.Logger, arg2 string) {
+	fake.secretAccessedMutex.Lock()
+	fake.secretAccessedArgsForCall = append(fake.secretAccessedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("SecretAccessed", []interface{}{arg1, arg2})
+	fake.secretAccessedMutex.Unlock()
+	if fake.SecretAccessedStub != nil {
+		fake.SecretAccessedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeGetDelegate) SecretAccessedCallCount() int {
+	fake.secretAccessedMutex.RLock()
+	defer fake.secretAccessedMutex.RUnlock()
+	return len(fake.secretAccessedArgsForCall)
+}
+
+func (fake *FakeGetDelegate) SecretAccessedCalls(stub func(lager.Logger, string)) {
+	fake.secretAccessedMutex.Lock()
+	defer fake.secretAccessedMutex.Unlock()
+	fake.SecretAccessedStub = stub
+}
+
+func (fake *FakeGetDelegate) SecretAccessedArgsForCall(i int) (lager.Logger, string) {
+	fake.secretAccessedMutex.RLock()
+	defer fake.secretAccessedMutex.RUnlock()
+	argsForCall := fake.secretAccessedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeGetDelegate) Starting(arg1 lager.Logger) {
 	fake.startingMutex.Lock()
 	fake.startingArgsForCall = append(fake.startingArgsForCall, struct {
@@ -401,6 +597,38 @@ func (fake *FakeGetDelegate) UpdateVersionArgsForCall(i int) (lager.Logger, atc.
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
+func (fake *FakeGetDelegate) WaitingForWorker(arg1 lager.Logger, arg2 atc.Tags) {
+	fake.waitingForWorkerMutex.Lock()
+	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Tags
+	}{arg1, arg2})
+	fake.recordInvocation("WaitingForWorker", []interface{}{arg1, arg2})
+	fake.waitingForWorkerMutex.Unlock()
+	if fake.WaitingForWorkerStub != nil {
+		fake.WaitingForWorkerStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeGetDelegate) WaitingForWorkerCallCount() int {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	return len(fake.waitingForWorkerArgsForCall)
+}
+
+func (fake *FakeGetDelegate) WaitingForWorkerCalls(stub func(lager.Logger, atc.Tags)) {
+	fake.waitingForWorkerMutex.Lock()
+	defer fake.waitingForWorkerMutex.Unlock()
+	fake.WaitingForWorkerStub = stub
+}
+
+func (fake *FakeGetDelegate) WaitingForWorkerArgsForCall(i int) (lager.Logger, atc.Tags) {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	argsForCall := fake.waitingForWorkerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeGetDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -408,10 +636,18 @@ func (fake *FakeGetDelegate) Invocations() map[string][][]interface{} {
 	defer fake.erroredMutex.RUnlock()
 	fake.finishedMutex.RLock()
 	defer fake.finishedMutex.RUnlock()
+	fake.imageCheckedMutex.RLock()
+	defer fake.imageCheckedMutex.RUnlock()
+	fake.imageFetchedMutex.RLock()
+	defer fake.imageFetchedMutex.RUnlock()
 	fake.imageVersionDeterminedMutex.RLock()
 	defer fake.imageVersionDeterminedMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
+	fake.resourceCacheUsedMutex.RLock()
+	defer fake.resourceCacheUsedMutex.RUnlock()
+	fake.secretAccessedMutex.RLock()
+	defer fake.secretAccessedMutex.RUnlock()
 	fake.startingMutex.RLock()
 	defer fake.startingMutex.RUnlock()
 	fake.stderrMutex.RLock()
@@ -420,6 +656,8 @@ func (fake *FakeGetDelegate) Invocations() map[string][][]interface{} {
 	defer fake.stdoutMutex.RUnlock()
 	fake.updateVersionMutex.RLock()
 	defer fake.updateVersionMutex.RUnlock()
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -9,6 +9,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/worker"
 )
 
 type FakePutDelegate struct {
@@ -25,6 +26,20 @@ type FakePutDelegate struct {
 		arg2 exec.ExitStatus
 		arg3 exec.VersionInfo
 	}
+	ImageCheckedStub        func(lager.Logger, worker.ImageResource, atc.Version)
+	imageCheckedMutex       sync.RWMutex
+	imageCheckedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.ImageResource
+		arg3 atc.Version
+	}
+	ImageFetchedStub        func(lager.Logger, worker.ImageResource, atc.Version)
+	imageFetchedMutex       sync.RWMutex
+	imageFetchedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.ImageResource
+		arg3 atc.Version
+	}
 	ImageVersionDeterminedStub        func(db.UsedResourceCache) error
 	imageVersionDeterminedMutex       sync.RWMutex
 	imageVersionDeterminedArgsForCall []struct {
@@ -50,6 +65,12 @@ type FakePutDelegate struct {
 		arg4 atc.VersionedResourceTypes
 		arg5 exec.VersionInfo
 	}
+	SecretAccessedStub        func(lager.Logger, string)
+	secretAccessedMutex       sync.RWMutex
+	secretAccessedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
 	StartingStub        func(lager.Logger)
 	startingMutex       sync.RWMutex
 	startingArgsForCall []struct {
@@ -75,6 +96,12 @@ type FakePutDelegate struct {
 	stdoutReturnsOnCall map[int]struct {
 		result1 io.Writer
 	}
+	WaitingForWorkerStub        func(lager.Logger, atc.Tags)
+	waitingForWorkerMutex       sync.RWMutex
+	waitingForWorkerArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Tags
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -144,6 +171,72 @@ func (fake *FakePutDelegate) FinishedArgsForCall(i int) (lager.Logger, exec.Exit
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
+func (fake *FakePutDelegate) ImageChecked(arg1 lager.Logger, arg2 worker.ImageResource, arg3 atc.Version) {
+	fake.imageCheckedMutex.Lock()
+	fake.imageCheckedArgsForCall = append(fake.imageCheckedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.ImageResource
+		arg3 atc.Version
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ImageChecked", []interface{}{arg1, arg2, arg3})
+	fake.imageCheckedMutex.Unlock()
+	if fake.ImageCheckedStub != nil {
+		fake.ImageCheckedStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakePutDelegate) ImageCheckedCallCount() int {
+	fake.imageCheckedMutex.RLock()
+	defer fake.imageCheckedMutex.RUnlock()
+	return len(fake.imageCheckedArgsForCall)
+}
+
+func (fake *FakePutDelegate) ImageCheckedCalls(stub func(lager.Logger, worker.ImageResource, atc.Version)) {
+	fake.imageCheckedMutex.Lock()
+	defer fake.imageCheckedMutex.Unlock()
+	fake.ImageCheckedStub = stub
+}
+
+func (fake *FakePutDelegate) ImageCheckedArgsForCall(i int) (lager.Logger, worker.ImageResource, atc.Version) {
+	fake.imageCheckedMutex.RLock()
+	defer fake.imageCheckedMutex.RUnlock()
+	argsForCall := fake.imageCheckedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePutDelegate) ImageFetched(arg1 lager.Logger, arg2 worker.ImageResource, arg3 atc.Version) {
+	fake.imageFetchedMutex.Lock()
+	fake.imageFetchedArgsForCall = append(fake.imageFetchedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.ImageResource
+		arg3 atc.Version
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ImageFetched", []interface{}{arg1, arg2, arg3})
+	fake.imageFetchedMutex.Unlock()
+	if fake.ImageFetchedStub != nil {
+		fake.ImageFetchedStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakePutDelegate) ImageFetchedCallCount() int {
+	fake.imageFetchedMutex.RLock()
+	defer fake.imageFetchedMutex.RUnlock()
+	return len(fake.imageFetchedArgsForCall)
+}
+
+func (fake *FakePutDelegate) ImageFetchedCalls(stub func(lager.Logger, worker.ImageResource, atc.Version)) {
+	fake.imageFetchedMutex.Lock()
+	defer fake.imageFetchedMutex.Unlock()
+	fake.ImageFetchedStub = stub
+}
+
+func (fake *FakePutDelegate) ImageFetchedArgsForCall(i int) (lager.Logger, worker.ImageResource, atc.Version) {
+	fake.imageFetchedMutex.RLock()
+	defer fake.imageFetchedMutex.RUnlock()
+	argsForCall := fake.imageFetchedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
 func (fake *FakePutDelegate) ImageVersionDetermined(arg1 db.UsedResourceCache) error {
 	fake.imageVersionDeterminedMutex.Lock()
 	ret, specificReturn := fake.imageVersionDeterminedReturnsOnCall[len(fake.imageVersionDeterminedArgsForCall)]
@@ -270,6 +363,38 @@ func (fake *FakePutDelegate) SaveOutputArgsForCall(i int) (lager.Logger, atc.Put
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
+func (fake *FakePutDelegate) SecretAccessed(arg1 lager.Logger, arg2 string) {
+	fake.secretAccessedMutex.Lock()
+	fake.secretAccessedArgsForCall = append(fake.secretAccessedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("SecretAccessed", []interface{}{arg1, arg2})
+	fake.secretAccessedMutex.Unlock()
+	if fake.SecretAccessedStub != nil {
+		fake.SecretAccessedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakePutDelegate) SecretAccessedCallCount() int {
+	fake.secretAccessedMutex.RLock()
+	defer fake.secretAccessedMutex.RUnlock()
+	return len(fake.secretAccessedArgsForCall)
+}
+
+func (fake *FakePutDelegate) SecretAccessedCalls(stub func(lager.Logger, string)) {
+	fake.secretAccessedMutex.Lock()
+	defer fake.secretAccessedMutex.Unlock()
+	fake.SecretAccessedStub = stub
+}
+
+func (fake *FakePutDelegate) SecretAccessedArgsForCall(i int) (lager.Logger, string) {
+	fake.secretAccessedMutex.RLock()
+	defer fake.secretAccessedMutex.RUnlock()
+	argsForCall := fake.secretAccessedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakePutDelegate) Starting(arg1 lager.Logger) {
 	fake.startingMutex.Lock()
 	fake.startingArgsForCall = append(fake.startingArgsForCall, struct {
@@ -405,6 +530,38 @@ func (fake *FakePutDelegate) StdoutReturnsOnCall(i int, result1 io.Writer) {
 	}{result1}
 }
 
+func (fake *FakePutDelegate) WaitingForWorker(arg1 lager.Logger, arg2 atc.Tags) {
+	fake.waitingForWorkerMutex.Lock()
+	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Tags
+	}{arg1, arg2})
+	fake.recordInvocation("WaitingForWorker", []interface{}{arg1, arg2})
+	fake.waitingForWorkerMutex.Unlock()
+	if fake.WaitingForWorkerStub != nil {
+		fake.WaitingForWorkerStub(arg1, arg2)
+	}
+}
+
+func (fake *FakePutDelegate) WaitingForWorkerCallCount() int {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	return len(fake.waitingForWorkerArgsForCall)
+}
+
+func (fake *FakePutDelegate) WaitingForWorkerCalls(stub func(lager.Logger, atc.Tags)) {
+	fake.waitingForWorkerMutex.Lock()
+	defer fake.waitingForWorkerMutex.Unlock()
+	fake.WaitingForWorkerStub = stub
+}
+
+func (fake *FakePutDelegate) WaitingForWorkerArgsForCall(i int) (lager.Logger, atc.Tags) {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	argsForCall := fake.waitingForWorkerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakePutDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -412,18 +569,26 @@ func (fake *FakePutDelegate) Invocations() map[string][][]interface{} {
 	defer fake.erroredMutex.RUnlock()
 	fake.finishedMutex.RLock()
 	defer fake.finishedMutex.RUnlock()
+	fake.imageCheckedMutex.RLock()
+	defer fake.imageCheckedMutex.RUnlock()
+	fake.imageFetchedMutex.RLock()
+	defer fake.imageFetchedMutex.RUnlock()
 	fake.imageVersionDeterminedMutex.RLock()
 	defer fake.imageVersionDeterminedMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
 	fake.saveOutputMutex.RLock()
 	defer fake.saveOutputMutex.RUnlock()
+	fake.secretAccessedMutex.RLock()
+	defer fake.secretAccessedMutex.RUnlock()
 	fake.startingMutex.RLock()
 	defer fake.startingMutex.RUnlock()
 	fake.stderrMutex.RLock()
 	defer fake.stderrMutex.RUnlock()
 	fake.stdoutMutex.RLock()
 	defer fake.stdoutMutex.RUnlock()
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
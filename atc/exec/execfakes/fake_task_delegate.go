@@ -9,6 +9,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/worker"
 )
 
 type FakeTaskDelegate struct {
@@ -24,6 +25,20 @@ type FakeTaskDelegate struct {
 		arg1 lager.Logger
 		arg2 exec.ExitStatus
 	}
+	ImageCheckedStub        func(lager.Logger, worker.ImageResource, atc.Version)
+	imageCheckedMutex       sync.RWMutex
+	imageCheckedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.ImageResource
+		arg3 atc.Version
+	}
+	ImageFetchedStub        func(lager.Logger, worker.ImageResource, atc.Version)
+	imageFetchedMutex       sync.RWMutex
+	imageFetchedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.ImageResource
+		arg3 atc.Version
+	}
 	ImageVersionDeterminedStub        func(db.UsedResourceCache) error
 	imageVersionDeterminedMutex       sync.RWMutex
 	imageVersionDeterminedArgsForCall []struct {
@@ -41,6 +56,18 @@ type FakeTaskDelegate struct {
 		arg1 lager.Logger
 		arg2 atc.TaskConfig
 	}
+	SecretAccessedStub        func(lager.Logger, string)
+	secretAccessedMutex       sync.RWMutex
+	secretAccessedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	SelectedWorkerStub        func(lager.Logger, string)
+	selectedWorkerMutex       sync.RWMutex
+	selectedWorkerArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
 	StartingStub        func(lager.Logger, atc.TaskConfig)
 	startingMutex       sync.RWMutex
 	startingArgsForCall []struct {
@@ -67,6 +94,12 @@ type FakeTaskDelegate struct {
 	stdoutReturnsOnCall map[int]struct {
 		result1 io.Writer
 	}
+	WaitingForWorkerStub        func(lager.Logger, atc.Tags)
+	waitingForWorkerMutex       sync.RWMutex
+	waitingForWorkerArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Tags
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -135,6 +168,72 @@ func (fake *FakeTaskDelegate) FinishedArgsForCall(i int) (lager.Logger, exec.Exi
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeTaskDelegate) ImageChecked(arg1 lager.Logger, arg2 worker.ImageResource, arg3 atc.Version) {
+	fake.imageCheckedMutex.Lock()
+	fake.imageCheckedArgsForCall = append(fake.imageCheckedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.ImageResource
+		arg3 atc.Version
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ImageChecked", []interface{}{arg1, arg2, arg3})
+	fake.imageCheckedMutex.Unlock()
+	if fake.ImageCheckedStub != nil {
+		fake.ImageCheckedStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeTaskDelegate) ImageCheckedCallCount() int {
+	fake.imageCheckedMutex.RLock()
+	defer fake.imageCheckedMutex.RUnlock()
+	return len(fake.imageCheckedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ImageCheckedCalls(stub func(lager.Logger, worker.ImageResource, atc.Version)) {
+	fake.imageCheckedMutex.Lock()
+	defer fake.imageCheckedMutex.Unlock()
+	fake.ImageCheckedStub = stub
+}
+
+func (fake *FakeTaskDelegate) ImageCheckedArgsForCall(i int) (lager.Logger, worker.ImageResource, atc.Version) {
+	fake.imageCheckedMutex.RLock()
+	defer fake.imageCheckedMutex.RUnlock()
+	argsForCall := fake.imageCheckedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTaskDelegate) ImageFetched(arg1 lager.Logger, arg2 worker.ImageResource, arg3 atc.Version) {
+	fake.imageFetchedMutex.Lock()
+	fake.imageFetchedArgsForCall = append(fake.imageFetchedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.ImageResource
+		arg3 atc.Version
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ImageFetched", []interface{}{arg1, arg2, arg3})
+	fake.imageFetchedMutex.Unlock()
+	if fake.ImageFetchedStub != nil {
+		fake.ImageFetchedStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeTaskDelegate) ImageFetchedCallCount() int {
+	fake.imageFetchedMutex.RLock()
+	defer fake.imageFetchedMutex.RUnlock()
+	return len(fake.imageFetchedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ImageFetchedCalls(stub func(lager.Logger, worker.ImageResource, atc.Version)) {
+	fake.imageFetchedMutex.Lock()
+	defer fake.imageFetchedMutex.Unlock()
+	fake.ImageFetchedStub = stub
+}
+
+func (fake *FakeTaskDelegate) ImageFetchedArgsForCall(i int) (lager.Logger, worker.ImageResource, atc.Version) {
+	fake.imageFetchedMutex.RLock()
+	defer fake.imageFetchedMutex.RUnlock()
+	argsForCall := fake.imageFetchedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
 func (fake *FakeTaskDelegate) ImageVersionDetermined(arg1 db.UsedResourceCache) error {
 	fake.imageVersionDeterminedMutex.Lock()
 	ret, specificReturn := fake.imageVersionDeterminedReturnsOnCall[len(fake.imageVersionDeterminedArgsForCall)]
@@ -227,6 +326,70 @@ func (fake *FakeTaskDelegate) InitializingArgsForCall(i int) (lager.Logger, atc.
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeTaskDelegate) SecretAccessed(arg1 lager.Logger, arg2 string) {
+	fake.secretAccessedMutex.Lock()
+	fake.secretAccessedArgsForCall = append(fake.secretAccessedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("SecretAccessed", []interface{}{arg1, arg2})
+	fake.secretAccessedMutex.Unlock()
+	if fake.SecretAccessedStub != nil {
+		fake.SecretAccessedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeTaskDelegate) SecretAccessedCallCount() int {
+	fake.secretAccessedMutex.RLock()
+	defer fake.secretAccessedMutex.RUnlock()
+	return len(fake.secretAccessedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) SecretAccessedCalls(stub func(lager.Logger, string)) {
+	fake.secretAccessedMutex.Lock()
+	defer fake.secretAccessedMutex.Unlock()
+	fake.SecretAccessedStub = stub
+}
+
+func (fake *FakeTaskDelegate) SecretAccessedArgsForCall(i int) (lager.Logger, string) {
+	fake.secretAccessedMutex.RLock()
+	defer fake.secretAccessedMutex.RUnlock()
+	argsForCall := fake.secretAccessedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTaskDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
+	fake.selectedWorkerMutex.Lock()
+	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("SelectedWorker", []interface{}{arg1, arg2})
+	fake.selectedWorkerMutex.Unlock()
+	if fake.SelectedWorkerStub != nil {
+		fake.SelectedWorkerStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeTaskDelegate) SelectedWorkerCallCount() int {
+	fake.selectedWorkerMutex.RLock()
+	defer fake.selectedWorkerMutex.RUnlock()
+	return len(fake.selectedWorkerArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) SelectedWorkerCalls(stub func(lager.Logger, string)) {
+	fake.selectedWorkerMutex.Lock()
+	defer fake.selectedWorkerMutex.Unlock()
+	fake.SelectedWorkerStub = stub
+}
+
+func (fake *FakeTaskDelegate) SelectedWorkerArgsForCall(i int) (lager.Logger, string) {
+	fake.selectedWorkerMutex.RLock()
+	defer fake.selectedWorkerMutex.RUnlock()
+	argsForCall := fake.selectedWorkerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeTaskDelegate) Starting(arg1 lager.Logger, arg2 atc.TaskConfig) {
 	fake.startingMutex.Lock()
 	fake.startingArgsForCall = append(fake.startingArgsForCall, struct {
@@ -363,6 +526,38 @@ func (fake *FakeTaskDelegate) StdoutReturnsOnCall(i int, result1 io.Writer) {
 	}{result1}
 }
 
+func (fake *FakeTaskDelegate) WaitingForWorker(arg1 lager.Logger, arg2 atc.Tags) {
+	fake.waitingForWorkerMutex.Lock()
+	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Tags
+	}{arg1, arg2})
+	fake.recordInvocation("WaitingForWorker", []interface{}{arg1, arg2})
+	fake.waitingForWorkerMutex.Unlock()
+	if fake.WaitingForWorkerStub != nil {
+		fake.WaitingForWorkerStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeTaskDelegate) WaitingForWorkerCallCount() int {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	return len(fake.waitingForWorkerArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) WaitingForWorkerCalls(stub func(lager.Logger, atc.Tags)) {
+	fake.waitingForWorkerMutex.Lock()
+	defer fake.waitingForWorkerMutex.Unlock()
+	fake.WaitingForWorkerStub = stub
+}
+
+func (fake *FakeTaskDelegate) WaitingForWorkerArgsForCall(i int) (lager.Logger, atc.Tags) {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	argsForCall := fake.waitingForWorkerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -370,16 +565,26 @@ func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	defer fake.erroredMutex.RUnlock()
 	fake.finishedMutex.RLock()
 	defer fake.finishedMutex.RUnlock()
+	fake.imageCheckedMutex.RLock()
+	defer fake.imageCheckedMutex.RUnlock()
+	fake.imageFetchedMutex.RLock()
+	defer fake.imageFetchedMutex.RUnlock()
 	fake.imageVersionDeterminedMutex.RLock()
 	defer fake.imageVersionDeterminedMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
+	fake.secretAccessedMutex.RLock()
+	defer fake.secretAccessedMutex.RUnlock()
+	fake.selectedWorkerMutex.RLock()
+	defer fake.selectedWorkerMutex.RUnlock()
 	fake.startingMutex.RLock()
 	defer fake.startingMutex.RUnlock()
 	fake.stderrMutex.RLock()
 	defer fake.stderrMutex.RUnlock()
 	fake.stdoutMutex.RLock()
 	defer fake.stdoutMutex.RUnlock()
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -42,6 +42,7 @@ type GetDelegate interface {
 	Starting(lager.Logger)
 	Finished(lager.Logger, ExitStatus, VersionInfo)
 	UpdateVersion(lager.Logger, atc.GetPlan, VersionInfo)
+	ResourceCacheUsed(db.UsedResourceCache) error
 }
 
 // GetStep will fetch a version of a resource on a worker that supports the
@@ -118,7 +119,9 @@ func (step *GetStep) Run(ctx context.Context, state RunState) error {
 
 	step.delegate.Initializing(logger)
 
-	variables := creds.NewVariables(step.secrets, step.metadata.TeamName, step.metadata.PipelineName)
+	variables := creds.NewTrackedVariables(step.secrets, step.metadata.TeamName, step.metadata.PipelineName, func(secretPath string) {
+		step.delegate.SecretAccessed(logger, secretPath)
+	})
 
 	source, err := creds.NewSource(variables, step.plan.Source).Evaluate()
 	if err != nil {
@@ -168,6 +171,12 @@ func (step *GetStep) Run(ctx context.Context, state RunState) error {
 		return err
 	}
 
+	err = step.delegate.ResourceCacheUsed(resourceCache)
+	if err != nil {
+		logger.Error("failed-to-register-resource-cache-use", err)
+		return err
+	}
+
 	resourceInstance := resource.NewResourceInstance(
 		resource.ResourceType(step.plan.Type),
 		version,
@@ -187,6 +196,7 @@ func (step *GetStep) Run(ctx context.Context, state RunState) error {
 		step.strategy,
 	)
 	if err != nil {
+		reportMissingWorker(logger, step.delegate, err)
 		return err
 	}
 
@@ -171,6 +171,29 @@ var _ = Describe("GetStep", func() {
 		Expect(strategy).To(Equal(fakeStrategy))
 	})
 
+	It("reports the secrets it looked up", func() {
+		Expect(fakeDelegate.SecretAccessedCallCount()).To(BeNumerically(">", 0))
+		_, secretPath := fakeDelegate.SecretAccessedArgsForCall(0)
+		Expect(secretPath).To(Equal("source-param"))
+	})
+
+	It("registers the resource cache as used by the build", func() {
+		Expect(fakeDelegate.ResourceCacheUsedCallCount()).To(Equal(1))
+	})
+
+	Context("when registering the resource cache use fails", func() {
+		disaster := errors.New("oh no")
+
+		BeforeEach(func() {
+			fakeDelegate.ResourceCacheUsedReturns(disaster)
+		})
+
+		It("returns the error without choosing a worker", func() {
+			Expect(stepErr).To(Equal(disaster))
+			Expect(fakePool.FindOrChooseWorkerForContainerCallCount()).To(BeZero())
+		})
+	})
+
 	Context("when find or choosing worker succeeds", func() {
 		BeforeEach(func() {
 			fakeWorker.NameReturns("some-worker")
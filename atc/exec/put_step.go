@@ -81,7 +81,9 @@ func (step *PutStep) Run(ctx context.Context, state RunState) error {
 
 	step.delegate.Initializing(logger)
 
-	variables := creds.NewVariables(step.secrets, step.metadata.TeamName, step.metadata.PipelineName)
+	variables := creds.NewTrackedVariables(step.secrets, step.metadata.TeamName, step.metadata.PipelineName, func(secretPath string) {
+		step.delegate.SecretAccessed(logger, secretPath)
+	})
 
 	source, err := creds.NewSource(variables, step.plan.Source).Evaluate()
 	if err != nil {
@@ -148,6 +150,7 @@ func (step *PutStep) Run(ctx context.Context, state RunState) error {
 		step.strategy,
 	)
 	if err != nil {
+		reportMissingWorker(logger, step.delegate, err)
 		return err
 	}
 
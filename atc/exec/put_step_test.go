@@ -295,7 +295,7 @@ var _ = Describe("PutStep", func() {
 				Expect(actualSource).To(Equal(atc.Source{"some": "super-secret-source"}))
 				Expect(actualResourceTypes).To(Equal(interpolatedResourceTypes))
 				Expect(info.Version).To(Equal(atc.Version{"some": "version"}))
-				Expect(info.Metadata).To(Equal([]atc.MetadataField{{"some", "metadata"}}))
+				Expect(info.Metadata).To(Equal([]atc.MetadataField{{Name: "some", Value: "metadata"}}))
 			})
 
 			Context("when the resource is blank", func() {
@@ -8,6 +8,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec/artifact"
+	"github.com/concourse/concourse/atc/worker"
 )
 
 //go:generate counterfeiter . Step
@@ -35,11 +36,15 @@ type Step interface {
 
 type BuildStepDelegate interface {
 	ImageVersionDetermined(db.UsedResourceCache) error
+	ImageChecked(lager.Logger, worker.ImageResource, atc.Version)
+	ImageFetched(lager.Logger, worker.ImageResource, atc.Version)
 
 	Stdout() io.Writer
 	Stderr() io.Writer
 
 	Errored(lager.Logger, string)
+	SecretAccessed(logger lager.Logger, secretPath string)
+	WaitingForWorker(lager.Logger, atc.Tags)
 }
 
 //go:generate counterfeiter . RunState
@@ -66,5 +71,13 @@ type ExitStatus int
 // special privileges (i.e. as an administrator user).
 type Privileged bool
 
+// reportMissingWorker tells the delegate when a worker could not be chosen
+// because none satisfy the step's worker spec.
+func reportMissingWorker(logger lager.Logger, delegate BuildStepDelegate, err error) {
+	if noWorkers, ok := err.(worker.NoCompatibleWorkersError); ok {
+		delegate.WaitingForWorker(logger, noWorkers.Spec.Tags)
+	}
+}
+
 type InputHandler func(io.ReadCloser) error
 type OutputHandler func(io.Writer) error
@@ -61,6 +61,7 @@ type TaskDelegate interface {
 	BuildStepDelegate
 
 	Initializing(lager.Logger, atc.TaskConfig)
+	SelectedWorker(lager.Logger, string)
 	Starting(lager.Logger, atc.TaskConfig)
 	Finished(lager.Logger, ExitStatus)
 }
@@ -129,7 +130,9 @@ func (step *TaskStep) Run(ctx context.Context, state RunState) error {
 		"job-id":    step.metadata.JobID,
 	})
 
-	variables := creds.NewVariables(step.secrets, step.metadata.TeamName, step.metadata.PipelineName)
+	variables := creds.NewTrackedVariables(step.secrets, step.metadata.TeamName, step.metadata.PipelineName, func(secretPath string) {
+		step.delegate.SecretAccessed(logger, secretPath)
+	})
 
 	resourceTypes, err := creds.NewVersionedResourceTypes(variables, step.plan.VersionedResourceTypes).Evaluate()
 	if err != nil {
@@ -223,6 +226,7 @@ func (step *TaskStep) Run(ctx context.Context, state RunState) error {
 			step.strategy,
 		)
 		if err != nil {
+			reportMissingWorker(logger, step.delegate, err)
 			return err
 		}
 
@@ -264,6 +268,8 @@ func (step *TaskStep) Run(ctx context.Context, state RunState) error {
 		break
 	}
 
+	step.delegate.SelectedWorker(logger, chosenWorker.Name())
+
 	container, err := chosenWorker.FindOrCreateContainer(
 		ctx,
 		logger,
@@ -276,6 +276,12 @@ var _ = Describe("TaskStep", func() {
 					})
 				})
 
+				It("tells the delegate which worker was selected", func() {
+					Expect(fakeDelegate.SelectedWorkerCallCount()).To(Equal(1))
+					_, workerName := fakeDelegate.SelectedWorkerArgsForCall(0)
+					Expect(workerName).To(Equal("some-worker"))
+				})
+
 				It("finds or creates a container", func() {
 					Expect(fakeWorker.FindOrCreateContainerCallCount()).To(Equal(1))
 					_, cancel, delegate, owner, createdMetadata, containerSpec, actualResourceTypes := fakeWorker.FindOrCreateContainerArgsForCall(0)
@@ -1751,6 +1757,32 @@ var _ = Describe("TaskStep", func() {
 			It("is not successful", func() {
 				Expect(taskStep.Succeeded()).To(BeFalse())
 			})
+
+			It("does not report that the build is waiting for a worker", func() {
+				Expect(fakeDelegate.WaitingForWorkerCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when no worker satisfies the task's tags", func() {
+			var noWorkers worker.NoCompatibleWorkersError
+
+			BeforeEach(func() {
+				noWorkers = worker.NoCompatibleWorkersError{
+					Spec: worker.WorkerSpec{Tags: atc.Tags{"some-tag"}},
+				}
+
+				fakePool.FindOrChooseWorkerForContainerReturns(nil, noWorkers)
+			})
+
+			It("returns the error", func() {
+				Expect(stepErr).To(Equal(noWorkers))
+			})
+
+			It("reports the unsatisfiable tags to the delegate", func() {
+				Expect(fakeDelegate.WaitingForWorkerCallCount()).To(Equal(1))
+				_, tags := fakeDelegate.WaitingForWorkerArgsForCall(0)
+				Expect(tags).To(Equal(atc.Tags{"some-tag"}))
+			})
 		})
 
 		Context("when missing the platform", func() {
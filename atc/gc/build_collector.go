@@ -12,6 +12,7 @@ type buildCollector struct {
 
 type buildFactory interface {
 	MarkNonInterceptibleBuilds() error
+	DeleteExpiredOneOffBuilds() error
 }
 
 func NewBuildCollector(buildFactory buildFactory) *buildCollector {
@@ -26,5 +27,17 @@ func (b *buildCollector) Run(ctx context.Context) error {
 	logger.Debug("start")
 	defer logger.Debug("done")
 
-	return b.buildFactory.MarkNonInterceptibleBuilds()
+	err := b.buildFactory.MarkNonInterceptibleBuilds()
+	if err != nil {
+		logger.Error("failed-to-mark-non-interceptible-builds", err)
+		return err
+	}
+
+	err = b.buildFactory.DeleteExpiredOneOffBuilds()
+	if err != nil {
+		logger.Error("failed-to-delete-expired-builds", err)
+		return err
+	}
+
+	return nil
 }
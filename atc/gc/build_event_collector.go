@@ -0,0 +1,66 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type buildEventCollector struct {
+	pipelineFactory  db.PipelineFactory
+	defaultRetention time.Duration
+}
+
+// NewBuildEventCollector returns a Collector which trims the log events of
+// each pipeline's builds once they are older than the pipeline's event
+// retention, or the given default if the pipeline does not set one. A
+// retention of 0 keeps events forever.
+func NewBuildEventCollector(
+	pipelineFactory db.PipelineFactory,
+	defaultRetention time.Duration,
+) Collector {
+	return &buildEventCollector{
+		pipelineFactory:  pipelineFactory,
+		defaultRetention: defaultRetention,
+	}
+}
+
+func (bc *buildEventCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("build-event-reaper")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	pipelines, err := bc.pipelineFactory.AllPipelines()
+	if err != nil {
+		logger.Error("failed-to-get-pipelines", err)
+		return err
+	}
+
+	for _, pipeline := range pipelines {
+		retention := pipeline.EventRetention()
+		if retention == 0 {
+			retention = bc.defaultRetention
+		}
+
+		if retention == 0 {
+			continue
+		}
+
+		trimmed, err := pipeline.TrimEvents(time.Now().Add(-retention))
+		if err != nil {
+			logger.Error("failed-to-trim-events", err, lager.Data{"pipeline": pipeline.Name()})
+			return err
+		}
+
+		if trimmed > 0 {
+			logger.Debug("trimmed-events", lager.Data{"pipeline": pipeline.Name(), "count": trimmed})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/concourse/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildEventCollector", func() {
+	var (
+		buildEventCollector Collector
+		fakePipelineFactory *dbfakes.FakePipelineFactory
+		fakePipeline        *dbfakes.FakePipeline
+		defaultRetention    time.Duration
+
+		err error
+	)
+
+	BeforeEach(func() {
+		fakePipelineFactory = new(dbfakes.FakePipelineFactory)
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipelineFactory.AllPipelinesReturns([]db.Pipeline{fakePipeline}, nil)
+
+		defaultRetention = 0
+	})
+
+	JustBeforeEach(func() {
+		buildEventCollector = NewBuildEventCollector(fakePipelineFactory, defaultRetention)
+		err = buildEventCollector.Run(context.TODO())
+	})
+
+	Context("when neither the pipeline nor the default sets a retention", func() {
+		It("does not trim any events", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakePipeline.TrimEventsCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when only the default sets a retention", func() {
+		BeforeEach(func() {
+			defaultRetention = 24 * time.Hour
+		})
+
+		It("trims events older than the default", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakePipeline.TrimEventsCallCount()).To(Equal(1))
+			Expect(fakePipeline.TrimEventsArgsForCall(0)).To(BeTemporally("~", time.Now().Add(-24*time.Hour), time.Minute))
+		})
+	})
+
+	Context("when the pipeline sets a retention", func() {
+		BeforeEach(func() {
+			defaultRetention = 24 * time.Hour
+			fakePipeline.EventRetentionReturns(time.Hour)
+		})
+
+		It("trims events older than the pipeline's retention", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakePipeline.TrimEventsCallCount()).To(Equal(1))
+			Expect(fakePipeline.TrimEventsArgsForCall(0)).To(BeTemporally("~", time.Now().Add(-time.Hour), time.Minute))
+		})
+
+		Context("when trimming fails", func() {
+			var disaster = errors.New("nope")
+
+			BeforeEach(func() {
+				fakePipeline.TrimEventsReturns(0, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(Equal(disaster))
+			})
+		})
+	})
+
+	Context("when getting the pipelines fails", func() {
+		var disaster = errors.New("nope")
+
+		BeforeEach(func() {
+			fakePipelineFactory.AllPipelinesReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(Equal(disaster))
+		})
+	})
+})
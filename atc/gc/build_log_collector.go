@@ -118,6 +118,11 @@ func (br *buildLogCollector) Run(ctx context.Context) error {
 				firstBuildToRetain = buildsToRetain[len(buildsToRetain)-1].ID()
 			}
 
+			reapPolicy := db.ReapPolicy{
+				MinAge:         time.Duration(logRetention.Days) * 24 * time.Hour,
+				RequireDrained: br.drainerConfigured,
+			}
+
 			buildIDsToDelete := []int{}
 			for i := len(buildsToConsiderDeleting) - 1; i >= 0; i-- {
 				build := buildsToConsiderDeleting[i]
@@ -126,16 +131,8 @@ func (br *buildLogCollector) Run(ctx context.Context) error {
 					break
 				}
 
-				if br.drainerConfigured {
-					if !build.IsDrained() {
-						continue
-					}
-				}
-
-				if logRetention.Days > 0 {
-					if build.EndTime().AddDate(0, 0, logRetention.Days).After(time.Now()) {
-						continue
-					}
+				if !build.Reapable(reapPolicy) {
+					continue
 				}
 
 				buildIDsToDelete = append(buildIDsToDelete, build.ID())
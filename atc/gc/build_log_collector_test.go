@@ -763,6 +763,7 @@ func sb(id int) db.Build {
 	build := new(dbfakes.FakeBuild)
 	build.IDReturns(id)
 	build.IsRunningReturns(false)
+	build.ReapableReturns(true)
 	return build
 }
 
@@ -771,6 +772,9 @@ func sbTime(id int, end time.Time) db.Build {
 	build.IDReturns(id)
 	build.EndTimeReturns(end)
 	build.IsRunningReturns(false)
+	build.ReapableStub = func(policy db.ReapPolicy) bool {
+		return !end.Add(policy.MinAge).After(time.Now())
+	}
 	return build
 }
 
@@ -779,6 +783,9 @@ func sbDrained(id int, drained bool) db.Build {
 	build.IsDrainedReturns(drained)
 	build.IDReturns(id)
 	build.IsRunningReturns(false)
+	build.ReapableStub = func(policy db.ReapPolicy) bool {
+		return drained || !policy.RequireDrained
+	}
 	return build
 }
 
@@ -133,7 +133,7 @@ var _ = BeforeEach(func() {
 
 	logger = lagertest.NewTestLogger("gc-test")
 
-	resourceCacheLifecycle = db.NewResourceCacheLifecycle(dbConn)
+	resourceCacheLifecycle = db.NewResourceCacheLifecycle(dbConn, 5*time.Minute)
 	resourceCacheFactory = db.NewResourceCacheFactory(dbConn, lockFactory)
 	resourceConfigFactory = db.NewResourceConfigFactory(dbConn, lockFactory)
 })
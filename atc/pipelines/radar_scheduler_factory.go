@@ -30,6 +30,7 @@ type radarSchedulerFactory struct {
 	resourceConfigFactory        db.ResourceConfigFactory
 	resourceTypeCheckingInterval time.Duration
 	resourceCheckingInterval     time.Duration
+	checkBackoff                 db.CheckBackoff
 	strategy                     worker.ContainerPlacementStrategy
 }
 
@@ -39,6 +40,7 @@ func NewRadarSchedulerFactory(
 	resourceConfigFactory db.ResourceConfigFactory,
 	resourceTypeCheckingInterval time.Duration,
 	resourceCheckingInterval time.Duration,
+	checkBackoff db.CheckBackoff,
 	strategy worker.ContainerPlacementStrategy,
 ) RadarSchedulerFactory {
 	return &radarSchedulerFactory{
@@ -47,6 +49,7 @@ func NewRadarSchedulerFactory(
 		resourceConfigFactory:        resourceConfigFactory,
 		resourceTypeCheckingInterval: resourceTypeCheckingInterval,
 		resourceCheckingInterval:     resourceCheckingInterval,
+		checkBackoff:                 checkBackoff,
 		strategy:                     strategy,
 	}
 }
@@ -58,6 +61,7 @@ func (rsf *radarSchedulerFactory) BuildScanRunnerFactory(dbPipeline db.Pipeline,
 		rsf.resourceConfigFactory,
 		rsf.resourceTypeCheckingInterval,
 		rsf.resourceCheckingInterval,
+		rsf.checkBackoff,
 		dbPipeline,
 		clock.NewClock(),
 		externalURL,
@@ -50,6 +50,20 @@ type FakeScanner struct {
 	scanFromVersionReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ScanWithSourceOverrideStub        func(lager.Logger, int, atc.Version, atc.Source) error
+	scanWithSourceOverrideMutex       sync.RWMutex
+	scanWithSourceOverrideArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 atc.Version
+		arg4 atc.Source
+	}
+	scanWithSourceOverrideReturns struct {
+		result1 error
+	}
+	scanWithSourceOverrideReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -241,6 +255,69 @@ func (fake *FakeScanner) ScanFromVersionReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeScanner) ScanWithSourceOverride(arg1 lager.Logger, arg2 int, arg3 atc.Version, arg4 atc.Source) error {
+	fake.scanWithSourceOverrideMutex.Lock()
+	ret, specificReturn := fake.scanWithSourceOverrideReturnsOnCall[len(fake.scanWithSourceOverrideArgsForCall)]
+	fake.scanWithSourceOverrideArgsForCall = append(fake.scanWithSourceOverrideArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 atc.Version
+		arg4 atc.Source
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("ScanWithSourceOverride", []interface{}{arg1, arg2, arg3, arg4})
+	fake.scanWithSourceOverrideMutex.Unlock()
+	if fake.ScanWithSourceOverrideStub != nil {
+		return fake.ScanWithSourceOverrideStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.scanWithSourceOverrideReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeScanner) ScanWithSourceOverrideCallCount() int {
+	fake.scanWithSourceOverrideMutex.RLock()
+	defer fake.scanWithSourceOverrideMutex.RUnlock()
+	return len(fake.scanWithSourceOverrideArgsForCall)
+}
+
+func (fake *FakeScanner) ScanWithSourceOverrideCalls(stub func(lager.Logger, int, atc.Version, atc.Source) error) {
+	fake.scanWithSourceOverrideMutex.Lock()
+	defer fake.scanWithSourceOverrideMutex.Unlock()
+	fake.ScanWithSourceOverrideStub = stub
+}
+
+func (fake *FakeScanner) ScanWithSourceOverrideArgsForCall(i int) (lager.Logger, int, atc.Version, atc.Source) {
+	fake.scanWithSourceOverrideMutex.RLock()
+	defer fake.scanWithSourceOverrideMutex.RUnlock()
+	argsForCall := fake.scanWithSourceOverrideArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeScanner) ScanWithSourceOverrideReturns(result1 error) {
+	fake.scanWithSourceOverrideMutex.Lock()
+	defer fake.scanWithSourceOverrideMutex.Unlock()
+	fake.ScanWithSourceOverrideStub = nil
+	fake.scanWithSourceOverrideReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeScanner) ScanWithSourceOverrideReturnsOnCall(i int, result1 error) {
+	fake.scanWithSourceOverrideMutex.Lock()
+	defer fake.scanWithSourceOverrideMutex.Unlock()
+	fake.ScanWithSourceOverrideStub = nil
+	if fake.scanWithSourceOverrideReturnsOnCall == nil {
+		fake.scanWithSourceOverrideReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.scanWithSourceOverrideReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeScanner) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -250,6 +327,8 @@ func (fake *FakeScanner) Invocations() map[string][][]interface{} {
 	defer fake.scanMutex.RUnlock()
 	fake.scanFromVersionMutex.RLock()
 	defer fake.scanFromVersionMutex.RUnlock()
+	fake.scanWithSourceOverrideMutex.RLock()
+	defer fake.scanWithSourceOverrideMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -26,6 +26,7 @@ type resourceScanner struct {
 	resourceFactory       resource.ResourceFactory
 	resourceConfigFactory db.ResourceConfigFactory
 	defaultInterval       time.Duration
+	checkBackoff          db.CheckBackoff
 	dbPipeline            db.Pipeline
 	externalURL           string
 	variables             vars.Variables
@@ -38,6 +39,7 @@ func NewResourceScanner(
 	resourceFactory resource.ResourceFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
 	defaultInterval time.Duration,
+	checkBackoff db.CheckBackoff,
 	dbPipeline db.Pipeline,
 	externalURL string,
 	variables vars.Variables,
@@ -49,6 +51,7 @@ func NewResourceScanner(
 		resourceFactory:       resourceFactory,
 		resourceConfigFactory: resourceConfigFactory,
 		defaultInterval:       defaultInterval,
+		checkBackoff:          checkBackoff,
 		dbPipeline:            dbPipeline,
 		externalURL:           externalURL,
 		variables:             variables,
@@ -61,7 +64,7 @@ var ErrResourceTypeNotFound = errors.New("resource type not found")
 var ErrResourceTypeCheckError = errors.New("resource type failed to check")
 
 func (scanner *resourceScanner) Run(logger lager.Logger, resourceID int) (time.Duration, error) {
-	interval, err := scanner.scan(logger.Session("tick"), resourceID, nil, false, false)
+	interval, err := scanner.scan(logger.Session("tick"), resourceID, nil, nil, false, false)
 
 	err = swallowErrResourceScriptFailed(err)
 
@@ -69,20 +72,30 @@ func (scanner *resourceScanner) Run(logger lager.Logger, resourceID int) (time.D
 }
 
 func (scanner *resourceScanner) ScanFromVersion(logger lager.Logger, resourceID int, fromVersion atc.Version) error {
-	_, err := scanner.scan(logger, resourceID, fromVersion, true, true)
+	_, err := scanner.scan(logger, resourceID, fromVersion, nil, true, true)
+
+	return err
+}
+
+// ScanWithSourceOverride checks the resource with the given source keys
+// merged over its configured source. Nothing about the check is recorded: the
+// override is not saved to the resource, and neither are the versions found,
+// the check error or the check times of the resource's config.
+func (scanner *resourceScanner) ScanWithSourceOverride(logger lager.Logger, resourceID int, fromVersion atc.Version, sourceOverride atc.Source) error {
+	_, err := scanner.scan(logger, resourceID, fromVersion, sourceOverride, true, true)
 
 	return err
 }
 
 func (scanner *resourceScanner) Scan(logger lager.Logger, resourceID int) error {
-	_, err := scanner.scan(logger, resourceID, nil, true, false)
+	_, err := scanner.scan(logger, resourceID, nil, nil, true, false)
 
 	err = swallowErrResourceScriptFailed(err)
 
 	return err
 }
 
-func (scanner *resourceScanner) scan(logger lager.Logger, resourceID int, fromVersion atc.Version, mustComplete bool, saveGiven bool) (time.Duration, error) {
+func (scanner *resourceScanner) scan(logger lager.Logger, resourceID int, fromVersion atc.Version, sourceOverride atc.Source, mustComplete bool, saveGiven bool) (time.Duration, error) {
 	savedResource, found, err := scanner.dbPipeline.ResourceByID(resourceID)
 	if err != nil {
 		return 0, err
@@ -172,6 +185,15 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceID int, fromVe
 		return 0, err
 	}
 
+	checkSource := source
+	if sourceOverride != nil {
+		checkSource, err = creds.NewSource(scanner.variables, mergeSource(savedResource.Source(), sourceOverride)).Evaluate()
+		if err != nil {
+			logger.Error("failed-to-evaluate-source-override", err)
+			return 0, err
+		}
+	}
+
 	resourceConfigScope, err := savedResource.SetResourceConfig(
 		source,
 		versionedResourceTypes,
@@ -182,8 +204,12 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceID int, fromVe
 		return 0, err
 	}
 
-	// Clear out check error on the resource
-	scanner.setResourceCheckError(logger, savedResource, nil)
+	if sourceOverride != nil {
+		resourceConfigScope = unrecordedScope{resourceConfigScope}
+	} else {
+		// Clear out check error on the resource
+		scanner.setResourceCheckError(logger, savedResource, nil)
+	}
 
 	currentVersion := savedResource.CurrentPinnedVersion()
 	if currentVersion != nil {
@@ -191,7 +217,7 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceID int, fromVe
 
 		if err != nil {
 			logger.Error("failed-to-find-pinned-version-on-resource", err, lager.Data{"pinned-version": currentVersion})
-			chkErr := resourceConfigScope.SetCheckError(err)
+			chkErr := resourceConfigScope.SetCheckError(err, scanner.checkBackoff)
 			if chkErr != nil {
 				logger.Error("failed-to-set-check-error-on-resource-config", chkErr)
 			}
@@ -258,7 +284,7 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceID int, fromVe
 		resourceConfigScope,
 		fromVersion,
 		versionedResourceTypes,
-		source,
+		checkSource,
 		saveGiven,
 		timeout,
 	)
@@ -332,7 +358,7 @@ func (scanner *resourceScanner) check(
 	)
 	if err != nil {
 		logger.Error("failed-to-choose-a-worker", err)
-		chkErr := resourceConfigScope.SetCheckError(err)
+		chkErr := resourceConfigScope.SetCheckError(err, scanner.checkBackoff)
 		if chkErr != nil {
 			logger.Error("failed-to-set-check-error-on-resource-config", chkErr)
 		}
@@ -358,7 +384,7 @@ func (scanner *resourceScanner) check(
 			return nil
 		}
 		logger.Error("failed-to-create-or-find-container", err)
-		chkErr := resourceConfigScope.SetCheckError(err)
+		chkErr := resourceConfigScope.SetCheckError(err, scanner.checkBackoff)
 		if chkErr != nil {
 			logger.Error("failed-to-set-check-error-on-resource-config", chkErr)
 		}
@@ -378,7 +404,7 @@ func (scanner *resourceScanner) check(
 		err = fmt.Errorf("Timed out after %v while checking for new versions - perhaps increase your resource check timeout?", timeout)
 	}
 
-	resourceConfigScope.SetCheckError(err)
+	resourceConfigScope.SetCheckError(err, scanner.checkBackoff)
 	metric.ResourceCheck{
 		PipelineName: scanner.dbPipeline.Name(),
 		ResourceName: savedResource.Name(),
@@ -39,6 +39,7 @@ var _ = Describe("ResourceScanner", func() {
 		fakeDBPipeline            *dbfakes.FakePipeline
 		fakeClock                 *fakeclock.FakeClock
 		interval                  time.Duration
+		checkBackoff              db.CheckBackoff
 		variables                 vars.Variables
 
 		fakeResourceType          *dbfakes.FakeResourceType
@@ -60,6 +61,7 @@ var _ = Describe("ResourceScanner", func() {
 		scanLogger = lagertest.NewTestLogger("test")
 		fakeLock = &lockfakes.FakeLock{}
 		interval = 1 * time.Minute
+		checkBackoff = db.CheckBackoff{Min: 10 * time.Second, Max: time.Hour}
 		GlobalResourceCheckTimeout = 1 * time.Hour
 		variables = vars.StaticVariables{
 			"source-params": "some-secret-sauce",
@@ -128,6 +130,7 @@ var _ = Describe("ResourceScanner", func() {
 			fakeResourceFactory,
 			fakeResourceConfigFactory,
 			interval,
+			checkBackoff,
 			fakeDBPipeline,
 			"https://www.example.com",
 			variables,
@@ -732,7 +735,8 @@ var _ = Describe("ResourceScanner", func() {
 					Expect(scanErr).To(HaveOccurred())
 					Expect(fakeResourceConfigScope.SetCheckErrorCallCount()).To(Equal(1))
 
-					resourceErr := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
+					resourceErr, backoff := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
+					Expect(backoff).To(Equal(checkBackoff))
 					Expect(resourceErr).To(MatchError("catastrophe"))
 				})
 			})
@@ -746,7 +750,7 @@ var _ = Describe("ResourceScanner", func() {
 					Expect(scanErr).To(HaveOccurred())
 					Expect(fakeResourceConfigScope.SetCheckErrorCallCount()).To(Equal(1))
 
-					resourceErr := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
+					resourceErr, _ := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
 					Expect(resourceErr).To(MatchError("catastrophe"))
 				})
 			})
@@ -855,7 +859,7 @@ var _ = Describe("ResourceScanner", func() {
 					It("sets the check error on the resource config", func() {
 						Expect(fakeResourceConfigScope.SetCheckErrorCallCount()).To(Equal(1))
 
-						err := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
+						err, _ := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
 						Expect(err).To(Equal(errors.New("ah")))
 					})
 				})
@@ -864,7 +868,7 @@ var _ = Describe("ResourceScanner", func() {
 			It("clears the resource's check error", func() {
 				Expect(fakeResourceConfigScope.SetCheckErrorCallCount()).To(Equal(1))
 
-				err := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
+				err, _ := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
 				Expect(err).To(BeNil())
 			})
 
@@ -1006,7 +1010,7 @@ var _ = Describe("ResourceScanner", func() {
 				It("sets the resource's check error", func() {
 					Expect(fakeResourceConfigScope.SetCheckErrorCallCount()).To(Equal(1))
 
-					err := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
+					err, _ := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
 					Expect(err).To(Equal(disaster))
 				})
 			})
@@ -1025,7 +1029,7 @@ var _ = Describe("ResourceScanner", func() {
 				It("sets the resource's check error", func() {
 					Expect(fakeResourceConfigScope.SetCheckErrorCallCount()).To(Equal(1))
 
-					err := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
+					err, _ := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
 					Expect(err).To(Equal(scriptFail))
 				})
 			})
@@ -1119,4 +1123,88 @@ var _ = Describe("ResourceScanner", func() {
 			})
 		})
 	})
+
+	Describe("ScanWithSourceOverride", func() {
+		var (
+			fakeResource   *rfakes.FakeResource
+			sourceOverride atc.Source
+
+			scanErr error
+		)
+
+		BeforeEach(func() {
+			fakeWorker.NameReturns("some-worker")
+			fakePool.FindOrChooseWorkerForContainerReturns(fakeWorker, nil)
+
+			fakeContainer.HandleReturns("some-handle")
+			fakeWorker.FindOrCreateContainerReturns(fakeContainer, nil)
+
+			fakeResource = new(rfakes.FakeResource)
+			fakeResourceFactory.NewResourceForContainerReturns(fakeResource)
+
+			fakeDBResource.SourceReturns(atc.Source{
+				"uri":      "((source-params))",
+				"password": "old-password",
+			})
+
+			sourceOverride = atc.Source{"password": "((source-params))"}
+
+			fakeResourceConfigScope.AcquireResourceCheckingLockReturns(fakeLock, true, nil)
+			fakeResourceConfigScope.UpdateLastCheckStartTimeReturns(true, nil)
+		})
+
+		JustBeforeEach(func() {
+			scanErr = scanner.ScanWithSourceOverride(lagertest.NewTestLogger("test"), 39, nil, sourceOverride)
+		})
+
+		It("succeeds", func() {
+			Expect(scanErr).NotTo(HaveOccurred())
+		})
+
+		It("checks with the override merged over the evaluated source", func() {
+			Expect(fakeResource.CheckCallCount()).To(Equal(1))
+			_, source, _ := fakeResource.CheckArgsForCall(0)
+			Expect(source).To(Equal(atc.Source{
+				"uri":      "some-secret-sauce",
+				"password": "some-secret-sauce",
+			}))
+		})
+
+		It("does not save the override to the resource", func() {
+			Expect(fakeDBResource.SetResourceConfigCallCount()).To(Equal(1))
+			source, _ := fakeDBResource.SetResourceConfigArgsForCall(0)
+			Expect(source).To(Equal(atc.Source{
+				"uri":      "some-secret-sauce",
+				"password": "old-password",
+			}))
+		})
+
+		Context("when the check finds versions", func() {
+			BeforeEach(func() {
+				fakeResource.CheckReturns([]atc.Version{{"version": "injected"}}, nil)
+			})
+
+			It("leaves the stored source's scope untouched", func() {
+				Expect(fakeResourceConfigScope.SaveVersionsCallCount()).To(BeZero())
+				Expect(fakeResourceConfigScope.SetCheckErrorCallCount()).To(BeZero())
+				Expect(fakeResourceConfigScope.UpdateLastCheckStartTimeCallCount()).To(BeZero())
+				Expect(fakeResourceConfigScope.UpdateLastCheckEndTimeCallCount()).To(BeZero())
+			})
+
+			It("does not clear the resource's stored check error", func() {
+				Expect(fakeDBResource.SetCheckSetupErrorCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the check fails", func() {
+			BeforeEach(func() {
+				fakeResource.CheckReturns(nil, errors.New("bad override"))
+			})
+
+			It("does not record the error on the stored source's scope", func() {
+				Expect(scanErr).To(HaveOccurred())
+				Expect(fakeResourceConfigScope.SetCheckErrorCallCount()).To(BeZero())
+			})
+		})
+	})
 })
@@ -21,6 +21,7 @@ type resourceTypeScanner struct {
 	resourceFactory       resource.ResourceFactory
 	resourceConfigFactory db.ResourceConfigFactory
 	defaultInterval       time.Duration
+	checkBackoff          db.CheckBackoff
 	dbPipeline            db.Pipeline
 	externalURL           string
 	variables             vars.Variables
@@ -33,6 +34,7 @@ func NewResourceTypeScanner(
 	resourceFactory resource.ResourceFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
 	defaultInterval time.Duration,
+	checkBackoff db.CheckBackoff,
 	dbPipeline db.Pipeline,
 	externalURL string,
 	variables vars.Variables,
@@ -44,6 +46,7 @@ func NewResourceTypeScanner(
 		resourceFactory:       resourceFactory,
 		resourceConfigFactory: resourceConfigFactory,
 		defaultInterval:       defaultInterval,
+		checkBackoff:          checkBackoff,
 		dbPipeline:            dbPipeline,
 		externalURL:           externalURL,
 		variables:             variables,
@@ -52,20 +55,28 @@ func NewResourceTypeScanner(
 }
 
 func (scanner *resourceTypeScanner) Run(logger lager.Logger, resourceTypeID int) (time.Duration, error) {
-	return scanner.scan(logger.Session("tick"), resourceTypeID, nil, false, false)
+	return scanner.scan(logger.Session("tick"), resourceTypeID, nil, nil, false, false)
 }
 
 func (scanner *resourceTypeScanner) ScanFromVersion(logger lager.Logger, resourceTypeID int, fromVersion atc.Version) error {
-	_, err := scanner.scan(logger, resourceTypeID, fromVersion, true, true)
+	_, err := scanner.scan(logger, resourceTypeID, fromVersion, nil, true, true)
+	return err
+}
+
+// ScanWithSourceOverride checks the resource type with the given source keys
+// merged over its configured source. Like the resource scanner's variant,
+// nothing about the check is recorded on the resource type or its config.
+func (scanner *resourceTypeScanner) ScanWithSourceOverride(logger lager.Logger, resourceTypeID int, fromVersion atc.Version, sourceOverride atc.Source) error {
+	_, err := scanner.scan(logger, resourceTypeID, fromVersion, sourceOverride, true, true)
 	return err
 }
 
 func (scanner *resourceTypeScanner) Scan(logger lager.Logger, resourceTypeID int) error {
-	_, err := scanner.scan(logger, resourceTypeID, nil, true, false)
+	_, err := scanner.scan(logger, resourceTypeID, nil, nil, true, false)
 	return err
 }
 
-func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeID int, fromVersion atc.Version, mustComplete bool, saveGiven bool) (time.Duration, error) {
+func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeID int, fromVersion atc.Version, sourceOverride atc.Source, mustComplete bool, saveGiven bool) (time.Duration, error) {
 	savedResourceType, found, err := scanner.dbPipeline.ResourceTypeByID(resourceTypeID)
 	if err != nil {
 		logger.Error("failed-to-find-resource-type-in-db", err)
@@ -133,6 +144,15 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeID int
 		return 0, err
 	}
 
+	checkSource := source
+	if sourceOverride != nil {
+		checkSource, err = creds.NewSource(scanner.variables, mergeSource(savedResourceType.Source(), sourceOverride)).Evaluate()
+		if err != nil {
+			logger.Error("failed-to-evaluate-source-override", err)
+			return 0, err
+		}
+	}
+
 	resourceConfigScope, err := savedResourceType.SetResourceConfig(
 		source,
 		versionedResourceTypes.Without(savedResourceType.Name()),
@@ -143,8 +163,12 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeID int
 		return 0, err
 	}
 
-	// Clear out the check error on the resource type
-	scanner.setCheckError(logger, savedResourceType, err)
+	if sourceOverride != nil {
+		resourceConfigScope = unrecordedScope{resourceConfigScope}
+	} else {
+		// Clear out the check error on the resource type
+		scanner.setCheckError(logger, savedResourceType, err)
+	}
 
 	reattempt := true
 	for reattempt {
@@ -212,7 +236,7 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeID int
 		resourceConfigScope,
 		fromVersion,
 		versionedResourceTypes,
-		source,
+		checkSource,
 		saveGiven,
 	)
 }
@@ -266,7 +290,7 @@ func (scanner *resourceTypeScanner) check(
 		scanner.strategy,
 	)
 	if err != nil {
-		chkErr := resourceConfigScope.SetCheckError(err)
+		chkErr := resourceConfigScope.SetCheckError(err, scanner.checkBackoff)
 		if chkErr != nil {
 			logger.Error("failed-to-set-check-error-on-resource-config", chkErr)
 		}
@@ -286,7 +310,7 @@ func (scanner *resourceTypeScanner) check(
 		versionedResourceTypes.Without(savedResourceType.Name()),
 	)
 	if err != nil {
-		chkErr := resourceConfigScope.SetCheckError(err)
+		chkErr := resourceConfigScope.SetCheckError(err, scanner.checkBackoff)
 		if chkErr != nil {
 			logger.Error("failed-to-set-check-error-on-resource-config", chkErr)
 		}
@@ -296,7 +320,7 @@ func (scanner *resourceTypeScanner) check(
 
 	res := scanner.resourceFactory.NewResourceForContainer(container)
 	newVersions, err := res.Check(context.TODO(), source, fromVersion)
-	resourceConfigScope.SetCheckError(err)
+	resourceConfigScope.SetCheckError(err, scanner.checkBackoff)
 	if err != nil {
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
 			logger.Info("check-failed", lager.Data{"exit-status": rErr.ExitStatus})
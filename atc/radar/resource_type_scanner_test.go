@@ -39,6 +39,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 		fakeResourceConfigScope   *dbfakes.FakeResourceConfigScope
 		fakeClock                 *fakeclock.FakeClock
 		interval                  time.Duration
+		checkBackoff              db.CheckBackoff
 		variables                 vars.Variables
 		metadata                  db.ContainerMetadata
 
@@ -54,6 +55,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 	BeforeEach(func() {
 		fakeLock = &lockfakes.FakeLock{}
 		interval = 1 * time.Minute
+		checkBackoff = db.CheckBackoff{Min: 10 * time.Second, Max: time.Hour}
 		variables = vars.StaticVariables{
 			"source-params": "some-secret-sauce",
 		}
@@ -107,6 +109,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 			fakeResourceFactory,
 			fakeResourceConfigFactory,
 			interval,
+			checkBackoff,
 			fakeDBPipeline,
 			"https://www.example.com",
 			variables,
@@ -402,7 +405,8 @@ var _ = Describe("ResourceTypeScanner", func() {
 					It("sets the resource's check error", func() {
 						Expect(fakeResourceConfigScope.SetCheckErrorCallCount()).To(Equal(1))
 
-						err := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
+						err, backoff := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
+						Expect(backoff).To(Equal(checkBackoff))
 						Expect(err).To(Equal(disaster))
 					})
 				})
@@ -653,7 +657,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 					Expect(runErr).To(HaveOccurred())
 					Expect(fakeResourceConfigScope.SetCheckErrorCallCount()).To(Equal(1))
 
-					chkErr := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
+					chkErr, _ := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
 					Expect(chkErr).To(MatchError("catastrophe"))
 				})
 			})
@@ -667,7 +671,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 					Expect(runErr).To(HaveOccurred())
 					Expect(fakeResourceConfigScope.SetCheckErrorCallCount()).To(Equal(1))
 
-					chkErr := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
+					chkErr, _ := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
 					Expect(chkErr).To(MatchError("catastrophe"))
 				})
 			})
@@ -821,7 +825,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 			It("clears the resource's check error", func() {
 				Expect(fakeResourceConfigScope.SetCheckErrorCallCount()).To(Equal(1))
 
-				err := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
+				err, _ := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
 				Expect(err).To(BeNil())
 			})
 
@@ -32,6 +32,7 @@ func NewScanRunnerFactory(
 	resourceConfigFactory db.ResourceConfigFactory,
 	resourceTypeCheckingInterval time.Duration,
 	resourceCheckingInterval time.Duration,
+	checkBackoff db.CheckBackoff,
 	dbPipeline db.Pipeline,
 	clock clock.Clock,
 	externalURL string,
@@ -45,6 +46,7 @@ func NewScanRunnerFactory(
 		resourceFactory,
 		resourceConfigFactory,
 		resourceTypeCheckingInterval,
+		checkBackoff,
 		dbPipeline,
 		externalURL,
 		variables,
@@ -57,6 +59,7 @@ func NewScanRunnerFactory(
 		resourceFactory,
 		resourceConfigFactory,
 		resourceCheckingInterval,
+		checkBackoff,
 		dbPipeline,
 		externalURL,
 		variables,
@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
 )
 
 //go:generate counterfeiter . Scanner
@@ -13,4 +14,36 @@ type Scanner interface {
 	Run(lager.Logger, int) (time.Duration, error)
 	Scan(lager.Logger, int) error
 	ScanFromVersion(lager.Logger, int, atc.Version) error
+	ScanWithSourceOverride(lager.Logger, int, atc.Version, atc.Source) error
 }
+
+// mergeSource returns a copy of source with the keys of override replacing
+// its own.
+func mergeSource(source atc.Source, override atc.Source) atc.Source {
+	merged := atc.Source{}
+	for k, v := range source {
+		merged[k] = v
+	}
+
+	for k, v := range override {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+// unrecordedScope is the resource config scope of a check run with a source
+// override. The override's versions and errors do not belong to the stored
+// source, so nothing about the check is recorded on the scope.
+type unrecordedScope struct {
+	db.ResourceConfigScope
+}
+
+func (unrecordedScope) SaveVersions([]atc.Version) error           { return nil }
+func (unrecordedScope) SetCheckError(error, db.CheckBackoff) error { return nil }
+
+func (unrecordedScope) UpdateLastCheckStartTime(time.Duration, bool) (bool, error) {
+	return true, nil
+}
+
+func (unrecordedScope) UpdateLastCheckEndTime() (bool, error) { return true, nil }
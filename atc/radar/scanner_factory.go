@@ -25,6 +25,7 @@ type scannerFactory struct {
 	resourceConfigFactory        db.ResourceConfigFactory
 	resourceTypeCheckingInterval time.Duration
 	resourceCheckingInterval     time.Duration
+	checkBackoff                 db.CheckBackoff
 	externalURL                  string
 	secretManager                creds.Secrets
 	strategy                     worker.ContainerPlacementStrategy
@@ -41,6 +42,7 @@ func NewScannerFactory(
 	resourceConfigFactory db.ResourceConfigFactory,
 	resourceTypeCheckingInterval time.Duration,
 	resourceCheckingInterval time.Duration,
+	checkBackoff db.CheckBackoff,
 	externalURL string,
 	secretManager creds.Secrets,
 	strategy worker.ContainerPlacementStrategy,
@@ -51,6 +53,7 @@ func NewScannerFactory(
 		resourceConfigFactory:        resourceConfigFactory,
 		resourceCheckingInterval:     resourceCheckingInterval,
 		resourceTypeCheckingInterval: resourceTypeCheckingInterval,
+		checkBackoff:                 checkBackoff,
 		externalURL:                  externalURL,
 		secretManager:                secretManager,
 		strategy:                     strategy,
@@ -66,6 +69,7 @@ func (f *scannerFactory) NewResourceScanner(dbPipeline db.Pipeline) Scanner {
 		f.resourceFactory,
 		f.resourceConfigFactory,
 		f.resourceCheckingInterval,
+		f.checkBackoff,
 		dbPipeline,
 		f.externalURL,
 		variables,
@@ -82,6 +86,7 @@ func (f *scannerFactory) NewResourceTypeScanner(dbPipeline db.Pipeline) Scanner
 		f.resourceFactory,
 		f.resourceConfigFactory,
 		f.resourceTypeCheckingInterval,
+		f.checkBackoff,
 		dbPipeline,
 		f.externalURL,
 		variables,
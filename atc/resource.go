@@ -12,9 +12,10 @@ type Resource struct {
 	CheckSetupError string `json:"check_setup_error,omitempty"`
 	CheckError      string `json:"check_error,omitempty"`
 
-	PinnedVersion  Version `json:"pinned_version,omitempty"`
-	PinnedInConfig bool    `json:"pinned_in_config,omitempty"`
-	PinComment     string  `json:"pin_comment,omitempty"`
+	PinnedVersion   Version `json:"pinned_version,omitempty"`
+	PinnedInConfig  bool    `json:"pinned_in_config,omitempty"`
+	PinComment      string  `json:"pin_comment,omitempty"`
+	LastCheckReason string  `json:"last_check_reason,omitempty"`
 }
 
 var EnableGlobalResources bool
@@ -9,6 +9,7 @@ import (
 type MetadataField struct {
 	Name  string `json:"name"`
 	Value string `json:"value"`
+	Type  string `json:"type,omitempty"`
 }
 
 type Source map[string]interface{}
@@ -1,10 +1,60 @@
 package atc
 
 type CheckRequestBody struct {
-	From Version `json:"from"`
+	From   Version `json:"from"`
+	Source Source  `json:"source,omitempty"`
+	Reason string  `json:"reason,omitempty"`
 }
 
 type CheckResponseBody struct {
 	ExitStatus int    `json:"exit_status"`
 	Stderr     string `json:"stderr"`
 }
+
+type CheckErrorCode string
+
+const (
+	CheckErrorCodeResourceTypeNotFound    CheckErrorCode = "resource_type_not_found"
+	CheckErrorCodeResourceTypeCheckFailed CheckErrorCode = "resource_type_check_failed"
+	CheckErrorCodeInternal                CheckErrorCode = "internal_error"
+	CheckErrorCodeResourceNotFound        CheckErrorCode = "resource_not_found"
+	CheckErrorCodeResourceCheckFailed     CheckErrorCode = "resource_check_failed"
+)
+
+type CheckErrorResponseBody struct {
+	Code    CheckErrorCode `json:"code"`
+	Message string         `json:"message"`
+}
+
+type CheckResourcesRequestBody struct {
+	Resources []string `json:"resources"`
+	Reason    string   `json:"reason,omitempty"`
+}
+
+// RecursiveCheckResult is the outcome of one of the checks run for a
+// recursive check of a resource. The results are ordered as the checks ran:
+// the resource's parent resource types first, ending with the resource.
+type RecursiveCheckResult struct {
+	Name         string         `json:"name"`
+	ResourceType bool           `json:"resource_type,omitempty"`
+	Status       int            `json:"status"`
+	ExitStatus   int            `json:"exit_status,omitempty"`
+	Stderr       string         `json:"stderr,omitempty"`
+	Code         CheckErrorCode `json:"code,omitempty"`
+	Message      string         `json:"message,omitempty"`
+}
+
+// CheckResourcesResult is the outcome of checking one of the resources named
+// in a CheckResourcesRequestBody. Status is the HTTP status the check would
+// have had if the resource had been checked on its own. Reason is the reason
+// recorded for the check, if one was given. It is also the body of a
+// successful check of a single resource which was given a reason.
+type CheckResourcesResult struct {
+	Resource   string         `json:"resource"`
+	Status     int            `json:"status"`
+	Reason     string         `json:"reason,omitempty"`
+	ExitStatus int            `json:"exit_status,omitempty"`
+	Stderr     string         `json:"stderr,omitempty"`
+	Code       CheckErrorCode `json:"code,omitempty"`
+	Message    string         `json:"message,omitempty"`
+}
@@ -90,6 +90,13 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return true, nil
 	}
 
+	if nextPendingBuild.IsHeld() {
+		// skip over the held build so that it does not block the builds after
+		// it from being scheduled
+		logger.Debug("skip-held-pending-build")
+		return true, nil
+	}
+
 	reachedMaxInFlight, err := s.maxInFlightUpdater.UpdateMaxInFlightReached(logger, job, nextPendingBuild.ID())
 	if err != nil {
 		return false, err
@@ -111,6 +111,48 @@ var _ = Describe("BuildStarter", func() {
 			})
 		})
 
+		Context("when one pending build is held", func() {
+			var heldBuild *dbfakes.FakeBuild
+
+			BeforeEach(func() {
+				job = new(dbfakes.FakeJob)
+				job.NameReturns("some-job")
+				job.ConfigReturns(atc.JobConfig{Plan: atc.PlanSequence{{Get: "input-1", Resource: "some-resource"}, {Get: "input-2", Resource: "some-resource"}}})
+
+				heldBuild = new(dbfakes.FakeBuild)
+				heldBuild.IDReturns(42)
+				heldBuild.IsHeldReturns(true)
+
+				pendingBuilds = append([]db.Build{heldBuild}, pendingBuilds...)
+				resources = db.Resources{resource}
+			})
+
+			JustBeforeEach(func() {
+				tryStartErr = buildStarter.TryStartPendingBuildsForJob(
+					lagertest.NewTestLogger("test"),
+					job,
+					resources,
+					versionedResourceTypes,
+					pendingBuilds,
+				)
+			})
+
+			It("does not schedule the held build", func() {
+				Expect(heldBuild.ScheduleCallCount()).To(BeZero())
+				Expect(heldBuild.FinishCallCount()).To(BeZero())
+			})
+
+			It("will try to start the next pending build", func() {
+				Expect(fakeUpdater.UpdateMaxInFlightReachedCallCount()).To(Equal(1))
+				_, _, buildID := fakeUpdater.UpdateMaxInFlightReachedArgsForCall(0)
+				Expect(buildID).To(Equal(66))
+			})
+
+			It("returns without error", func() {
+				Expect(tryStartErr).NotTo(HaveOccurred())
+			})
+		})
+
 		Context("when manually triggered", func() {
 			BeforeEach(func() {
 				job = new(dbfakes.FakeJob)
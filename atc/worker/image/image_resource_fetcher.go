@@ -119,6 +119,8 @@ func (i *imageResourceFetcher) Fetch(
 			logger.Error("failed-to-get-latest-image-version", err)
 			return nil, nil, nil, err
 		}
+
+		i.imageFetchingDelegate.ImageChecked(logger, i.imageResource, version)
 	}
 
 	var params atc.Params
@@ -189,6 +191,8 @@ func (i *imageResourceFetcher) Fetch(
 		return nil, nil, nil, ErrImageGetDidNotProduceVolume
 	}
 
+	i.imageFetchingDelegate.ImageFetched(logger, i.imageResource, version)
+
 	reader, err := versionedSource.StreamOut(ImageMetadataFile)
 	if err != nil {
 		return nil, nil, nil, err
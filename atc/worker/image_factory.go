@@ -47,6 +47,9 @@ type ImageFetchingDelegate interface {
 	Stdout() io.Writer
 	Stderr() io.Writer
 	ImageVersionDetermined(db.UsedResourceCache) error
+
+	ImageChecked(lager.Logger, ImageResource, atc.Version)
+	ImageFetched(lager.Logger, ImageResource, atc.Version)
 }
 
 type ImageMetadata struct {
@@ -59,3 +62,5 @@ type NoopImageFetchingDelegate struct{}
 func (NoopImageFetchingDelegate) Stdout() io.Writer                                 { return ioutil.Discard }
 func (NoopImageFetchingDelegate) Stderr() io.Writer                                 { return ioutil.Discard }
 func (NoopImageFetchingDelegate) ImageVersionDetermined(db.UsedResourceCache) error { return nil }
+func (NoopImageFetchingDelegate) ImageChecked(lager.Logger, ImageResource, atc.Version) {}
+func (NoopImageFetchingDelegate) ImageFetched(lager.Logger, ImageResource, atc.Version) {}
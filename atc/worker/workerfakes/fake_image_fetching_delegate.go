@@ -5,11 +5,27 @@ import (
 	"io"
 	"sync"
 
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/worker"
 )
 
 type FakeImageFetchingDelegate struct {
+	ImageCheckedStub        func(lager.Logger, worker.ImageResource, atc.Version)
+	imageCheckedMutex       sync.RWMutex
+	imageCheckedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.ImageResource
+		arg3 atc.Version
+	}
+	ImageFetchedStub        func(lager.Logger, worker.ImageResource, atc.Version)
+	imageFetchedMutex       sync.RWMutex
+	imageFetchedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.ImageResource
+		arg3 atc.Version
+	}
 	ImageVersionDeterminedStub        func(db.UsedResourceCache) error
 	imageVersionDeterminedMutex       sync.RWMutex
 	imageVersionDeterminedArgsForCall []struct {
@@ -45,6 +61,72 @@ type FakeImageFetchingDelegate struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeImageFetchingDelegate) ImageChecked(arg1 lager.Logger, arg2 worker.ImageResource, arg3 atc.Version) {
+	fake.imageCheckedMutex.Lock()
+	fake.imageCheckedArgsForCall = append(fake.imageCheckedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.ImageResource
+		arg3 atc.Version
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ImageChecked", []interface{}{arg1, arg2, arg3})
+	fake.imageCheckedMutex.Unlock()
+	if fake.ImageCheckedStub != nil {
+		fake.ImageCheckedStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeImageFetchingDelegate) ImageCheckedCallCount() int {
+	fake.imageCheckedMutex.RLock()
+	defer fake.imageCheckedMutex.RUnlock()
+	return len(fake.imageCheckedArgsForCall)
+}
+
+func (fake *FakeImageFetchingDelegate) ImageCheckedCalls(stub func(lager.Logger, worker.ImageResource, atc.Version)) {
+	fake.imageCheckedMutex.Lock()
+	defer fake.imageCheckedMutex.Unlock()
+	fake.ImageCheckedStub = stub
+}
+
+func (fake *FakeImageFetchingDelegate) ImageCheckedArgsForCall(i int) (lager.Logger, worker.ImageResource, atc.Version) {
+	fake.imageCheckedMutex.RLock()
+	defer fake.imageCheckedMutex.RUnlock()
+	argsForCall := fake.imageCheckedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeImageFetchingDelegate) ImageFetched(arg1 lager.Logger, arg2 worker.ImageResource, arg3 atc.Version) {
+	fake.imageFetchedMutex.Lock()
+	fake.imageFetchedArgsForCall = append(fake.imageFetchedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.ImageResource
+		arg3 atc.Version
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ImageFetched", []interface{}{arg1, arg2, arg3})
+	fake.imageFetchedMutex.Unlock()
+	if fake.ImageFetchedStub != nil {
+		fake.ImageFetchedStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeImageFetchingDelegate) ImageFetchedCallCount() int {
+	fake.imageFetchedMutex.RLock()
+	defer fake.imageFetchedMutex.RUnlock()
+	return len(fake.imageFetchedArgsForCall)
+}
+
+func (fake *FakeImageFetchingDelegate) ImageFetchedCalls(stub func(lager.Logger, worker.ImageResource, atc.Version)) {
+	fake.imageFetchedMutex.Lock()
+	defer fake.imageFetchedMutex.Unlock()
+	fake.ImageFetchedStub = stub
+}
+
+func (fake *FakeImageFetchingDelegate) ImageFetchedArgsForCall(i int) (lager.Logger, worker.ImageResource, atc.Version) {
+	fake.imageFetchedMutex.RLock()
+	defer fake.imageFetchedMutex.RUnlock()
+	argsForCall := fake.imageFetchedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
 func (fake *FakeImageFetchingDelegate) ImageVersionDetermined(arg1 db.UsedResourceCache) error {
 	fake.imageVersionDeterminedMutex.Lock()
 	ret, specificReturn := fake.imageVersionDeterminedReturnsOnCall[len(fake.imageVersionDeterminedArgsForCall)]
@@ -212,6 +294,10 @@ func (fake *FakeImageFetchingDelegate) StdoutReturnsOnCall(i int, result1 io.Wri
 func (fake *FakeImageFetchingDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.imageCheckedMutex.RLock()
+	defer fake.imageCheckedMutex.RUnlock()
+	fake.imageFetchedMutex.RLock()
+	defer fake.imageFetchedMutex.RUnlock()
 	fake.imageVersionDeterminedMutex.RLock()
 	defer fake.imageVersionDeterminedMutex.RUnlock()
 	fake.stderrMutex.RLock()
@@ -219,6 +219,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				// authorized (requested team matches resource team)
 				atc.CheckResource:           authorized(inputHandlers[atc.CheckResource]),
 				atc.CheckResourceType:       authorized(inputHandlers[atc.CheckResourceType]),
+				atc.CheckResources:          authorized(inputHandlers[atc.CheckResources]),
 				atc.CreateJobBuild:          authorized(inputHandlers[atc.CreateJobBuild]),
 				atc.DeletePipeline:          authorized(inputHandlers[atc.DeletePipeline]),
 				atc.DisableResourceVersion:  authorized(inputHandlers[atc.DisableResourceVersion]),
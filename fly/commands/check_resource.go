@@ -1,7 +1,10 @@
 package commands
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
@@ -11,6 +14,7 @@ import (
 type CheckResourceCommand struct {
 	Resource flaghelpers.ResourceFlag `short:"r" long:"resource" required:"true" value-name:"PIPELINE/RESOURCE" description:"Name of a resource to check version for"`
 	Version  *atc.Version             `short:"f" long:"from"                     value-name:"VERSION"           description:"Version of the resource to check from, e.g. ref:abcd or path:thing-1.2.3.tgz"`
+	FromFile atc.PathFlag             `long:"from-file"                          value-name:"PATH"              description:"File containing the version of the resource to check from, as a JSON object"`
 }
 
 func (command *CheckResourceCommand) Execute(args []string) error {
@@ -29,6 +33,17 @@ func (command *CheckResourceCommand) Execute(args []string) error {
 		version = *command.Version
 	}
 
+	if command.FromFile != "" {
+		if command.Version != nil {
+			return errors.New("only one of --from and --from-file may be given")
+		}
+
+		version, err = command.versionFromFile()
+		if err != nil {
+			return err
+		}
+	}
+
 	found, err := target.Team().CheckResource(command.Resource.PipelineName, command.Resource.ResourceName, version)
 	if err != nil {
 		return err
@@ -41,3 +56,18 @@ func (command *CheckResourceCommand) Execute(args []string) error {
 	fmt.Printf("checked '%s'\n", command.Resource.ResourceName)
 	return nil
 }
+
+func (command *CheckResourceCommand) versionFromFile() (atc.Version, error) {
+	versionBytes, err := ioutil.ReadFile(string(command.FromFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var version atc.Version
+	err = json.Unmarshal(versionBytes, &version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version in '%s': expected a JSON object of strings: %s", command.FromFile, err)
+	}
+
+	return version, nil
+}
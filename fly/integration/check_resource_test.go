@@ -1,8 +1,11 @@
 package integration_test
 
 import (
+	"io/ioutil"
 	"net/http"
+	"os"
 	"os/exec"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -101,6 +104,91 @@ var _ = Describe("CheckResource", func() {
 		})
 	})
 
+	Context("when the version is given in a file", func() {
+		var versionFile string
+
+		BeforeEach(func() {
+			dir, err := ioutil.TempDir("", "fly-check-resource")
+			Expect(err).NotTo(HaveOccurred())
+
+			versionFile = filepath.Join(dir, "version.json")
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(filepath.Dir(versionFile))).To(Succeed())
+		})
+
+		Context("when the file contains a JSON object", func() {
+			BeforeEach(func() {
+				err := ioutil.WriteFile(versionFile, []byte(`{"ref":"fake-ref","path":"some/path"}`), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				expectedURL := "/api/v1/teams/main/pipelines/mypipeline/resources/myresource/check"
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", expectedURL),
+						ghttp.VerifyJSON(`{"from":{"ref":"fake-ref","path":"some/path"}}`),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, ""),
+					),
+				)
+			})
+
+			It("checks from the version in the file", func() {
+				Expect(func() {
+					flyCmd = exec.Command(flyPath, "-t", targetName, "check-resource", "-r", "mypipeline/myresource", "--from-file", versionFile)
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+
+					Expect(sess.Out).To(gbytes.Say("checked 'myresource'"))
+
+				}).To(Change(func() int {
+					return len(atcServer.ReceivedRequests())
+				}).By(2))
+			})
+		})
+
+		Context("when the file is not valid JSON", func() {
+			BeforeEach(func() {
+				err := ioutil.WriteFile(versionFile, []byte(`{"ref":`), 0644)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("fails without checking", func() {
+				Expect(func() {
+					flyCmd = exec.Command(flyPath, "-t", targetName, "check-resource", "-r", "mypipeline/myresource", "--from-file", versionFile)
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(1))
+
+					Expect(sess.Err).To(gbytes.Say("invalid version in '.*version.json': expected a JSON object of strings"))
+
+				}).To(Change(func() int {
+					return len(atcServer.ReceivedRequests())
+				}).By(1))
+			})
+		})
+
+		Context("when --from is also given", func() {
+			BeforeEach(func() {
+				err := ioutil.WriteFile(versionFile, []byte(`{"ref":"fake-ref"}`), 0644)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("fails with an error", func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "check-resource", "-r", "mypipeline/myresource", "-f", "ref:other-ref", "--from-file", versionFile)
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+
+				Expect(sess.Err).To(gbytes.Say("only one of --from and --from-file may be given"))
+			})
+		})
+	})
+
 	Context("when pipeline or resource is not found", func() {
 		BeforeEach(func() {
 			expectedURL := "/api/v1/teams/main/pipelines/mypipeline/resources/myresource/check"
@@ -50,9 +50,17 @@ func (team *team) CheckResource(pipelineName string, resourceName string, versio
 				ExitStatus: checkRes.ExitStatus,
 				Output:     checkRes.Stderr,
 			}
-		case http.StatusInternalServerError:
+		case http.StatusUnprocessableEntity, http.StatusBadGateway, http.StatusInternalServerError:
+			var checkErr atc.CheckErrorResponseBody
+			err = json.Unmarshal([]byte(e.Body), &checkErr)
+			if err != nil || checkErr.Message == "" {
+				return false, GenericError{
+					e.Body,
+				}
+			}
+
 			return false, GenericError{
-				e.Body,
+				checkErr.Message,
 			}
 		default:
 			return false, err
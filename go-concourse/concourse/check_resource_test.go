@@ -103,4 +103,30 @@ var _ = Describe("CheckResource", func() {
 			Expect(cre.Error()).To(Equal("unknown server error"))
 		})
 	})
+
+	Context("when ATC responds with a check error code", func() {
+		BeforeEach(func() {
+			expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/resources/myresource/check"
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", expectedURL),
+					ghttp.VerifyJSON(`{"from":{"ref":"fake-ref"}}`),
+					ghttp.RespondWithJSONEncoded(http.StatusUnprocessableEntity, atc.CheckErrorResponseBody{
+						Code:    atc.CheckErrorCodeResourceTypeNotFound,
+						Message: "resource type not found",
+					}),
+				),
+			)
+		})
+
+		It("returns an error with the message", func() {
+			_, err := team.CheckResource("mypipeline", "myresource", atc.Version{"ref": "fake-ref"})
+			Expect(err).To(HaveOccurred())
+
+			cre, ok := err.(concourse.GenericError)
+			Expect(ok).To(BeTrue())
+			Expect(cre.Error()).To(Equal("resource type not found"))
+		})
+	})
 })
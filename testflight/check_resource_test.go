@@ -0,0 +1,32 @@
+package testflight_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	uuid "github.com/nu7hatch/gouuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Checking a resource from a version file", func() {
+	BeforeEach(func() {
+		u, err := uuid.NewV4()
+		Expect(err).ToNot(HaveOccurred())
+
+		setAndUnpausePipeline("fixtures/resource-version-every.yml", "-v", "hash="+u.String())
+	})
+
+	It("checks from the version in the file", func() {
+		versionFile := filepath.Join(tmp, "version.json")
+		err := ioutil.WriteFile(versionFile, []byte(`{"version":"from-file-version"}`), 0644)
+		Expect(err).ToNot(HaveOccurred())
+
+		checkS := fly("check-resource", "-r", inPipeline("some-resource"), "--from-file", versionFile)
+		Expect(checkS).To(gbytes.Say("checked 'some-resource'"))
+
+		watch := fly("trigger-job", "-j", inPipeline("some-passing-job"), "-w")
+		Expect(watch).To(gbytes.Say("from-file-version"))
+	})
+})
@@ -211,6 +211,15 @@ handleEvent event ( model, effects, outmsg ) =
             , outmsg
             )
 
+        ImageCheck _ _ _ ->
+            ( model, effects, outmsg )
+
+        ImageGet _ _ _ ->
+            ( model, effects, outmsg )
+
+        BuildSummary _ _ ->
+            ( model, effects, outmsg )
+
         BuildStatus status date ->
             let
                 newSt =
@@ -235,6 +244,9 @@ handleEvent event ( model, effects, outmsg ) =
         NetworkError ->
             ( model, effects, outmsg )
 
+        UnknownEvent _ ->
+            ( model, effects, outmsg )
+
 
 updateStep : StepID -> (StepTree -> StepTree) -> OutputModel -> OutputModel
 updateStep id update model =
@@ -130,11 +130,15 @@ type BuildEvent
     | InitializePut Origin Time.Posix
     | StartPut Origin Time.Posix
     | FinishPut Origin Int Concourse.Version Concourse.Metadata (Maybe Time.Posix)
+    | ImageCheck Origin Concourse.Version Time.Posix
+    | ImageGet Origin Concourse.Version Time.Posix
+    | BuildSummary Concourse.BuildStatus Time.Posix
     | Log Origin String (Maybe Time.Posix)
     | Error Origin String Time.Posix
     | End
     | Opened
     | NetworkError
+    | UnknownEvent String
 
 
 type alias Origin =
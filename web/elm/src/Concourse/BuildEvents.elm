@@ -151,8 +151,22 @@ decodeBuildEvent =
                     "finish-put" ->
                         Json.Decode.field "data" (decodeFinishResource FinishPut)
 
+                    "image-check" ->
+                        Json.Decode.field "data" (decodeImageResource ImageCheck)
+
+                    "image-get" ->
+                        Json.Decode.field "data" (decodeImageResource ImageGet)
+
+                    "build-summary" ->
+                        Json.Decode.field
+                            "data"
+                            (Json.Decode.map2 BuildSummary
+                                (Json.Decode.field "status" Concourse.decodeBuildStatus)
+                                (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
                     unknown ->
-                        Json.Decode.fail ("unknown event type: " ++ unknown)
+                        Json.Decode.succeed (UnknownEvent unknown)
             )
 
 
@@ -189,6 +203,25 @@ decodeFinishResource cons =
         (Json.Decode.maybe <| Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
 
 
+decodeImageResource :
+    (Origin
+     -> Concourse.Version
+     -> Time.Posix
+     -> a
+    )
+    -> Json.Decode.Decoder a
+decodeImageResource cons =
+    Json.Decode.map3 cons
+        (Json.Decode.field "origin" decodeOrigin)
+        (Json.Decode.map
+            (Maybe.withDefault Dict.empty)
+            << Json.Decode.maybe
+         <|
+            Json.Decode.field "version" Concourse.decodeVersion
+        )
+        (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+
+
 decodeErrorEvent : Json.Decode.Decoder BuildEvent
 decodeErrorEvent =
     Json.Decode.map3